@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/status"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	debugModeName = status.DebugModeName
+	// fieldManager identifies the plugin as creator of a DebugMode in its audit record.
+	fieldManager = "kubectl-debugmode"
+
+	defaultPollInterval = time.Second
+	defaultPollTimeout  = time.Minute
+)
+
+const (
+	actionChange      = "change"
+	actionKeep        = "keep"
+	actionUnsupported = "unsupported"
+	actionNotSelected = "not selected"
+)
+
+// debugModeCLI implements the commands of the plugin against the namespace of the ecosystem.
+type debugModeCLI struct {
+	namespace       string
+	debugModes      debugModeInterface
+	dogus           doguInterface
+	configMaps      configMapInterface
+	logLevelHandler logLevelHandler
+	statusReader    *status.Reader
+	printer         printer
+	now             func() time.Time
+	pollInterval    time.Duration
+	pollTimeout     time.Duration
+}
+
+// startOptions are the settings of a new debug mode.
+type startOptions struct {
+	duration time.Duration
+	level    string
+	dogus    string
+}
+
+// planOptions are the settings of a new debug mode and the settings of the operator that decide about its changes.
+type planOptions struct {
+	startOptions
+	raiseOnly    bool
+	vocabularies string
+}
+
+// start creates a new DebugMode. A completed DebugMode is replaced, an active one is never changed.
+func (c *debugModeCLI) start(ctx context.Context, opts startOptions) error {
+	level, err := parseTargetLevel(opts.level)
+	if err != nil {
+		return err
+	}
+	if opts.duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %s", opts.duration)
+	}
+
+	existing, err := c.debugModes.Get(ctx, debugModeName, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get debug mode: %w", err)
+	}
+	if err == nil {
+		if !isCompleted(existing) {
+			return fmt.Errorf("debug mode is already active in phase %s, use extend or stop instead", status.Phase(existing))
+		}
+		err = c.deleteCompleted(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	cr := &k8sCRLib.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{Name: debugModeName, Namespace: c.namespace},
+		Spec: k8sCRLib.DebugModeSpec{
+			DeactivateTimestamp: metav1.NewTime(c.now().Add(opts.duration)),
+			TargetLogLevel:      level.String(),
+		},
+	}
+	if dogus := controller.ParseDoguSelection(opts.dogus); len(dogus) > 0 {
+		cr.Annotations = map[string]string{controller.DogusAnnotation: strings.Join(dogus, ",")}
+	}
+
+	created, err := c.debugModes.Create(ctx, cr, metav1.CreateOptions{FieldManager: fieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to create debug mode: %w", err)
+	}
+	return c.printer.print(newDebugModeView(created, c.now()))
+}
+
+// deleteCompleted deletes the completed DebugMode and waits until it is gone, so a new one can be created.
+func (c *debugModeCLI) deleteCompleted(ctx context.Context) error {
+	err := c.debugModes.Delete(ctx, debugModeName, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete completed debug mode: %w", err)
+	}
+	err = wait.PollUntilContextTimeout(ctx, c.pollInterval, c.pollTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := c.debugModes.Get(ctx, debugModeName, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for deletion of completed debug mode: %w", err)
+	}
+	return nil
+}
+
+// status prints the DebugMode and the log levels of all elements recorded in its state map.
+func (c *debugModeCLI) status(ctx context.Context) error {
+	cr, err := c.debugModes.Get(ctx, debugModeName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("no debug mode found in namespace %s", c.namespace)
+		}
+		return fmt.Errorf("failed to get debug mode: %w", err)
+	}
+
+	view := statusView{debugModeView: newDebugModeView(cr, c.now())}
+	view.Elements, err = c.elementStates(ctx, cr)
+	if err != nil {
+		return err
+	}
+	return c.printer.print(view)
+}
+
+// elementStates reads the original log levels from the state map of the DebugMode and the current log levels of the dogus.
+func (c *debugModeCLI) elementStates(ctx context.Context, cr *k8sCRLib.DebugMode) ([]elementView, error) {
+	elements, err := c.statusReader.Elements(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]elementView, 0, len(elements))
+	for _, e := range elements {
+		views = append(views, elementView{Kind: e.Kind, Name: e.Name, Original: e.Original, Current: e.Current, Target: e.Target})
+	}
+	return views, nil
+}
+
+// extend moves the deactivation of an active DebugMode the given duration into the future.
+func (c *debugModeCLI) extend(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %s", duration)
+	}
+	return c.updateDeactivateTimestamp(ctx, func(current time.Time) time.Time {
+		return current.Add(duration)
+	})
+}
+
+// stop ends an active DebugMode immediately. The operator rolls back the log levels afterward.
+func (c *debugModeCLI) stop(ctx context.Context) error {
+	return c.updateDeactivateTimestamp(ctx, func(time.Time) time.Time {
+		return c.now()
+	})
+}
+
+func (c *debugModeCLI) updateDeactivateTimestamp(ctx context.Context, change func(current time.Time) time.Time) error {
+	var updated *k8sCRLib.DebugMode
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cr, err := c.debugModes.Get(ctx, debugModeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		err = checkActive(cr, c.now())
+		if err != nil {
+			return err
+		}
+		cr.Spec.DeactivateTimestamp = metav1.NewTime(change(cr.Spec.DeactivateTimestamp.Time))
+		updated, err = c.debugModes.Update(ctx, cr, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("no debug mode found in namespace %s", c.namespace)
+		}
+		return fmt.Errorf("failed to update debug mode: %w", err)
+	}
+	return c.printer.print(newDebugModeView(updated, c.now()))
+}
+
+// plan prints which dogus a debug mode with the given settings would change, without changing anything. The log levels
+// are compared like the operator does, so dogus are only reported as changed if the operator would change them.
+func (c *debugModeCLI) plan(ctx context.Context, opts planOptions) error {
+	level, err := parseTargetLevel(opts.level)
+	if err != nil {
+		return err
+	}
+	if opts.vocabularies != "" {
+		vocabularies, err := loglevel.ParseVocabularies(opts.vocabularies)
+		if err != nil {
+			return err
+		}
+		c.logLevelHandler.SetVocabularies(vocabularies)
+	}
+	selection := controller.ParseDoguSelection(opts.dogus)
+
+	list, err := c.dogus.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list dogus: %w", err)
+	}
+
+	view := planView{}
+	for _, dogu := range list.Items {
+		element := elementView{Kind: status.KindDogu, Name: dogu.Name, Target: level.String()}
+		current, err := c.logLevelHandler.GetLogLevel(ctx, dogu)
+		element.Current = current.String()
+		if err != nil {
+			element.Current = status.LevelUnknown
+		}
+		element.Action = c.planAction(ctx, dogu, current, level, opts.raiseOnly, selection)
+		view.Elements = append(view.Elements, element)
+	}
+	for _, name := range selection {
+		if !slices.ContainsFunc(list.Items, func(d v2.Dogu) bool { return d.Name == name }) {
+			view.UnknownDogus = append(view.UnknownDogus, name)
+		}
+	}
+	slices.SortFunc(view.Elements, func(a, b elementView) int { return strings.Compare(a.Name, b.Name) })
+	return c.printer.print(view)
+}
+
+// planAction returns what the operator would do with the dogu. Like the activation, a dogu already at the target log
+// level or kept with raise-only is never checked for the support of the target log level.
+func (c *debugModeCLI) planAction(ctx context.Context, dogu v2.Dogu, current loglevel.LogLevel, target loglevel.LogLevel, raiseOnly bool, selection []string) string {
+	if len(selection) > 0 && !slices.Contains(selection, dogu.Name) {
+		return actionNotSelected
+	}
+	if controller.KeepsMoreVerbose(raiseOnly, current, target) || current == target {
+		return actionKeep
+	}
+	supported, err := c.logLevelHandler.IsLogLevelSupported(ctx, dogu, target)
+	if err != nil || !supported {
+		return actionUnsupported
+	}
+	return actionChange
+}
+
+func parseTargetLevel(value string) (loglevel.LogLevel, error) {
+	level, err := loglevel.CreateLogLevelFromString(value)
+	if err != nil {
+		return level, fmt.Errorf("invalid log level %q: %w", value, err)
+	}
+	return level, nil
+}
+
+// checkActive returns an error if the DebugMode cannot be extended or stopped anymore.
+func checkActive(cr *k8sCRLib.DebugMode, now time.Time) error {
+	switch {
+	case isCompleted(cr):
+		return errors.New("debug mode is already completed, use start to begin a new one")
+	case cr.DeletionTimestamp != nil:
+		return errors.New("debug mode is being deleted")
+	case !cr.Spec.DeactivateTimestamp.After(now):
+		return fmt.Errorf("debug mode has already been deactivated and is in phase %s", status.Phase(cr))
+	}
+	return nil
+}
+
+func isCompleted(cr *k8sCRLib.DebugMode) bool {
+	if cr.Status.Phase == k8sCRLib.DebugModeStatusCompleted {
+		return true
+	}
+	for _, cond := range cr.Status.Conditions {
+		if cond.Reason == string(k8sCRLib.DebugModeStatusCompleted) {
+			return true
+		}
+	}
+	return false
+}
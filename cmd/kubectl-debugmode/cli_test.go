@@ -0,0 +1,342 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/status"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var testNow = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+var notFound = apierrors.NewNotFound(schema.GroupResource{Resource: "debugmodes"}, debugModeName)
+
+type cliMocks struct {
+	debugModes      *mockDebugModeInterface
+	dogus           *mockDoguInterface
+	configMaps      *mockConfigMapInterface
+	logLevelHandler *mockLogLevelHandler
+	out             *bytes.Buffer
+}
+
+func newTestCLI(t *testing.T, output string) (*debugModeCLI, cliMocks) {
+	m := cliMocks{
+		debugModes:      newMockDebugModeInterface(t),
+		dogus:           newMockDoguInterface(t),
+		configMaps:      newMockConfigMapInterface(t),
+		logLevelHandler: newMockLogLevelHandler(t),
+		out:             &bytes.Buffer{},
+	}
+	return &debugModeCLI{
+		namespace:       "ecosystem",
+		debugModes:      m.debugModes,
+		dogus:           m.dogus,
+		configMaps:      m.configMaps,
+		logLevelHandler: m.logLevelHandler,
+		statusReader:    status.NewReader(m.debugModes, m.configMaps, m.dogus, m.logLevelHandler, nil),
+		printer:         printer{out: m.out, output: output},
+		now:             func() time.Time { return testNow },
+		pollInterval:    time.Millisecond,
+		pollTimeout:     time.Second,
+	}, m
+}
+
+func newDebugMode(phase k8sCRLib.StatusPhase, deactivate time.Time) *k8sCRLib.DebugMode {
+	return &k8sCRLib.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{Name: debugModeName, UID: "uid-1"},
+		Spec:       k8sCRLib.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(deactivate), TargetLogLevel: "DEBUG"},
+		Status:     k8sCRLib.DebugModeStatus{Phase: phase},
+	}
+}
+
+func Test_debugModeCLI_start(t *testing.T) {
+	t.Run("should create debug mode with selected dogus", func(t *testing.T) {
+		// given
+		cli, m := newTestCLI(t, outputJSON)
+		m.debugModes.EXPECT().Get(t.Context(), debugModeName, metav1.GetOptions{}).Return(nil, notFound)
+		m.debugModes.EXPECT().Create(t.Context(), mock.Anything, metav1.CreateOptions{FieldManager: fieldManager}).
+			RunAndReturn(func(_ context.Context, cr *k8sCRLib.DebugMode, _ metav1.CreateOptions) (*k8sCRLib.DebugMode, error) {
+				return cr, nil
+			})
+
+		// when
+		err := cli.start(t.Context(), startOptions{duration: 2 * time.Hour, level: "debug", dogus: "ldap, cas"})
+
+		// then
+		require.NoError(t, err)
+		created := m.debugModes.Calls[1].Arguments.Get(1).(*k8sCRLib.DebugMode)
+		assert.Equal(t, "DEBUG", created.Spec.TargetLogLevel)
+		assert.Equal(t, testNow.Add(2*time.Hour), created.Spec.DeactivateTimestamp.Time)
+		assert.Equal(t, "cas,ldap", created.Annotations[controller.DogusAnnotation])
+		var v debugModeView
+		require.NoError(t, json.Unmarshal(m.out.Bytes(), &v))
+		assert.Equal(t, "2h0m0s", v.Remaining)
+	})
+	t.Run("should replace completed debug mode", func(t *testing.T) {
+		// given
+		cli, m := newTestCLI(t, outputTable)
+		m.debugModes.EXPECT().Get(mock.Anything, debugModeName, metav1.GetOptions{}).
+			Return(newDebugMode(k8sCRLib.DebugModeStatusCompleted, testNow.Add(-time.Hour)), nil).Once()
+		m.debugModes.EXPECT().Delete(t.Context(), debugModeName, metav1.DeleteOptions{}).Return(nil)
+		m.debugModes.EXPECT().Get(mock.Anything, debugModeName, metav1.GetOptions{}).Return(nil, notFound).Once()
+		m.debugModes.EXPECT().Create(t.Context(), mock.Anything, metav1.CreateOptions{FieldManager: fieldManager}).
+			RunAndReturn(func(_ context.Context, cr *k8sCRLib.DebugMode, _ metav1.CreateOptions) (*k8sCRLib.DebugMode, error) {
+				return cr, nil
+			})
+
+		// when
+		err := cli.start(t.Context(), startOptions{duration: time.Hour, level: "trace"})
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, m.out.String(), "TRACE")
+	})
+	t.Run("should refuse to change active debug mode", func(t *testing.T) {
+		// given
+		cli, m := newTestCLI(t, outputTable)
+		m.debugModes.EXPECT().Get(t.Context(), debugModeName, metav1.GetOptions{}).
+			Return(newDebugMode(k8sCRLib.DebugModeStatusWaitForRollback, testNow.Add(time.Hour)), nil)
+
+		// when
+		err := cli.start(t.Context(), startOptions{duration: time.Hour, level: "debug"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "already active in phase WaitForRollback")
+	})
+	t.Run("should fail on invalid log level", func(t *testing.T) {
+		cli, _ := newTestCLI(t, outputTable)
+
+		err := cli.start(t.Context(), startOptions{duration: time.Hour, level: "verbose"})
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid log level \"verbose\"")
+	})
+	t.Run("should fail on non-positive duration", func(t *testing.T) {
+		cli, _ := newTestCLI(t, outputTable)
+
+		err := cli.start(t.Context(), startOptions{level: "debug"})
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "duration must be positive")
+	})
+}
+
+func Test_debugModeCLI_status(t *testing.T) {
+	t.Run("should print original and current log levels", func(t *testing.T) {
+		// given
+		cli, m := newTestCLI(t, outputJSON)
+		m.debugModes.EXPECT().Get(t.Context(), debugModeName, metav1.GetOptions{}).
+			Return(newDebugMode(k8sCRLib.DebugModeStatusWaitForRollback, testNow.Add(30*time.Minute)), nil)
+		owner := []metav1.OwnerReference{{UID: "uid-1"}}
+		m.configMaps.EXPECT().List(t.Context(), metav1.ListOptions{LabelSelector: controller.StateMapSelector(debugModeName)}).
+			Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{
+				{ObjectMeta: metav1.ObjectMeta{OwnerReferences: owner}, Data: map[string]string{
+					"dogu.cas":                    "WARN",
+					"component.k8s-dogu-operator": "INFO",
+					"doguconfig.cas":              `{"version":0,"level":""}`,
+					"dogu.redmine":                `{"version":1,"level":"ERROR","checksum":"manipulated"}`,
+				}},
+				{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{UID: types.UID("other")}}}, Data: map[string]string{
+					"dogu.ldap": "INFO",
+				}},
+			}}, nil)
+		cas := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas"}}
+		m.dogus.EXPECT().List(t.Context(), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{cas}}, nil)
+		m.logLevelHandler.EXPECT().GetLogLevelState(t.Context(), cas).Return(loglevel.LogLevelState{Level: loglevel.LevelDebug}, nil)
+
+		// when
+		err := cli.status(t.Context())
+
+		// then
+		require.NoError(t, err)
+		var v statusView
+		require.NoError(t, json.Unmarshal(m.out.Bytes(), &v))
+		assert.Equal(t, "WaitForRollback", v.Phase)
+		assert.Equal(t, "30m0s", v.Remaining)
+		assert.Equal(t, []elementView{
+			{Kind: "component", Name: "k8s-dogu-operator", Original: "INFO", Target: "DEBUG"},
+			{Kind: "dogu", Name: "cas", Original: "WARN", Current: "DEBUG", Target: "DEBUG"},
+			{Kind: "dogu", Name: "redmine", Original: "invalid", Current: "uninstalled", Target: "DEBUG"},
+		}, v.Elements)
+	})
+	t.Run("should fail without debug mode", func(t *testing.T) {
+		cli, m := newTestCLI(t, outputTable)
+		m.debugModes.EXPECT().Get(t.Context(), debugModeName, metav1.GetOptions{}).Return(nil, notFound)
+
+		err := cli.status(t.Context())
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "no debug mode found in namespace ecosystem")
+	})
+}
+
+func Test_debugModeCLI_extend(t *testing.T) {
+	t.Run("should add duration to deactivate timestamp", func(t *testing.T) {
+		// given
+		cli, m := newTestCLI(t, outputTable)
+		m.debugModes.EXPECT().Get(t.Context(), debugModeName, metav1.GetOptions{}).
+			Return(newDebugMode(k8sCRLib.DebugModeStatusWaitForRollback, testNow.Add(time.Hour)), nil)
+		m.debugModes.EXPECT().Update(t.Context(), mock.Anything, metav1.UpdateOptions{}).
+			RunAndReturn(func(_ context.Context, cr *k8sCRLib.DebugMode, _ metav1.UpdateOptions) (*k8sCRLib.DebugMode, error) {
+				return cr, nil
+			})
+
+		// when
+		err := cli.extend(t.Context(), 30*time.Minute)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, m.out.String(), "1h30m0s")
+	})
+	t.Run("should refuse to extend completed debug mode", func(t *testing.T) {
+		cli, m := newTestCLI(t, outputTable)
+		m.debugModes.EXPECT().Get(t.Context(), debugModeName, metav1.GetOptions{}).
+			Return(newDebugMode(k8sCRLib.DebugModeStatusCompleted, testNow.Add(-time.Hour)), nil)
+
+		err := cli.extend(t.Context(), time.Hour)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "already completed")
+	})
+	t.Run("should refuse to extend deactivated debug mode", func(t *testing.T) {
+		cli, m := newTestCLI(t, outputTable)
+		m.debugModes.EXPECT().Get(t.Context(), debugModeName, metav1.GetOptions{}).
+			Return(newDebugMode(k8sCRLib.DebugModeStatusRollback, testNow.Add(-time.Minute)), nil)
+
+		err := cli.extend(t.Context(), time.Hour)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "already been deactivated and is in phase Rollback")
+	})
+}
+
+func Test_debugModeCLI_stop(t *testing.T) {
+	t.Run("should set deactivate timestamp to now", func(t *testing.T) {
+		// given
+		cli, m := newTestCLI(t, outputJSON)
+		m.debugModes.EXPECT().Get(t.Context(), debugModeName, metav1.GetOptions{}).
+			Return(newDebugMode(k8sCRLib.DebugModeStatusWaitForRollback, testNow.Add(time.Hour)), nil)
+		m.debugModes.EXPECT().Update(t.Context(), mock.Anything, metav1.UpdateOptions{}).
+			RunAndReturn(func(_ context.Context, cr *k8sCRLib.DebugMode, _ metav1.UpdateOptions) (*k8sCRLib.DebugMode, error) {
+				return cr, nil
+			})
+
+		// when
+		err := cli.stop(t.Context())
+
+		// then
+		require.NoError(t, err)
+		var v debugModeView
+		require.NoError(t, json.Unmarshal(m.out.Bytes(), &v))
+		assert.Equal(t, testNow, v.DeactivateTimestamp.UTC())
+		assert.Equal(t, "0s", v.Remaining)
+	})
+	t.Run("should fail without debug mode", func(t *testing.T) {
+		cli, m := newTestCLI(t, outputTable)
+		m.debugModes.EXPECT().Get(t.Context(), debugModeName, metav1.GetOptions{}).Return(nil, notFound)
+
+		err := cli.stop(t.Context())
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "no debug mode found")
+	})
+}
+
+func Test_debugModeCLI_plan(t *testing.T) {
+	t.Run("should print action for every dogu", func(t *testing.T) {
+		// given
+		cli, m := newTestCLI(t, outputJSON)
+		cas := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas"}}
+		ldap := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}}
+		nexus := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "nexus"}}
+		redmine := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "redmine"}}
+		m.dogus.EXPECT().List(t.Context(), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{redmine, nexus, ldap, cas}}, nil)
+		m.logLevelHandler.EXPECT().GetLogLevel(t.Context(), cas).Return(loglevel.LevelWarn, nil)
+		m.logLevelHandler.EXPECT().GetLogLevel(t.Context(), ldap).Return(loglevel.LevelInfo, nil)
+		m.logLevelHandler.EXPECT().GetLogLevel(t.Context(), nexus).Return(loglevel.LevelDebug, nil)
+		m.logLevelHandler.EXPECT().GetLogLevel(t.Context(), redmine).Return(loglevel.LevelInfo, nil)
+		m.logLevelHandler.EXPECT().IsLogLevelSupported(t.Context(), cas, loglevel.LevelDebug).Return(true, nil)
+		m.logLevelHandler.EXPECT().IsLogLevelSupported(t.Context(), ldap, loglevel.LevelDebug).Return(false, nil)
+
+		// when
+		err := cli.plan(t.Context(), planOptions{startOptions: startOptions{level: "debug", dogus: "cas,ldap,nexus,scm"}})
+
+		// then
+		require.NoError(t, err)
+		var v planView
+		require.NoError(t, json.Unmarshal(m.out.Bytes(), &v))
+		assert.Equal(t, []elementView{
+			{Kind: "dogu", Name: "cas", Current: "WARN", Target: "DEBUG", Action: actionChange},
+			{Kind: "dogu", Name: "ldap", Current: "INFO", Target: "DEBUG", Action: actionUnsupported},
+			{Kind: "dogu", Name: "nexus", Current: "DEBUG", Target: "DEBUG", Action: actionKeep},
+			{Kind: "dogu", Name: "redmine", Current: "INFO", Target: "DEBUG", Action: actionNotSelected},
+		}, v.Elements)
+		assert.Equal(t, []string{"scm"}, v.UnknownDogus)
+	})
+	t.Run("should keep more verbose dogus with raise-only", func(t *testing.T) {
+		// given
+		cli, m := newTestCLI(t, outputJSON)
+		cas := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas"}}
+		ldap := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}}
+		m.dogus.EXPECT().List(t.Context(), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{cas, ldap}}, nil)
+		m.logLevelHandler.EXPECT().GetLogLevel(t.Context(), cas).Return(loglevel.LevelTrace, nil)
+		m.logLevelHandler.EXPECT().GetLogLevel(t.Context(), ldap).Return(loglevel.LevelInfo, nil)
+		m.logLevelHandler.EXPECT().IsLogLevelSupported(t.Context(), ldap, loglevel.LevelDebug).Return(true, nil)
+
+		// when
+		err := cli.plan(t.Context(), planOptions{startOptions: startOptions{level: "debug"}, raiseOnly: true})
+
+		// then
+		require.NoError(t, err)
+		var v planView
+		require.NoError(t, json.Unmarshal(m.out.Bytes(), &v))
+		assert.Equal(t, []elementView{
+			{Kind: "dogu", Name: "cas", Current: "TRACE", Target: "DEBUG", Action: actionKeep},
+			{Kind: "dogu", Name: "ldap", Current: "INFO", Target: "DEBUG", Action: actionChange},
+		}, v.Elements)
+	})
+	t.Run("should read log levels with the vocabularies", func(t *testing.T) {
+		// given
+		cli, m := newTestCLI(t, outputJSON)
+		redmine := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "redmine"}}
+		m.logLevelHandler.EXPECT().SetVocabularies(map[string]loglevel.Vocabulary{"redmine": {loglevel.LevelWarn: "warning"}})
+		m.dogus.EXPECT().List(t.Context(), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{redmine}}, nil)
+		m.logLevelHandler.EXPECT().GetLogLevel(t.Context(), redmine).Return(loglevel.LevelWarn, nil)
+
+		// when
+		err := cli.plan(t.Context(), planOptions{startOptions: startOptions{level: "warn"}, vocabularies: `{"redmine": {"WARN": "warning"}}`})
+
+		// then
+		require.NoError(t, err)
+		var v planView
+		require.NoError(t, json.Unmarshal(m.out.Bytes(), &v))
+		assert.Equal(t, []elementView{{Kind: "dogu", Name: "redmine", Current: "WARN", Target: "WARN", Action: actionKeep}}, v.Elements)
+	})
+	t.Run("should fail for invalid vocabularies", func(t *testing.T) {
+		// given
+		cli, _ := newTestCLI(t, outputJSON)
+
+		// when
+		err := cli.plan(t.Context(), planOptions{startOptions: startOptions{level: "debug"}, vocabularies: `{"redmine": {"VERBOSE": "verbose"}}`})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `invalid log level "VERBOSE" in vocabulary of dogu redmine`)
+	})
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const defaultDuration = time.Hour
+
+func addStartFlags(cmd *cobra.Command, opts *startOptions) {
+	cmd.Flags().StringVar(&opts.level, "level", "DEBUG", "Target log level of the dogus and components")
+	cmd.Flags().StringVar(&opts.dogus, "dogus", "", "Comma separated list of dogus to change, all dogus if empty")
+}
+
+func newStartCommand(cli func() *debugModeCLI) *cobra.Command {
+	opts := startOptions{}
+	cmd := &cobra.Command{
+		Use:     "start",
+		Short:   "Start a new debug mode",
+		Example: "kubectl debugmode start --for 2h --level debug --dogus cas,ldap",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cli().start(cmd.Context(), opts)
+		},
+	}
+	cmd.Flags().DurationVar(&opts.duration, "for", defaultDuration, "Duration of the debug mode")
+	addStartFlags(cmd, &opts)
+	return cmd
+}
+
+func newStatusCommand(cli func() *debugModeCLI) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the debug mode and the log levels it changed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cli().status(cmd.Context())
+		},
+	}
+}
+
+func newExtendCommand(cli func() *debugModeCLI) *cobra.Command {
+	var duration time.Duration
+	cmd := &cobra.Command{
+		Use:     "extend",
+		Short:   "Extend the active debug mode",
+		Example: "kubectl debugmode extend --for 30m",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cli().extend(cmd.Context(), duration)
+		},
+	}
+	cmd.Flags().DurationVar(&duration, "for", defaultDuration, "Duration added to the deactivation time")
+	return cmd
+}
+
+func newStopCommand(cli func() *debugModeCLI) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "End the active debug mode and restore the original log levels",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cli().stop(cmd.Context())
+		},
+	}
+}
+
+func newPlanCommand(cli func() *debugModeCLI) *cobra.Command {
+	opts := planOptions{}
+	cmd := &cobra.Command{
+		Use:     "plan",
+		Short:   "Show which dogus a new debug mode would change without changing anything",
+		Example: "kubectl debugmode plan --level trace --dogus nexus",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cli().plan(cmd.Context(), opts)
+		},
+	}
+	addStartFlags(cmd, &opts.startOptions)
+	cmd.Flags().BoolVar(&opts.raiseOnly, "raise-only", false, "Keep dogus more verbose than the target log level like an operator with raise-only")
+	cmd.Flags().StringVar(&opts.vocabularies, "log-level-vocabularies", "",
+		`The log levels single dogus expect as JSON like the operator setting, e.g. {"redmine": {"WARN": "warning"}}`)
+	return cmd
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	libclient "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	typev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+type debugModeInterface interface {
+	libclient.DebugModeInterface
+}
+
+type doguInterface interface {
+	doguClient.DoguInterface
+}
+
+type configMapInterface interface {
+	typev1.ConfigMapInterface
+}
+
+type logLevelHandler interface {
+	loglevel.LogLevelHandler
+	// SetVocabularies sets the spellings of log levels for single dogus.
+	SetVocabularies(vocabularies map[string]loglevel.Vocabulary)
+}
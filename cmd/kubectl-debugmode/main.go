@@ -0,0 +1,14 @@
+// kubectl-debugmode starts, inspects and ends the debug mode of a Cloudogu EcoSystem.
+// Installed into the PATH it is available as "kubectl debugmode".
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	err := newRootCommand(os.Stdout).Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
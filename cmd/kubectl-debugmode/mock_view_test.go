@@ -0,0 +1,69 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package main
+
+import (
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockView is an autogenerated mock type for the view type
+type mockView struct {
+	mock.Mock
+}
+
+type mockView_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockView) EXPECT() *mockView_Expecter {
+	return &mockView_Expecter{mock: &_m.Mock}
+}
+
+// writeTable provides a mock function with given fields: w
+func (_m *mockView) writeTable(w io.Writer) {
+	_m.Called(w)
+}
+
+// mockView_writeTable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'writeTable'
+type mockView_writeTable_Call struct {
+	*mock.Call
+}
+
+// writeTable is a helper method to define mock.On call
+//   - w io.Writer
+func (_e *mockView_Expecter) writeTable(w interface{}) *mockView_writeTable_Call {
+	return &mockView_writeTable_Call{Call: _e.mock.On("writeTable", w)}
+}
+
+func (_c *mockView_writeTable_Call) Run(run func(w io.Writer)) *mockView_writeTable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *mockView_writeTable_Call) Return() *mockView_writeTable_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockView_writeTable_Call) RunAndReturn(run func(io.Writer)) *mockView_writeTable_Call {
+	_c.Run(run)
+	return _c
+}
+
+// newMockView creates a new instance of mockView. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockView(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockView {
+	mock := &mockView{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/status"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// view is a result of a command that can be printed as table.
+type view interface {
+	writeTable(w io.Writer)
+}
+
+// printer writes views as table or JSON.
+type printer struct {
+	out    io.Writer
+	output string
+}
+
+func (p printer) print(v view) error {
+	if p.output == outputJSON {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(v)
+		if err != nil {
+			return fmt.Errorf("failed to write json: %w", err)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	v.writeTable(w)
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write table: %w", err)
+	}
+	return nil
+}
+
+// debugModeView describes a DebugMode.
+type debugModeView struct {
+	Name                string    `json:"name"`
+	Phase               string    `json:"phase"`
+	TargetLogLevel      string    `json:"targetLogLevel"`
+	DeactivateTimestamp time.Time `json:"deactivateTimestamp"`
+	Remaining           string    `json:"remaining"`
+	Dogus               string    `json:"dogus,omitempty"`
+	Message             string    `json:"message,omitempty"`
+}
+
+func newDebugModeView(cr *k8sCRLib.DebugMode, now time.Time) debugModeView {
+	v := debugModeView{
+		Name:                cr.Name,
+		Phase:               status.Phase(cr),
+		TargetLogLevel:      cr.Spec.TargetLogLevel,
+		DeactivateTimestamp: cr.Spec.DeactivateTimestamp.Time,
+		Remaining:           "0s",
+		Dogus:               cr.Annotations[controller.DogusAnnotation],
+	}
+	if remaining := cr.Spec.DeactivateTimestamp.Sub(now); remaining > 0 {
+		v.Remaining = remaining.Round(time.Second).String()
+	}
+	if cond := meta.FindStatusCondition(cr.Status.Conditions, k8sCRLib.ConditionLogLevelSet); cond != nil {
+		v.Message = cond.Message
+	}
+	return v
+}
+
+func (v debugModeView) writeTable(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "NAME\tPHASE\tTARGET\tDEACTIVATE\tREMAINING\tDOGUS")
+	dogus := v.Dogus
+	if dogus == "" {
+		dogus = "all"
+	}
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", v.Name, v.Phase, v.TargetLogLevel,
+		v.DeactivateTimestamp.Local().Format(time.RFC3339), v.Remaining, dogus)
+	if v.Message != "" {
+		_, _ = fmt.Fprintf(w, "\nMESSAGE: %s\n", v.Message)
+	}
+}
+
+// elementView describes the log level of a single dogu or component.
+type elementView struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Original string `json:"original,omitempty"`
+	Current  string `json:"current,omitempty"`
+	Target   string `json:"target"`
+	Action   string `json:"action,omitempty"`
+}
+
+// statusView describes a DebugMode and the log levels it changed.
+type statusView struct {
+	debugModeView
+	Elements []elementView `json:"elements"`
+}
+
+func (v statusView) writeTable(w io.Writer) {
+	v.debugModeView.writeTable(w)
+	if len(v.Elements) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "\nKIND\tNAME\tORIGINAL\tCURRENT\tTARGET")
+	for _, e := range v.Elements {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Kind, e.Name, e.Original, orDash(e.Current), e.Target)
+	}
+}
+
+// planView describes the changes a new debug mode would make.
+type planView struct {
+	Elements     []elementView `json:"elements"`
+	UnknownDogus []string      `json:"unknownDogus,omitempty"`
+}
+
+func (v planView) writeTable(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "DOGU\tCURRENT\tTARGET\tACTION")
+	for _, e := range v.Elements {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.Name, e.Current, e.Target, e.Action)
+	}
+	for _, name := range v.UnknownDogus {
+		_, _ = fmt.Fprintf(w, "%s\t-\t-\tnot installed\n", name)
+	}
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
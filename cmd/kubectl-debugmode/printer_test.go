@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_printer_print(t *testing.T) {
+	t.Run("should print status as table", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		cr := newDebugMode(k8sCRLib.DebugModeStatusWaitForRollback, testNow.Add(time.Hour))
+		cr.Status.Conditions = []metav1.Condition{{Type: k8sCRLib.ConditionLogLevelSet, Message: "Debug-Mode set"}}
+		v := statusView{
+			debugModeView: newDebugModeView(cr, testNow),
+			Elements:      []elementView{{Kind: "dogu", Name: "cas", Original: "WARN", Current: "DEBUG", Target: "DEBUG"}},
+		}
+
+		// when
+		err := printer{out: out, output: outputTable}.print(v)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "debug-mode  WaitForRollback  DEBUG")
+		assert.Contains(t, out.String(), "1h0m0s     all")
+		assert.Contains(t, out.String(), "MESSAGE: Debug-Mode set")
+		assert.Contains(t, out.String(), "dogu  cas   WARN      DEBUG    DEBUG")
+	})
+	t.Run("should print json", func(t *testing.T) {
+		out := &bytes.Buffer{}
+
+		err := printer{out: out, output: outputJSON}.print(planView{UnknownDogus: []string{"scm"}})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"elements":null,"unknownDogus":["scm"]}`, out.String())
+	})
+}
+
+func Test_newRootCommand(t *testing.T) {
+	t.Run("should reject unknown output format", func(t *testing.T) {
+		// given
+		cmd := newRootCommand(&bytes.Buffer{})
+		cmd.SetArgs([]string{"status", "-o", "yaml"})
+		cmd.SetErr(&bytes.Buffer{})
+
+		// when
+		err := cmd.Execute()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unsupported output format \"yaml\"")
+	})
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	libclient "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/status"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"github.com/cloudogu/k8s-registry-lib/dogu"
+	"github.com/cloudogu/k8s-registry-lib/repository"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// newRootCommand creates the kubectl-debugmode command with all sub commands writing to the given output.
+func newRootCommand(out io.Writer) *cobra.Command {
+	configFlags := genericclioptions.NewConfigFlags(true)
+	output := outputTable
+	var cli *debugModeCLI
+
+	root := &cobra.Command{
+		Use:          "kubectl-debugmode",
+		Short:        "Start, inspect and end the debug mode of the Cloudogu EcoSystem",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if output != outputTable && output != outputJSON {
+				return fmt.Errorf("unsupported output format %q, expected %s or %s", output, outputTable, outputJSON)
+			}
+			var err error
+			cli, err = newDebugModeCLI(configFlags, out, output)
+			return err
+		},
+	}
+	configFlags.AddFlags(root.PersistentFlags())
+	root.PersistentFlags().StringVarP(&output, "output", "o", outputTable, "Output format, one of table or json")
+
+	getCLI := func() *debugModeCLI { return cli }
+	root.AddCommand(
+		newStartCommand(getCLI),
+		newStatusCommand(getCLI),
+		newExtendCommand(getCLI),
+		newStopCommand(getCLI),
+		newPlanCommand(getCLI),
+	)
+	return root
+}
+
+// newDebugModeCLI creates the clients for the namespace of the current kubeconfig context or of the --namespace flag.
+func newDebugModeCLI(configFlags *genericclioptions.ConfigFlags, out io.Writer, output string) (*debugModeCLI, error) {
+	restConfig, err := configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	debugModeClient, err := libclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debug mode client: %w", err)
+	}
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	doguClientSet, err := doguClient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dogu client: %w", err)
+	}
+
+	configMaps := clientSet.CoreV1().ConfigMaps(namespace)
+	doguDescriptorGetter := controller.NewDoguGetter(
+		dogu.NewDoguVersionRegistry(configMaps),
+		dogu.NewLocalDoguDescriptorRepository(configMaps),
+	)
+
+	debugModes := debugModeClient.DebugMode(namespace)
+	dogus := doguClientSet.Dogus(namespace)
+	logLevels := loglevel.NewDoguLogLevelHandler(repository.NewDoguConfigRepository(configMaps), doguDescriptorGetter)
+
+	return &debugModeCLI{
+		namespace:       namespace,
+		debugModes:      debugModes,
+		dogus:           dogus,
+		configMaps:      configMaps,
+		logLevelHandler: logLevels,
+		statusReader:    status.NewReader(debugModes, configMaps, dogus, logLevels, nil),
+		printer:         printer{out: out, output: output},
+		now:             time.Now,
+		pollInterval:    defaultPollInterval,
+		pollTimeout:     defaultPollTimeout,
+	}, nil
+}
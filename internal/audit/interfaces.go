@@ -0,0 +1,9 @@
+package audit
+
+import (
+	typev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+type configMapInterface interface {
+	typev1.ConfigMapInterface
+}
@@ -0,0 +1,138 @@
+// Package audit keeps a bounded history of all debug mode sessions, which outlives the DebugMode and its state map.
+package audit
+
+import (
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RequestedByAnnotation names the user who requested the debug mode. Clients like the admin dogu set it, because
+	// the operator only knows the field manager of the DebugMode otherwise.
+	RequestedByAnnotation = "debugmode.k8s.cloudogu.com/requested-by"
+
+	recordVersion = 1
+	// maxFailures is the number of distinct failures kept per session.
+	maxFailures = 20
+)
+
+// Result describes how a debug mode session ended.
+type Result string
+
+const (
+	// ResultCompleted is set if all log levels have been restored after the deactivation.
+	ResultCompleted Result = "Completed"
+	// ResultDeleted is set if all log levels have been restored after the DebugMode was deleted.
+	ResultDeleted Result = "Deleted"
+	// ResultForceDeleted is set if the DebugMode was released without restoring the log levels.
+	ResultForceDeleted Result = "ForceDeleted"
+	// ResultRecovered is set if the log levels have been restored on the start of the operator.
+	ResultRecovered Result = "Recovered"
+)
+
+// Record is the audit record of a single debug mode session.
+type Record struct {
+	Version int `json:"version"`
+	// UID is the UID of the DebugMode of the session.
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+	// RequestedBy is the user of the annotation debugmode.k8s.cloudogu.com/requested-by or the field manager that
+	// created the DebugMode.
+	RequestedBy         string    `json:"requestedBy,omitempty"`
+	TargetLogLevel      string    `json:"targetLogLevel"`
+	StartedAt           time.Time `json:"startedAt"`
+	DeactivateTimestamp time.Time `json:"deactivateTimestamp"`
+	// Extensions are the changes of the deactivate timestamp during the session, e.g. extensions or an early stop.
+	Extensions []Extension `json:"extensions,omitempty"`
+	// Changes are the elements changed by the debug mode with their original log level.
+	Changes  []Change  `json:"changes,omitempty"`
+	Failures []Failure `json:"failures,omitempty"`
+	// EndedAt is empty while the session is active.
+	EndedAt *time.Time `json:"endedAt,omitempty"`
+	Result  Result     `json:"result,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+// Extension is a change of the deactivate timestamp.
+type Extension struct {
+	At   time.Time `json:"at"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Change is an element changed by the debug mode.
+type Change struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Original string `json:"original,omitempty"`
+	Target   string `json:"target,omitempty"`
+	// Keys are the dogu config keys overridden by the debug mode.
+	Keys []string `json:"keys,omitempty"`
+}
+
+// Failure is an error of the session. Repeated errors with the same message are counted instead of added.
+type Failure struct {
+	At      time.Time `json:"at"`
+	LastAt  time.Time `json:"lastAt"`
+	Count   int       `json:"count"`
+	Message string    `json:"message"`
+}
+
+// newRecord creates the record of a session of the DebugMode started at the given time.
+func newRecord(cr *k8sCRLib.DebugMode, startedAt time.Time) Record {
+	return Record{
+		Version:             recordVersion,
+		UID:                 string(cr.UID),
+		Name:                cr.Name,
+		RequestedBy:         RequestedBy(cr),
+		TargetLogLevel:      cr.Spec.TargetLogLevel,
+		StartedAt:           startedAt,
+		DeactivateTimestamp: cr.Spec.DeactivateTimestamp.Time,
+	}
+}
+
+// RequestedBy returns the user of the annotation or the field manager that created the DebugMode.
+func RequestedBy(cr *k8sCRLib.DebugMode) string {
+	if user := cr.Annotations[RequestedByAnnotation]; user != "" {
+		return user
+	}
+	// the creation is recorded as the earliest entry, as managed fields do not distinguish creations from updates
+	var creator *metav1.ManagedFieldsEntry
+	for i, field := range cr.ManagedFields {
+		if field.Subresource != "" || field.Time == nil {
+			continue
+		}
+		if creator == nil || field.Time.Before(creator.Time) {
+			creator = &cr.ManagedFields[i]
+		}
+	}
+	if creator == nil {
+		return ""
+	}
+	return creator.Manager
+}
+
+// reschedule records a changed deactivate timestamp and returns whether the record has changed.
+func (r *Record) reschedule(deactivate time.Time, now time.Time) bool {
+	if r.EndedAt != nil || r.DeactivateTimestamp.Equal(deactivate) {
+		return false
+	}
+	r.Extensions = append(r.Extensions, Extension{At: now, From: r.DeactivateTimestamp, To: deactivate})
+	r.DeactivateTimestamp = deactivate
+	return true
+}
+
+// addFailure records the error message, counting repeated messages.
+func (r *Record) addFailure(message string, now time.Time) {
+	if last := len(r.Failures) - 1; last >= 0 && r.Failures[last].Message == message {
+		r.Failures[last].LastAt = now
+		r.Failures[last].Count++
+		return
+	}
+	r.Failures = append(r.Failures, Failure{At: now, LastAt: now, Count: 1, Message: message})
+	if len(r.Failures) > maxFailures {
+		r.Failures = r.Failures[len(r.Failures)-maxFailures:]
+	}
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequestedBy(t *testing.T) {
+	created := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	updated := metav1.NewTime(created.Add(time.Hour))
+	managedFields := []metav1.ManagedFieldsEntry{
+		{Manager: "k8s-debug-mode-operator", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status", Time: &created},
+		{Manager: "admin-dogu", Operation: metav1.ManagedFieldsOperationUpdate, Time: &updated},
+		{Manager: "kubectl-debugmode", Operation: metav1.ManagedFieldsOperationUpdate, Time: &created},
+	}
+
+	t.Run("should use user of annotation", func(t *testing.T) {
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{
+			Annotations:   map[string]string{RequestedByAnnotation: "jane"},
+			ManagedFields: managedFields,
+		}}
+
+		assert.Equal(t, "jane", RequestedBy(cr))
+	})
+	t.Run("should use field manager that created the debug mode", func(t *testing.T) {
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{ManagedFields: managedFields}}
+
+		assert.Equal(t, "kubectl-debugmode", RequestedBy(cr))
+	})
+	t.Run("should be empty without managed fields", func(t *testing.T) {
+		assert.Empty(t, RequestedBy(&k8sCRLib.DebugMode{}))
+	})
+}
+
+func TestRecord_reschedule(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := start.Add(time.Minute)
+
+	t.Run("should record extension", func(t *testing.T) {
+		// given
+		record := &Record{DeactivateTimestamp: start.Add(time.Hour)}
+
+		// when
+		changed := record.reschedule(start.Add(2*time.Hour), now)
+
+		// then
+		assert.True(t, changed)
+		assert.Equal(t, start.Add(2*time.Hour), record.DeactivateTimestamp)
+		assert.Equal(t, []Extension{{At: now, From: start.Add(time.Hour), To: start.Add(2 * time.Hour)}}, record.Extensions)
+	})
+	t.Run("should ignore unchanged deactivate timestamp", func(t *testing.T) {
+		record := &Record{DeactivateTimestamp: start}
+
+		assert.False(t, record.reschedule(start, now))
+		assert.Empty(t, record.Extensions)
+	})
+	t.Run("should ignore ended session", func(t *testing.T) {
+		record := &Record{DeactivateTimestamp: start, EndedAt: &now}
+
+		assert.False(t, record.reschedule(start.Add(time.Hour), now))
+	})
+}
+
+func TestRecord_addFailure(t *testing.T) {
+	first := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+
+	t.Run("should count repeated failure", func(t *testing.T) {
+		// given
+		record := &Record{}
+
+		// when
+		record.addFailure("failed", first)
+		record.addFailure("failed", second)
+
+		// then
+		assert.Equal(t, []Failure{{At: first, LastAt: second, Count: 2, Message: "failed"}}, record.Failures)
+	})
+	t.Run("should keep latest failures only", func(t *testing.T) {
+		// given
+		record := &Record{}
+
+		// when
+		for i := range maxFailures + 5 {
+			record.addFailure(fmt.Sprintf("failure %d", i), first)
+		}
+
+		// then
+		assert.Len(t, record.Failures, maxFailures)
+		assert.Equal(t, "failure 5", record.Failures[0].Message)
+		assert.Equal(t, fmt.Sprintf("failure %d", maxFailures+4), record.Failures[maxFailures-1].Message)
+	})
+}
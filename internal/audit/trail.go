@@ -0,0 +1,260 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// DefaultRetention is the number of sessions kept by default.
+	DefaultRetention = 50
+
+	auditMapPrefix = "debugmode-audit"
+	auditLabel     = "debugmode.k8s.cloudogu.com/audit"
+	recordKey      = "record"
+)
+
+// Trail writes one audit record per debug mode session into its own ConfigMap. The ConfigMaps are not owned by the
+// DebugMode, so they outlive it; only the latest sessions up to the retention are kept.
+type Trail struct {
+	configMaps configMapInterface
+	retention  int
+	now        func() time.Time
+}
+
+// NewTrail creates a trail keeping the given number of sessions.
+func NewTrail(configMaps configMapInterface, retention int) *Trail {
+	return &Trail{configMaps: configMaps, retention: max(retention, 1), now: time.Now}
+}
+
+func auditMapName(uid types.UID) string {
+	return fmt.Sprintf("%s-%s", auditMapPrefix, uid)
+}
+
+// Observe creates the record of a new session or records a changed deactivate timestamp of a running one.
+func (t *Trail) Observe(ctx context.Context, cr *k8sCRLib.DebugMode) error {
+	_, err := t.configMaps.Get(ctx, auditMapName(cr.UID), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return t.create(ctx, cr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get audit record of %s: %w", cr.Name, err)
+	}
+
+	return t.update(ctx, cr.UID, func(record *Record) bool {
+		return record.reschedule(cr.Spec.DeactivateTimestamp.Time, t.now())
+	})
+}
+
+// RecordChanges replaces the changed elements of the session.
+func (t *Trail) RecordChanges(ctx context.Context, uid types.UID, changes []Change) error {
+	return t.update(ctx, uid, func(record *Record) bool {
+		if record.EndedAt != nil || slices.EqualFunc(record.Changes, changes, equalChange) {
+			return false
+		}
+		record.Changes = changes
+		return true
+	})
+}
+
+// RecordFailure adds an error of the session.
+func (t *Trail) RecordFailure(ctx context.Context, uid types.UID, failure error) error {
+	return t.update(ctx, uid, func(record *Record) bool {
+		record.addFailure(failure.Error(), t.now())
+		return true
+	})
+}
+
+// RecordEnd closes the record of the session. Later calls do not change it anymore.
+func (t *Trail) RecordEnd(ctx context.Context, uid types.UID, result Result, message string) error {
+	return t.update(ctx, uid, func(record *Record) bool {
+		if record.EndedAt != nil {
+			return false
+		}
+		now := t.now()
+		record.EndedAt = &now
+		record.Result = result
+		record.Message = message
+		return true
+	})
+}
+
+// Archive moves the record of a finished session of the re-armed DebugMode aside and starts the record of its next
+// session at the time of the re-arm, since the DebugMode keeps its creation timestamp. The archived record is kept and
+// pruned like any other.
+func (t *Trail) Archive(ctx context.Context, cr *k8sCRLib.DebugMode) error {
+	uid := cr.UID
+	cm, err := t.configMaps.Get(ctx, auditMapName(uid), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get audit record of session %s: %w", uid, err)
+	}
+	record, err := parseRecord(cm)
+	if err != nil {
+		return fmt.Errorf("failed to archive audit record of session %s: %w", uid, err)
+	}
+
+	endedAt := t.now()
+	if record.EndedAt != nil {
+		endedAt = *record.EndedAt
+	}
+	archived := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s-%d", auditMapName(uid), endedAt.Unix()),
+			Labels: map[string]string{auditLabel: "true"},
+		},
+		Data: cm.Data,
+	}
+	_, err = t.configMaps.Create(ctx, archived, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to archive audit record of session %s: %w", uid, err)
+	}
+
+	data, err := json.Marshal(newRecord(cr, t.now()))
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	cm.Data = map[string]string{recordKey: string(data)}
+	_, err = t.configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to start audit record of re-armed session %s: %w", uid, err)
+	}
+	logging.FromContext(ctx).Info("Audit record archived", "session", uid, "configMap", archived.Name)
+	return nil
+}
+
+// List returns all kept records, the latest session first.
+func (t *Trail) List(ctx context.Context) ([]Record, error) {
+	list, err := t.configMaps.List(ctx, metav1.ListOptions{LabelSelector: auditLabel + "=true"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit records: %w", err)
+	}
+
+	records := make([]Record, 0, len(list.Items))
+	for _, cm := range list.Items {
+		record, err := parseRecord(&cm)
+		if err != nil {
+			logging.FromContext(ctx).Error("ERROR: skip audit record", "record", cm.Name, "error", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	slices.SortFunc(records, func(a, b Record) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	return records, nil
+}
+
+func (t *Trail) create(ctx context.Context, cr *k8sCRLib.DebugMode) error {
+	startedAt := cr.CreationTimestamp.Time
+	if startedAt.IsZero() {
+		startedAt = t.now()
+	}
+	record := newRecord(cr, startedAt)
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   auditMapName(cr.UID),
+			Labels: map[string]string{auditLabel: "true"},
+		},
+		Data: map[string]string{recordKey: string(data)},
+	}
+	_, err = t.configMaps.Create(ctx, cm, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create audit record of %s: %w", cr.Name, err)
+	}
+	logging.FromContext(ctx).Info("Audit record created", "session", cr.UID, "requestedBy", record.RequestedBy)
+
+	return t.prune(ctx)
+}
+
+// update applies the change to the record of the session. A session without a record, e.g. started before the audit
+// trail was enabled, is ignored.
+func (t *Trail) update(ctx context.Context, uid types.UID, change func(record *Record) bool) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := t.configMaps.Get(ctx, auditMapName(uid), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		record, err := parseRecord(cm)
+		if err != nil {
+			return err
+		}
+		if !change(&record) {
+			return nil
+		}
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit record: %w", err)
+		}
+		cm.Data[recordKey] = string(data)
+		_, err = t.configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update audit record of session %s: %w", uid, err)
+	}
+	return nil
+}
+
+// prune deletes the records of the oldest sessions exceeding the retention.
+func (t *Trail) prune(ctx context.Context) error {
+	list, err := t.configMaps.List(ctx, metav1.ListOptions{LabelSelector: auditLabel + "=true"})
+	if err != nil {
+		return fmt.Errorf("failed to list audit records: %w", err)
+	}
+	if len(list.Items) <= t.retention {
+		return nil
+	}
+
+	items := list.Items
+	slices.SortFunc(items, func(a, b corev1.ConfigMap) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, cm := range items[:len(items)-t.retention] {
+		err = t.configMaps.Delete(ctx, cm.Name, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete audit record %s: %w", cm.Name, err)
+		}
+		logging.FromContext(ctx).Info("Audit record deleted by retention", "configMap", cm.Name)
+	}
+	return nil
+}
+
+func parseRecord(cm *corev1.ConfigMap) (Record, error) {
+	var record Record
+	err := json.Unmarshal([]byte(cm.Data[recordKey]), &record)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to unmarshal audit record: %w", err)
+	}
+	return record, nil
+}
+
+func equalChange(a, b Change) bool {
+	return a.Kind == b.Kind && a.Name == b.Name && a.Original == b.Original && a.Target == b.Target && slices.Equal(a.Keys, b.Keys)
+}
@@ -0,0 +1,309 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testNow = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+const testAuditMapName = "debugmode-audit-uid-1"
+
+var auditListOptions = metav1.ListOptions{LabelSelector: "debugmode.k8s.cloudogu.com/audit=true"}
+
+func newTestTrail(t *testing.T, retention int) (*Trail, *mockConfigMapInterface) {
+	configMaps := newMockConfigMapInterface(t)
+	trail := NewTrail(configMaps, retention)
+	trail.now = func() time.Time { return testNow }
+	return trail, configMaps
+}
+
+func newTestDebugMode() *k8sCRLib.DebugMode {
+	return &k8sCRLib.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "debug-mode",
+			UID:               "uid-1",
+			CreationTimestamp: metav1.NewTime(testNow.Add(-time.Minute)),
+			Annotations:       map[string]string{RequestedByAnnotation: "jane"},
+		},
+		Spec: k8sCRLib.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(testNow.Add(time.Hour)), TargetLogLevel: "DEBUG"},
+	}
+}
+
+func auditMap(t *testing.T, name string, record Record) *corev1.ConfigMap {
+	data, err := json.Marshal(record)
+	require.NoError(t, err)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{auditLabel: "true"}},
+		Data:       map[string]string{recordKey: string(data)},
+	}
+}
+
+func recordOf(t *testing.T, cm *corev1.ConfigMap) Record {
+	record, err := parseRecord(cm)
+	require.NoError(t, err)
+	return record
+}
+
+func TestTrail_Observe(t *testing.T) {
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, testAuditMapName)
+
+	t.Run("should create record of new session and prune old sessions", func(t *testing.T) {
+		// given
+		trail, configMaps := newTestTrail(t, 2)
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).Return(nil, notFound)
+		var created *corev1.ConfigMap
+		configMaps.EXPECT().Create(t.Context(), mock.Anything, metav1.CreateOptions{}).
+			RunAndReturn(func(_ context.Context, cm *corev1.ConfigMap, _ metav1.CreateOptions) (*corev1.ConfigMap, error) {
+				created = cm
+				return cm, nil
+			})
+		oldest := auditMap(t, "debugmode-audit-a", Record{})
+		oldest.CreationTimestamp = metav1.NewTime(testNow.Add(-48 * time.Hour))
+		older := auditMap(t, "debugmode-audit-b", Record{})
+		older.CreationTimestamp = metav1.NewTime(testNow.Add(-24 * time.Hour))
+		current := auditMap(t, testAuditMapName, Record{})
+		current.CreationTimestamp = metav1.NewTime(testNow)
+		configMaps.EXPECT().List(t.Context(), auditListOptions).
+			Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*current, *older, *oldest}}, nil)
+		configMaps.EXPECT().Delete(t.Context(), "debugmode-audit-a", metav1.DeleteOptions{}).Return(nil)
+
+		// when
+		err := trail.Observe(t.Context(), newTestDebugMode())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testAuditMapName, created.Name)
+		assert.Equal(t, "true", created.Labels[auditLabel])
+		assert.Equal(t, Record{
+			Version:             recordVersion,
+			UID:                 "uid-1",
+			Name:                "debug-mode",
+			RequestedBy:         "jane",
+			TargetLogLevel:      "DEBUG",
+			StartedAt:           testNow.Add(-time.Minute),
+			DeactivateTimestamp: testNow.Add(time.Hour),
+		}, recordOf(t, created))
+	})
+	t.Run("should record extension of running session", func(t *testing.T) {
+		// given
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		cm := auditMap(t, testAuditMapName, Record{UID: "uid-1", DeactivateTimestamp: testNow.Add(30 * time.Minute)})
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).Return(cm, nil)
+		configMaps.EXPECT().Update(t.Context(), cm, metav1.UpdateOptions{}).Return(cm, nil)
+
+		// when
+		err := trail.Observe(t.Context(), newTestDebugMode())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []Extension{{At: testNow, From: testNow.Add(30 * time.Minute), To: testNow.Add(time.Hour)}}, recordOf(t, cm).Extensions)
+	})
+	t.Run("should not update unchanged session", func(t *testing.T) {
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		cm := auditMap(t, testAuditMapName, Record{UID: "uid-1", DeactivateTimestamp: testNow.Add(time.Hour)})
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).Return(cm, nil)
+
+		err := trail.Observe(t.Context(), newTestDebugMode())
+
+		require.NoError(t, err)
+	})
+	t.Run("should fail to create record", func(t *testing.T) {
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).Return(nil, notFound)
+		configMaps.EXPECT().Create(t.Context(), mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+
+		err := trail.Observe(t.Context(), newTestDebugMode())
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to create audit record of debug-mode")
+	})
+}
+
+func TestTrail_RecordEnd(t *testing.T) {
+	t.Run("should close record once", func(t *testing.T) {
+		// given
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		cm := auditMap(t, testAuditMapName, Record{UID: "uid-1"})
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).Return(cm, nil)
+		configMaps.EXPECT().Update(t.Context(), cm, metav1.UpdateOptions{}).Return(cm, nil).Once()
+
+		// when
+		err := trail.RecordEnd(t.Context(), "uid-1", ResultCompleted, "Debug-Mode deactivated")
+		require.NoError(t, err)
+		err = trail.RecordEnd(t.Context(), "uid-1", ResultForceDeleted, "later")
+
+		// then
+		require.NoError(t, err)
+		record := recordOf(t, cm)
+		assert.Equal(t, ResultCompleted, record.Result)
+		assert.Equal(t, "Debug-Mode deactivated", record.Message)
+		assert.Equal(t, testNow, *record.EndedAt)
+	})
+	t.Run("should ignore session without record", func(t *testing.T) {
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).
+			Return(nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, testAuditMapName))
+
+		err := trail.RecordEnd(t.Context(), "uid-1", ResultCompleted, "")
+
+		require.NoError(t, err)
+	})
+	t.Run("should retry on conflict", func(t *testing.T) {
+		// given
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		cm := auditMap(t, testAuditMapName, Record{UID: "uid-1"})
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).
+			RunAndReturn(func(context.Context, string, metav1.GetOptions) (*corev1.ConfigMap, error) {
+				return cm.DeepCopy(), nil
+			})
+		configMaps.EXPECT().Update(t.Context(), mock.Anything, metav1.UpdateOptions{}).
+			Return(nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, testAuditMapName, assert.AnError)).Once()
+		configMaps.EXPECT().Update(t.Context(), mock.Anything, metav1.UpdateOptions{}).Return(cm, nil).Once()
+
+		// when
+		err := trail.RecordEnd(t.Context(), "uid-1", ResultCompleted, "")
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func TestTrail_RecordChangesAndFailure(t *testing.T) {
+	t.Run("should record changes and failures", func(t *testing.T) {
+		// given
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		cm := auditMap(t, testAuditMapName, Record{UID: "uid-1"})
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).Return(cm, nil)
+		configMaps.EXPECT().Update(t.Context(), cm, metav1.UpdateOptions{}).Return(cm, nil).Twice()
+		changes := []Change{{Kind: "dogu", Name: "cas", Original: "WARN", Target: "DEBUG"}}
+
+		// when
+		err := trail.RecordChanges(t.Context(), "uid-1", changes)
+		require.NoError(t, err)
+		err = trail.RecordChanges(t.Context(), "uid-1", changes)
+		require.NoError(t, err)
+		err = trail.RecordFailure(t.Context(), "uid-1", assert.AnError)
+
+		// then
+		require.NoError(t, err)
+		record := recordOf(t, cm)
+		assert.Equal(t, changes, record.Changes)
+		assert.Equal(t, []Failure{{At: testNow, LastAt: testNow, Count: 1, Message: assert.AnError.Error()}}, record.Failures)
+	})
+}
+
+func TestTrail_Archive(t *testing.T) {
+	t.Run("should move record of finished session aside", func(t *testing.T) {
+		// given
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		endedAt := testNow.Add(-time.Hour)
+		cm := auditMap(t, testAuditMapName, Record{UID: "uid-1", EndedAt: &endedAt, Result: ResultCompleted})
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).Return(cm, nil)
+		configMaps.EXPECT().Create(t.Context(), mock.Anything, metav1.CreateOptions{}).
+			RunAndReturn(func(_ context.Context, archived *corev1.ConfigMap, _ metav1.CreateOptions) (*corev1.ConfigMap, error) {
+				assert.Equal(t, "debugmode-audit-uid-1-1767258000", archived.Name)
+				assert.Equal(t, "true", archived.Labels[auditLabel])
+				assert.Equal(t, ResultCompleted, recordOf(t, archived).Result)
+				return archived, nil
+			})
+		configMaps.EXPECT().Update(t.Context(), cm, metav1.UpdateOptions{}).Return(cm, nil)
+		rearmed := newTestDebugMode()
+		rearmed.CreationTimestamp = metav1.NewTime(testNow.Add(-48 * time.Hour))
+
+		// when
+		err := trail.Archive(t.Context(), rearmed)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Record{
+			Version:             recordVersion,
+			UID:                 "uid-1",
+			Name:                "debug-mode",
+			RequestedBy:         "jane",
+			TargetLogLevel:      "DEBUG",
+			StartedAt:           testNow,
+			DeactivateTimestamp: testNow.Add(time.Hour),
+		}, recordOf(t, cm))
+	})
+	t.Run("should ignore session without record", func(t *testing.T) {
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).
+			Return(nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, testAuditMapName))
+
+		err := trail.Archive(t.Context(), newTestDebugMode())
+
+		require.NoError(t, err)
+	})
+	t.Run("should keep record if the copy cannot be created", func(t *testing.T) {
+		// given
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		cm := auditMap(t, testAuditMapName, Record{UID: "uid-1"})
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).Return(cm, nil)
+		configMaps.EXPECT().Create(t.Context(), mock.Anything, metav1.CreateOptions{}).Return(nil, assert.AnError)
+
+		// when
+		err := trail.Archive(t.Context(), newTestDebugMode())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should fail if the record of the next session cannot be started", func(t *testing.T) {
+		// given
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		cm := auditMap(t, testAuditMapName, Record{UID: "uid-1"})
+		configMaps.EXPECT().Get(t.Context(), testAuditMapName, metav1.GetOptions{}).Return(cm, nil)
+		configMaps.EXPECT().Create(t.Context(), mock.Anything, metav1.CreateOptions{}).Return(&corev1.ConfigMap{}, nil)
+		configMaps.EXPECT().Update(t.Context(), cm, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+
+		// when
+		err := trail.Archive(t.Context(), newTestDebugMode())
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to start audit record of re-armed session uid-1")
+	})
+}
+
+func TestTrail_List(t *testing.T) {
+	t.Run("should list records with latest session first", func(t *testing.T) {
+		// given
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		older := auditMap(t, "debugmode-audit-a", Record{UID: "a", StartedAt: testNow.Add(-time.Hour)})
+		newer := auditMap(t, "debugmode-audit-b", Record{UID: "b", StartedAt: testNow})
+		invalid := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-audit-c"}, Data: map[string]string{recordKey: "{"}}
+		configMaps.EXPECT().List(t.Context(), auditListOptions).
+			Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*older, *invalid, *newer}}, nil)
+
+		// when
+		records, err := trail.List(t.Context())
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "b", records[0].UID)
+		assert.Equal(t, "a", records[1].UID)
+	})
+	t.Run("should fail to list records", func(t *testing.T) {
+		trail, configMaps := newTestTrail(t, DefaultRetention)
+		configMaps.EXPECT().List(t.Context(), auditListOptions).Return(nil, assert.AnError)
+
+		_, err := trail.List(t.Context())
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/health"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/trigger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// DefaultNamespace is the namespace of the Cloudogu EcoSystem used if none is configured.
+	DefaultNamespace = "ecosystem"
+
+	// StatusAPIAuthenticationNone serves the status API without authentication, e.g. if it is only reachable through
+	// a network policy.
+	StatusAPIAuthenticationNone = "none"
+	// StatusAPIAuthenticationTokenReview requires a bearer token that is allowed to get the non-resource URL /status.
+	StatusAPIAuthenticationTokenReview = "token-review"
+
+	// StatusBindAddressDisabled as status bind address disables the status API.
+	StatusBindAddressDisabled = "0"
+
+	// AllNamespaces as the only watched namespace lets the operator manage the debug modes in all namespaces.
+	AllNamespaces = "*"
+)
+
+// Config is the configuration of the operator. It is loaded from a config file, environment variables and flags.
+type Config struct {
+	// Namespace is the namespace of the operator. Its debug modes are managed if no other namespaces are watched.
+	Namespace string `json:"namespace"`
+	// WatchNamespaces are the namespaces of the Cloudogu EcoSystems whose debug modes are managed. AllNamespaces
+	// watches all namespaces. Only Namespace is watched if it is empty.
+	WatchNamespaces []string `json:"watchNamespaces"`
+
+	MetricsBindAddress     string `json:"metricsBindAddress"`
+	HealthProbeBindAddress string `json:"healthProbeBindAddress"`
+	// StatusBindAddress is the address of the status API. StatusBindAddressDisabled disables it.
+	StatusBindAddress       string `json:"statusBindAddress"`
+	StatusAPIAuthentication string `json:"statusApiAuthentication"`
+
+	LeaderElection LeaderElection `json:"leaderElection"`
+
+	// RequeueInterval is the time after which a debug mode is checked again while log levels are changed.
+	RequeueInterval metav1.Duration `json:"requeueInterval"`
+	// ReconcileDeadline is the time a reconcile may run or be overdue before the liveness probe fails.
+	ReconcileDeadline metav1.Duration `json:"reconcileDeadline"`
+	// CompletedTTL is the time a completed DebugMode is kept before it is deleted. Zero keeps it.
+	CompletedTTL metav1.Duration `json:"completedTTL"`
+
+	AddedDoguPolicy controller.AddedDoguPolicy `json:"addedDoguPolicy"`
+	RaiseOnly       bool                       `json:"raiseOnly"`
+	// ComponentSelector selects the deployments of platform components. Empty excludes all components.
+	ComponentSelector    string                                    `json:"componentSelector"`
+	DoguConfigProfiles   map[string]controller.DoguConfigOverrides `json:"doguConfigProfiles"`
+	LogLevelVocabularies map[string]map[string]string              `json:"logLevelVocabularies"`
+
+	// AuditRetention is the number of debug mode sessions kept in the audit trail. Zero disables the audit trail.
+	AuditRetention int `json:"auditRetention"`
+
+	// AutoDebugMode starts debug modes automatically when selected dogus degrade. It is disabled if it selects no dogus.
+	AutoDebugMode trigger.Policy `json:"autoDebugMode"`
+
+	// Webhooks receive notifications about the activation, extension, failure and completion of debug modes.
+	Webhooks []notify.WebhookConfig `json:"webhooks"`
+}
+
+// LeaderElection configures the leader election between several operator replicas.
+type LeaderElection struct {
+	Enabled bool   `json:"enabled"`
+	ID      string `json:"id"`
+	// Namespace is the namespace of the Lease. It defaults to the namespace of the operator.
+	Namespace     string          `json:"namespace"`
+	LeaseDuration metav1.Duration `json:"leaseDuration"`
+	RenewDeadline metav1.Duration `json:"renewDeadline"`
+	RetryPeriod   metav1.Duration `json:"retryPeriod"`
+}
+
+// Default returns the configuration used for all settings that are not configured.
+func Default() Config {
+	return Config{
+		Namespace:               DefaultNamespace,
+		MetricsBindAddress:      ":8080",
+		HealthProbeBindAddress:  ":8081",
+		StatusBindAddress:       ":8082",
+		StatusAPIAuthentication: StatusAPIAuthenticationTokenReview,
+		LeaderElection: LeaderElection{
+			ID:            "k8s-debug-mode-operator-leader",
+			LeaseDuration: metav1.Duration{Duration: 15 * time.Second},
+			RenewDeadline: metav1.Duration{Duration: 10 * time.Second},
+			RetryPeriod:   metav1.Duration{Duration: 2 * time.Second},
+		},
+		RequeueInterval:   metav1.Duration{Duration: controller.DefaultRequeueInterval},
+		ReconcileDeadline: metav1.Duration{Duration: health.DefaultReconcileDeadline},
+		AddedDoguPolicy:   controller.AddedDoguPolicyKeep,
+		AuditRetention:    audit.DefaultRetention,
+		AutoDebugMode:     trigger.DefaultPolicy(),
+	}
+}
+
+// ClusterWide returns true if the debug modes of all namespaces are managed.
+func (c Config) ClusterWide() bool {
+	return slices.Equal(c.WatchNamespaces, []string{AllNamespaces})
+}
+
+// WatchedNamespaces returns the namespaces whose debug modes are managed or nil if all namespaces are watched.
+func (c Config) WatchedNamespaces() []string {
+	if c.ClusterWide() {
+		return nil
+	}
+	if len(c.WatchNamespaces) == 0 {
+		return []string{c.Namespace}
+	}
+	return c.WatchNamespaces
+}
+
+// LeaderElectionNamespace returns the namespace of the Lease used for leader election.
+func (c Config) LeaderElectionNamespace() string {
+	if c.LeaderElection.Namespace != "" {
+		return c.LeaderElection.Namespace
+	}
+	return c.Namespace
+}
+
+// Vocabularies returns the log level vocabularies of the dogus.
+func (c Config) Vocabularies() (map[string]loglevel.Vocabulary, error) {
+	return loglevel.NewVocabularies(c.LogLevelVocabularies)
+}
+
+// Validate returns an error for every invalid setting.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Namespace == "" {
+		errs = append(errs, errors.New("namespace must not be empty"))
+	}
+	if !c.ClusterWide() {
+		for _, namespace := range c.WatchNamespaces {
+			if namespace == AllNamespaces {
+				errs = append(errs, fmt.Errorf("watched namespace %s must not be combined with other namespaces", AllNamespaces))
+			} else if problems := validation.IsDNS1123Label(namespace); len(problems) > 0 {
+				errs = append(errs, fmt.Errorf("invalid watched namespace %q: %s", namespace, strings.Join(problems, ", ")))
+			}
+		}
+	}
+	if c.MetricsBindAddress == "" || c.HealthProbeBindAddress == "" || c.StatusBindAddress == "" {
+		errs = append(errs, errors.New("bind addresses must not be empty"))
+	}
+	switch c.StatusAPIAuthentication {
+	case StatusAPIAuthenticationNone, StatusAPIAuthenticationTokenReview:
+	default:
+		errs = append(errs, fmt.Errorf("unknown status API authentication %q, expected %s or %s",
+			c.StatusAPIAuthentication, StatusAPIAuthenticationNone, StatusAPIAuthenticationTokenReview))
+	}
+	errs = append(errs, c.LeaderElection.validate())
+	if c.RequeueInterval.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("requeue interval %s must be positive", c.RequeueInterval.Duration))
+	}
+	if c.ReconcileDeadline.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("reconcile deadline %s must be positive", c.ReconcileDeadline.Duration))
+	}
+	if c.CompletedTTL.Duration < 0 {
+		errs = append(errs, fmt.Errorf("completed TTL %s must not be negative", c.CompletedTTL.Duration))
+	}
+	switch c.AddedDoguPolicy {
+	case controller.AddedDoguPolicyKeep, controller.AddedDoguPolicyRestoreDefault, controller.AddedDoguPolicyTargeted:
+	default:
+		errs = append(errs, fmt.Errorf("unknown added dogu policy %q, expected one of %s, %s, %s", c.AddedDoguPolicy,
+			controller.AddedDoguPolicyKeep, controller.AddedDoguPolicyRestoreDefault, controller.AddedDoguPolicyTargeted))
+	}
+	if _, err := controller.ParseComponentSelector(c.ComponentSelector); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, controller.ValidateDoguConfigProfiles(c.DoguConfigProfiles))
+	if _, err := c.Vocabularies(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.AuditRetention < 0 {
+		errs = append(errs, fmt.Errorf("audit retention %d must not be negative", c.AuditRetention))
+	}
+	errs = append(errs, c.AutoDebugMode.Validate())
+	errs = append(errs, notify.ValidateWebhooks(c.Webhooks))
+	return errors.Join(errs...)
+}
+
+func (l LeaderElection) validate() error {
+	if !l.Enabled {
+		return nil
+	}
+	if l.ID == "" {
+		return errors.New("leader election ID must not be empty")
+	}
+	if l.RetryPeriod.Duration <= 0 || l.RenewDeadline.Duration <= l.RetryPeriod.Duration ||
+		l.LeaseDuration.Duration <= l.RenewDeadline.Duration {
+		return fmt.Errorf("leader election durations must satisfy lease duration %s > renew deadline %s > retry period %s > 0",
+			l.LeaseDuration.Duration, l.RenewDeadline.Duration, l.RetryPeriod.Duration)
+	}
+	return nil
+}
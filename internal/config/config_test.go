@@ -0,0 +1,143 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDefault(t *testing.T) {
+	t.Run("should be valid", func(t *testing.T) {
+		require.NoError(t, Default().Validate())
+	})
+}
+
+func TestConfig_LeaderElectionNamespace(t *testing.T) {
+	t.Run("should default to the namespace of the operator", func(t *testing.T) {
+		cfg := Default()
+
+		assert.Equal(t, "ecosystem", cfg.LeaderElectionNamespace())
+	})
+	t.Run("should use the configured namespace", func(t *testing.T) {
+		cfg := Default()
+		cfg.LeaderElection.Namespace = "leases"
+
+		assert.Equal(t, "leases", cfg.LeaderElectionNamespace())
+	})
+}
+
+func TestConfig_WatchedNamespaces(t *testing.T) {
+	t.Run("should default to the namespace of the operator", func(t *testing.T) {
+		cfg := Default()
+
+		assert.False(t, cfg.ClusterWide())
+		assert.Equal(t, []string{"ecosystem"}, cfg.WatchedNamespaces())
+	})
+	t.Run("should return the watched namespaces", func(t *testing.T) {
+		cfg := Default()
+		cfg.WatchNamespaces = []string{"ecosystem-1", "ecosystem-2"}
+
+		assert.False(t, cfg.ClusterWide())
+		assert.Equal(t, []string{"ecosystem-1", "ecosystem-2"}, cfg.WatchedNamespaces())
+	})
+	t.Run("should return nil for all namespaces", func(t *testing.T) {
+		cfg := Default()
+		cfg.WatchNamespaces = []string{AllNamespaces}
+
+		assert.True(t, cfg.ClusterWide())
+		assert.Nil(t, cfg.WatchedNamespaces())
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errMsg string
+	}{
+		{name: "empty namespace", modify: func(cfg *Config) { cfg.Namespace = "" },
+			errMsg: "namespace must not be empty"},
+		{name: "all namespaces combined with others", modify: func(cfg *Config) { cfg.WatchNamespaces = []string{"ecosystem", "*"} },
+			errMsg: "watched namespace * must not be combined with other namespaces"},
+		{name: "invalid watched namespace", modify: func(cfg *Config) { cfg.WatchNamespaces = []string{"Ecosystem"} },
+			errMsg: `invalid watched namespace "Ecosystem"`},
+		{name: "empty bind address", modify: func(cfg *Config) { cfg.MetricsBindAddress = "" },
+			errMsg: "bind addresses must not be empty"},
+		{name: "unknown status API authentication", modify: func(cfg *Config) { cfg.StatusAPIAuthentication = "basic" },
+			errMsg: `unknown status API authentication "basic"`},
+		{name: "renew deadline not shorter than lease duration", modify: func(cfg *Config) {
+			cfg.LeaderElection.Enabled = true
+			cfg.LeaderElection.RenewDeadline = metav1.Duration{Duration: 15 * time.Second}
+		}, errMsg: "leader election durations must satisfy lease duration 15s > renew deadline 15s > retry period 2s > 0"},
+		{name: "empty leader election ID", modify: func(cfg *Config) {
+			cfg.LeaderElection.Enabled = true
+			cfg.LeaderElection.ID = ""
+		}, errMsg: "leader election ID must not be empty"},
+		{name: "zero requeue interval", modify: func(cfg *Config) { cfg.RequeueInterval = metav1.Duration{} },
+			errMsg: "requeue interval 0s must be positive"},
+		{name: "negative reconcile deadline", modify: func(cfg *Config) { cfg.ReconcileDeadline = metav1.Duration{Duration: -time.Second} },
+			errMsg: "reconcile deadline -1s must be positive"},
+		{name: "negative completed TTL", modify: func(cfg *Config) { cfg.CompletedTTL = metav1.Duration{Duration: -time.Second} },
+			errMsg: "completed TTL -1s must not be negative"},
+		{name: "unknown added dogu policy", modify: func(cfg *Config) { cfg.AddedDoguPolicy = "remove" },
+			errMsg: `unknown added dogu policy "remove"`},
+		{name: "added dogu policy in wrong case", modify: func(cfg *Config) { cfg.AddedDoguPolicy = "Keep" },
+			errMsg: `unknown added dogu policy "Keep"`},
+		{name: "invalid component selector", modify: func(cfg *Config) { cfg.ComponentSelector = "a in (b" },
+			errMsg: `invalid component selector "a in (b"`},
+		{name: "reserved dogu config key", modify: func(cfg *Config) {
+			cfg.DoguConfigProfiles = map[string]controller.DoguConfigOverrides{"sql": {"redmine": {"logging/root": "DEBUG"}}}
+		}, errMsg: "invalid profile sql"},
+		{name: "unknown log level in vocabulary", modify: func(cfg *Config) {
+			cfg.LogLevelVocabularies = map[string]map[string]string{"redmine": {"VERBOSE": "verbose"}}
+		}, errMsg: "VERBOSE"},
+		{name: "negative audit retention", modify: func(cfg *Config) { cfg.AuditRetention = -1 },
+			errMsg: "audit retention -1 must not be negative"},
+		{name: "invalid automatic debug mode policy", modify: func(cfg *Config) {
+			cfg.AutoDebugMode.Dogus = []string{"cas"}
+			cfg.AutoDebugMode.Duration = metav1.Duration{}
+		}, errMsg: "automatic debug mode duration 0s must be positive"},
+		{name: "invalid webhook", modify: func(cfg *Config) {
+			cfg.Webhooks = []notify.WebhookConfig{{Name: "chat", URL: "chat.example.com"}}
+		}, errMsg: "webhook chat must have an absolute http or https URL"},
+	}
+	for _, tt := range tests {
+		t.Run("should fail for "+tt.name, func(t *testing.T) {
+			// given
+			cfg := Default()
+			tt.modify(&cfg)
+
+			// when
+			err := cfg.Validate()
+
+			// then
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+	t.Run("should ignore the leader election durations if the leader election is disabled", func(t *testing.T) {
+		cfg := Default()
+		cfg.LeaderElection.RetryPeriod = metav1.Duration{}
+
+		require.NoError(t, cfg.Validate())
+	})
+	t.Run("should return all errors", func(t *testing.T) {
+		// given
+		cfg := Default()
+		cfg.Namespace = ""
+		cfg.AuditRetention = -1
+
+		// when
+		err := cfg.Validate()
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "namespace must not be empty")
+		assert.ErrorContains(t, err, "audit retention -1 must not be negative")
+	})
+}
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	configFileFlag = "config"
+	configFileEnv  = "CONFIG_FILE"
+)
+
+// option is a setting that can be set by a flag and an environment variable.
+type option struct {
+	flag    string
+	env     string
+	usage   string
+	boolean bool
+	set     func(cfg *Config, value string) error
+}
+
+var options = []option{
+	{flag: "namespace", env: "NAMESPACE", usage: "The namespace of the operator. Its debug modes are managed if no other namespaces are watched.",
+		set: func(cfg *Config, value string) error { cfg.Namespace = value; return nil }},
+	{flag: "watch-namespaces", env: "WATCH_NAMESPACES",
+		usage: "Comma separated namespaces whose debug modes are managed, * for all namespaces. Defaults to the namespace of the operator.",
+		set:   listSetter(func(cfg *Config) *[]string { return &cfg.WatchNamespaces })},
+	{flag: "metrics-bind-address", env: "METRICS_BIND_ADDRESS", usage: "The address the metric endpoint binds to.",
+		set: func(cfg *Config, value string) error { cfg.MetricsBindAddress = value; return nil }},
+	{flag: "health-probe-bind-address", env: "HEALTH_PROBE_BIND_ADDRESS", usage: "The address the probe endpoint binds to.",
+		set: func(cfg *Config, value string) error { cfg.HealthProbeBindAddress = value; return nil }},
+	{flag: "status-bind-address", env: "STATUS_BIND_ADDRESS", usage: "The address the status API binds to. Use 0 to disable it.",
+		set: func(cfg *Config, value string) error { cfg.StatusBindAddress = value; return nil }},
+	{flag: "status-api-authentication", env: "STATUS_API_AUTHENTICATION", usage: "The authentication of the status API: token-review or none.",
+		set: func(cfg *Config, value string) error { cfg.StatusAPIAuthentication = value; return nil }},
+	{flag: "leader-elect", env: "LEADER_ELECT", boolean: true,
+		usage: "Enable leader election, so only one of several operator replicas changes log levels.",
+		set:   boolSetter(func(cfg *Config) *bool { return &cfg.LeaderElection.Enabled })},
+	{flag: "leader-election-id", env: "LEADER_ELECTION_ID", usage: "The name of the Lease used for leader election.",
+		set: func(cfg *Config, value string) error { cfg.LeaderElection.ID = value; return nil }},
+	{flag: "leader-election-namespace", env: "LEADER_ELECTION_NAMESPACE",
+		usage: "The namespace of the Lease used for leader election. Defaults to the namespace of the operator.",
+		set:   func(cfg *Config, value string) error { cfg.LeaderElection.Namespace = value; return nil }},
+	{flag: "leader-election-lease-duration", env: "LEADER_ELECTION_LEASE_DURATION",
+		usage: "The duration non-leader replicas wait before they try to take over leadership.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.LeaderElection.LeaseDuration })},
+	{flag: "leader-election-renew-deadline", env: "LEADER_ELECTION_RENEW_DEADLINE",
+		usage: "The duration the leader retries to renew its leadership before it gives it up.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.LeaderElection.RenewDeadline })},
+	{flag: "leader-election-retry-period", env: "LEADER_ELECTION_RETRY_PERIOD",
+		usage: "The duration between two attempts to acquire or renew the leadership.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.LeaderElection.RetryPeriod })},
+	{flag: "requeue-interval", env: "REQUEUE_INTERVAL",
+		usage: "The time after which a debug mode is checked again while log levels are changed.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.RequeueInterval })},
+	{flag: "reconcile-deadline", env: "RECONCILE_DEADLINE",
+		usage: "The time a reconcile may run or be overdue before the liveness probe fails.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.ReconcileDeadline })},
+	{flag: "completed-ttl", env: "COMPLETED_TTL",
+		usage: "The time a completed DebugMode is kept before it is deleted. Empty keeps it.",
+		set: func(cfg *Config, value string) error {
+			ttl, err := controller.ParseCompletedTTL(value)
+			cfg.CompletedTTL = metav1.Duration{Duration: ttl}
+			return err
+		}},
+	{flag: "added-dogu-policy", env: "ADDED_DOGU_POLICY",
+		usage: "How the rollback treats dogus installed during a debug mode: keep, restore-default or targeted.",
+		set: func(cfg *Config, value string) error {
+			policy, err := controller.ParseAddedDoguPolicy(value)
+			cfg.AddedDoguPolicy = policy
+			return err
+		}},
+	{flag: "raise-only", env: "RAISE_ONLY", boolean: true,
+		usage: "Prevent debug modes from lowering the verbosity of dogus that log more than the target log level.",
+		set:   boolSetter(func(cfg *Config) *bool { return &cfg.RaiseOnly })},
+	{flag: "component-selector", env: "COMPONENT_SELECTOR",
+		usage: "The label selector of the platform component deployments. Empty excludes all components.",
+		set: func(cfg *Config, value string) error {
+			selector, err := controller.ParseComponentSelector(value)
+			cfg.ComponentSelector = selector
+			return err
+		}},
+	{flag: "dogu-config-profiles", env: "DOGU_CONFIG_PROFILES",
+		usage: `Named sets of dogu config keys as JSON, e.g. {"sql-logging": {"redmine": {"logging/sql": "true"}}}.`,
+		set: func(cfg *Config, value string) error {
+			profiles, err := controller.ParseDoguConfigProfiles(value)
+			cfg.DoguConfigProfiles = profiles
+			return err
+		}},
+	{flag: "log-level-vocabularies", env: "LOG_LEVEL_VOCABULARIES",
+		usage: `The log levels single dogus expect as JSON, e.g. {"redmine": {"WARN": "warning"}}.`,
+		set: func(cfg *Config, value string) error {
+			cfg.LogLevelVocabularies = nil
+			if strings.TrimSpace(value) == "" {
+				return nil
+			}
+			if err := json.Unmarshal([]byte(value), &cfg.LogLevelVocabularies); err != nil {
+				return fmt.Errorf("failed to parse log level vocabularies: %w", err)
+			}
+			return nil
+		}},
+	{flag: "audit-retention", env: "AUDIT_RETENTION",
+		usage: "The number of debug mode sessions kept in the audit trail. 0 disables the audit trail.",
+		set:   intSetter(func(cfg *Config) *int { return &cfg.AuditRetention })},
+	{flag: "auto-debug-mode-dogus", env: "AUTO_DEBUG_MODE_DOGUS",
+		usage: "Comma separated dogus whose degradation starts a debug mode automatically, * for all dogus. Empty disables automatic debug modes.",
+		set:   listSetter(func(cfg *Config) *[]string { return &cfg.AutoDebugMode.Dogus })},
+	{flag: "auto-debug-mode-unhealthy-for", env: "AUTO_DEBUG_MODE_UNHEALTHY_FOR",
+		usage: "The time a dogu must stay unhealthy before a debug mode is started automatically.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.AutoDebugMode.UnhealthyFor })},
+	{flag: "auto-debug-mode-restarts", env: "AUTO_DEBUG_MODE_RESTARTS",
+		usage: "The number of restarts of a dogu within the restart window that starts a debug mode automatically. 0 ignores restarts.",
+		set:   intSetter(func(cfg *Config) *int { return &cfg.AutoDebugMode.Restarts })},
+	{flag: "auto-debug-mode-restart-window", env: "AUTO_DEBUG_MODE_RESTART_WINDOW",
+		usage: "The time within which the restarts of a dogu are counted.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.AutoDebugMode.RestartWindow })},
+	{flag: "auto-debug-mode-duration", env: "AUTO_DEBUG_MODE_DURATION",
+		usage: "The time an automatically started debug mode stays active.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.AutoDebugMode.Duration })},
+	{flag: "auto-debug-mode-target-log-level", env: "AUTO_DEBUG_MODE_TARGET_LOG_LEVEL",
+		usage: "The target log level of automatically started debug modes.",
+		set:   func(cfg *Config, value string) error { cfg.AutoDebugMode.TargetLogLevel = value; return nil }},
+	{flag: "auto-debug-mode-cooldown", env: "AUTO_DEBUG_MODE_COOLDOWN",
+		usage: "The time after the end of a debug mode in which degradations of dogus are ignored.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.AutoDebugMode.Cooldown })},
+	{flag: "auto-debug-mode-max-triggers", env: "AUTO_DEBUG_MODE_MAX_TRIGGERS",
+		usage: "The number of debug modes that may be started automatically in a namespace within the rate limit window.",
+		set:   intSetter(func(cfg *Config) *int { return &cfg.AutoDebugMode.MaxTriggers })},
+	{flag: "auto-debug-mode-rate-limit-window", env: "AUTO_DEBUG_MODE_RATE_LIMIT_WINDOW",
+		usage: "The time within which the automatically started debug modes are counted.",
+		set:   durationSetter(func(cfg *Config) *metav1.Duration { return &cfg.AutoDebugMode.RateLimitWindow })},
+	{flag: "webhooks", env: "WEBHOOKS",
+		usage: `The webhooks notified about debug modes as JSON, e.g. [{"name": "chat", "url": "https://chat.example.com/hook", "events": ["activated", "completed"]}].`,
+		set: func(cfg *Config, value string) error {
+			webhooks, err := notify.ParseWebhooks(value)
+			cfg.Webhooks = webhooks
+			return err
+		}},
+}
+
+func boolSetter(field func(cfg *Config) *bool) func(cfg *Config, value string) error {
+	return func(cfg *Config, value string) error {
+		enabled, err := strconv.ParseBool(value)
+		*field(cfg) = enabled
+		return err
+	}
+}
+
+func intSetter(field func(cfg *Config) *int) func(cfg *Config, value string) error {
+	return func(cfg *Config, value string) error {
+		number, err := strconv.Atoi(value)
+		*field(cfg) = number
+		return err
+	}
+}
+
+// listSetter sets a comma separated list. Empty entries are ignored.
+func listSetter(field func(cfg *Config) *[]string) func(cfg *Config, value string) error {
+	return func(cfg *Config, value string) error {
+		var list []string
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				list = append(list, entry)
+			}
+		}
+		*field(cfg) = list
+		return nil
+	}
+}
+
+func durationSetter(field func(cfg *Config) *metav1.Duration) func(cfg *Config, value string) error {
+	return func(cfg *Config, value string) error {
+		duration, err := time.ParseDuration(value)
+		*field(cfg) = metav1.Duration{Duration: duration}
+		return err
+	}
+}
+
+// Load registers the flags of the operator in the given flag set, parses the arguments and returns the validated
+// configuration. Settings are taken from the defaults, the config file given by --config or CONFIG_FILE, the
+// environment variables and the flags, each overriding the former.
+func Load(fs *flag.FlagSet, args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	var file string
+	fs.StringVar(&file, configFileFlag, "", "The YAML config file of the operator. Environment variables and flags override its settings.")
+	flagValues := map[string]string{}
+	for _, opt := range options {
+		record := func(value string) error {
+			flagValues[opt.flag] = value
+			return nil
+		}
+		if opt.boolean {
+			fs.BoolFunc(opt.flag, opt.usage, record)
+		} else {
+			fs.Func(opt.flag, opt.usage, record)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	cfg := Default()
+	if file == "" {
+		file, _ = lookupEnv(configFileEnv)
+	}
+	if file != "" {
+		if err := readFile(file, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+	for _, opt := range options {
+		if value, found := lookupEnv(opt.env); found {
+			if err := opt.set(&cfg, value); err != nil {
+				return Config{}, fmt.Errorf("invalid environment variable %s: %w", opt.env, err)
+			}
+		}
+	}
+	for _, opt := range options {
+		if value, found := flagValues[opt.flag]; found {
+			if err := opt.set(&cfg, value); err != nil {
+				return Config{}, fmt.Errorf("invalid flag --%s: %w", opt.flag, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+func readFile(file string, cfg *Config) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	// unknown keys are rejected, so a misspelled setting does not silently fall back to its default
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", file, err)
+	}
+	return nil
+}
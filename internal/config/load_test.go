@@ -0,0 +1,237 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/trigger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func envOf(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, found := env[key]
+		return value, found
+	}
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return file
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should return the defaults", func(t *testing.T) {
+		// when
+		cfg, err := Load(newTestFlagSet(), nil, envOf(nil))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Default(), cfg)
+	})
+	t.Run("should read the config file", func(t *testing.T) {
+		// given
+		file := writeConfigFile(t, `
+namespace: ces
+requeueInterval: 30s
+leaderElection:
+  enabled: true
+  leaseDuration: 30s
+doguConfigProfiles:
+  sql-logging:
+    redmine:
+      logging/sql: "true"
+logLevelVocabularies:
+  redmine:
+    WARN: warning
+autoDebugMode:
+  dogus: [cas, ldap]
+  duration: 1h
+`)
+
+		// when
+		cfg, err := Load(newTestFlagSet(), []string{"--config", file}, envOf(nil))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "ces", cfg.Namespace)
+		assert.Equal(t, 30*time.Second, cfg.RequeueInterval.Duration)
+		assert.True(t, cfg.LeaderElection.Enabled)
+		assert.Equal(t, 30*time.Second, cfg.LeaderElection.LeaseDuration.Duration)
+		assert.Equal(t, 10*time.Second, cfg.LeaderElection.RenewDeadline.Duration, "unset values keep their default")
+		assert.Equal(t, map[string]controller.DoguConfigOverrides{"sql-logging": {"redmine": {"logging/sql": "true"}}}, cfg.DoguConfigProfiles)
+		assert.Equal(t, map[string]map[string]string{"redmine": {"WARN": "warning"}}, cfg.LogLevelVocabularies)
+		assert.Equal(t, []string{"cas", "ldap"}, cfg.AutoDebugMode.Dogus)
+		assert.Equal(t, time.Hour, cfg.AutoDebugMode.Duration.Duration)
+		assert.Equal(t, 3, cfg.AutoDebugMode.Restarts, "unset values keep their default")
+	})
+	t.Run("should read the config file given by the environment", func(t *testing.T) {
+		file := writeConfigFile(t, "namespace: ces\n")
+
+		cfg, err := Load(newTestFlagSet(), nil, envOf(map[string]string{"CONFIG_FILE": file}))
+
+		require.NoError(t, err)
+		assert.Equal(t, "ces", cfg.Namespace)
+	})
+	t.Run("should override the config file with the environment and the environment with flags", func(t *testing.T) {
+		// given
+		file := writeConfigFile(t, "namespace: file\nauditRetention: 10\nraiseOnly: true\n")
+		env := envOf(map[string]string{
+			"NAMESPACE":       "env",
+			"AUDIT_RETENTION": "20",
+			"RAISE_ONLY":      "false",
+		})
+
+		// when
+		cfg, err := Load(newTestFlagSet(), []string{"--config=" + file, "--namespace=flag", "--raise-only"}, env)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "flag", cfg.Namespace)
+		assert.Equal(t, 20, cfg.AuditRetention)
+		assert.True(t, cfg.RaiseOnly)
+	})
+	t.Run("should parse all environment variables", func(t *testing.T) {
+		// given
+		env := envOf(map[string]string{
+			"STATUS_API_AUTHENTICATION":         "none",
+			"LEADER_ELECT":                      "true",
+			"LEADER_ELECTION_NAMESPACE":         "leases",
+			"LEADER_ELECTION_RETRY_PERIOD":      "1s",
+			"REQUEUE_INTERVAL":                  "2m",
+			"RECONCILE_DEADLINE":                "1h",
+			"COMPLETED_TTL":                     "24h",
+			"ADDED_DOGU_POLICY":                 "Restore-Default",
+			"COMPONENT_SELECTOR":                " app=k8s-dogu-operator ",
+			"DOGU_CONFIG_PROFILES":              `{"sql": {"redmine": {"logging/sql": "true"}}}`,
+			"LOG_LEVEL_VOCABULARIES":            `{"redmine": {"WARN": "warning"}}`,
+			"LEADER_ELECTION_LEASE_DURATION":    "20s",
+			"WATCH_NAMESPACES":                  "ecosystem-1, ecosystem-2,",
+			"AUTO_DEBUG_MODE_DOGUS":             "*",
+			"AUTO_DEBUG_MODE_UNHEALTHY_FOR":     "5m",
+			"AUTO_DEBUG_MODE_RESTARTS":          "0",
+			"AUTO_DEBUG_MODE_RESTART_WINDOW":    "1h",
+			"AUTO_DEBUG_MODE_DURATION":          "15m",
+			"AUTO_DEBUG_MODE_TARGET_LOG_LEVEL":  "TRACE",
+			"AUTO_DEBUG_MODE_COOLDOWN":          "2h",
+			"AUTO_DEBUG_MODE_MAX_TRIGGERS":      "1",
+			"AUTO_DEBUG_MODE_RATE_LIMIT_WINDOW": "12h",
+			"WEBHOOKS":                          `[{"name": "chat", "url": "https://chat.example.com/hook", "events": ["completed"], "attempts": 2}]`,
+		})
+
+		// when
+		cfg, err := Load(newTestFlagSet(), nil, env)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, StatusAPIAuthenticationNone, cfg.StatusAPIAuthentication)
+		assert.Equal(t, []string{"ecosystem-1", "ecosystem-2"}, cfg.WatchNamespaces)
+		assert.Equal(t, LeaderElection{
+			Enabled:       true,
+			ID:            "k8s-debug-mode-operator-leader",
+			Namespace:     "leases",
+			LeaseDuration: metav1.Duration{Duration: 20 * time.Second},
+			RenewDeadline: metav1.Duration{Duration: 10 * time.Second},
+			RetryPeriod:   metav1.Duration{Duration: time.Second},
+		}, cfg.LeaderElection)
+		assert.Equal(t, 2*time.Minute, cfg.RequeueInterval.Duration)
+		assert.Equal(t, time.Hour, cfg.ReconcileDeadline.Duration)
+		assert.Equal(t, 24*time.Hour, cfg.CompletedTTL.Duration)
+		assert.Equal(t, controller.AddedDoguPolicyRestoreDefault, cfg.AddedDoguPolicy)
+		assert.Equal(t, "app=k8s-dogu-operator", cfg.ComponentSelector)
+		assert.Equal(t, map[string]controller.DoguConfigOverrides{"sql": {"redmine": {"logging/sql": "true"}}}, cfg.DoguConfigProfiles)
+		assert.Equal(t, map[string]map[string]string{"redmine": {"WARN": "warning"}}, cfg.LogLevelVocabularies)
+		assert.Equal(t, trigger.Policy{
+			Dogus:           []string{trigger.AllDogus},
+			UnhealthyFor:    metav1.Duration{Duration: 5 * time.Minute},
+			RestartWindow:   metav1.Duration{Duration: time.Hour},
+			Duration:        metav1.Duration{Duration: 15 * time.Minute},
+			TargetLogLevel:  "TRACE",
+			Cooldown:        metav1.Duration{Duration: 2 * time.Hour},
+			MaxTriggers:     1,
+			RateLimitWindow: metav1.Duration{Duration: 12 * time.Hour},
+		}, cfg.AutoDebugMode)
+		assert.Equal(t, []notify.WebhookConfig{{
+			Name:     "chat",
+			URL:      "https://chat.example.com/hook",
+			Events:   []notify.Event{notify.EventCompleted},
+			Attempts: 2,
+		}}, cfg.Webhooks)
+	})
+	t.Run("should accept empty values of optional environment variables", func(t *testing.T) {
+		env := envOf(map[string]string{
+			"COMPLETED_TTL":          "",
+			"COMPONENT_SELECTOR":     "",
+			"DOGU_CONFIG_PROFILES":   "",
+			"LOG_LEVEL_VOCABULARIES": "",
+			"AUTO_DEBUG_MODE_DOGUS":  "",
+			"WEBHOOKS":               "",
+		})
+
+		cfg, err := Load(newTestFlagSet(), nil, env)
+
+		require.NoError(t, err)
+		assert.Zero(t, cfg.CompletedTTL.Duration)
+		assert.Empty(t, cfg.ComponentSelector)
+		assert.Empty(t, cfg.DoguConfigProfiles)
+		assert.Empty(t, cfg.LogLevelVocabularies)
+		assert.False(t, cfg.AutoDebugMode.Enabled())
+		assert.Empty(t, cfg.Webhooks)
+	})
+	t.Run("should fail for an invalid environment variable", func(t *testing.T) {
+		_, err := Load(newTestFlagSet(), nil, envOf(map[string]string{"AUDIT_RETENTION": "many"}))
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid environment variable AUDIT_RETENTION")
+	})
+	t.Run("should fail for an invalid flag", func(t *testing.T) {
+		_, err := Load(newTestFlagSet(), []string{"--requeue-interval=soon"}, envOf(nil))
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid flag --requeue-interval")
+	})
+	t.Run("should fail for an unknown flag", func(t *testing.T) {
+		_, err := Load(newTestFlagSet(), []string{"--unknown"}, envOf(nil))
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse flags")
+	})
+	t.Run("should fail for a missing config file", func(t *testing.T) {
+		_, err := Load(newTestFlagSet(), []string{"--config", filepath.Join(t.TempDir(), "missing.yaml")}, envOf(nil))
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read config file")
+	})
+	t.Run("should fail for an unknown key in the config file", func(t *testing.T) {
+		file := writeConfigFile(t, "requeueIntervall: 30s\n")
+
+		_, err := Load(newTestFlagSet(), []string{"--config", file}, envOf(nil))
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse config file")
+	})
+	t.Run("should fail for an invalid configuration", func(t *testing.T) {
+		file := writeConfigFile(t, "requeueInterval: 0s\n")
+
+		_, err := Load(newTestFlagSet(), []string{"--config", file}, envOf(nil))
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid configuration: requeue interval 0s must be positive")
+	})
+}
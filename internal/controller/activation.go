@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+)
+
+// raiseOnlyAnnotation overrides the operator wide raise-only setting for a single DebugMode.
+const raiseOnlyAnnotation = "debugmode.k8s.cloudogu.com/raise-only"
+
+// activation holds the settings of an activation pass over all dogus and records every dogu
+// that could not be set to the target log level.
+type activation struct {
+	stateMap       *StateMap
+	targetLogLevel loglevel.LogLevel
+	logger         logging.Logger
+	// raiseOnly prevents lowering the verbosity of dogus that already log more than the target log level.
+	raiseOnly bool
+	// doguConfig contains further dogu config keys set while the debug mode is active.
+	doguConfig DoguConfigOverrides
+	// sensitiveDoguConfig contains sensitive dogu config keys set while the debug mode is active.
+	sensitiveDoguConfig DoguConfigOverrides
+	// sensitiveState stores the original values of sensitiveDoguConfig.
+	sensitiveState *SensitiveState
+	// dogus contains the names of the selected dogus. All dogus are selected if it is empty.
+	dogus []string
+	// componentSelector selects the deployments of the platform components. An empty selector excludes all components.
+	componentSelector string
+
+	// incompatible contains the names of dogus whose descriptor does not accept the target log level.
+	incompatible []string
+	// untouched contains the names of dogus left unchanged because they are more verbose than the target log level.
+	untouched []string
+	// notInstalled contains the names of dogus with declared dogu config that are not installed.
+	notInstalled []string
+	// unknownDogus contains the names of selected dogus that are not installed.
+	unknownDogus []string
+	// incompatibleComponents contains the names of components that do not accept the target log level.
+	incompatibleComponents []string
+	// untouchedComponents contains the names of components left unchanged because they are more verbose than the target log level.
+	untouchedComponents []string
+}
+
+// skips returns true if the debug mode must neither change nor record an element with the given log level.
+func (a *activation) skips(level loglevel.LogLevel) bool {
+	return KeepsMoreVerbose(a.raiseOnly, level, a.targetLogLevel)
+}
+
+// KeepsMoreVerbose returns true if a debug mode keeps an element with the given log level instead of setting the
+// target log level. With raise-only, elements more verbose than the target log level are kept.
+func KeepsMoreVerbose(raiseOnly bool, level loglevel.LogLevel, target loglevel.LogLevel) bool {
+	return raiseOnly && level.IsMoreVerboseThan(target)
+}
+
+// summary returns a human-readable description of all recorded deviations or an empty string if there are none.
+func (a *activation) summary() string {
+	var parts []string
+	if len(a.incompatible) > 0 {
+		parts = append(parts, fmt.Sprintf("target log level %s not supported by dogus: %s", a.targetLogLevel, strings.Join(a.incompatible, ", ")))
+	}
+	if len(a.untouched) > 0 {
+		parts = append(parts, fmt.Sprintf("kept more verbose dogus: %s", strings.Join(a.untouched, ", ")))
+	}
+	if len(a.unknownDogus) > 0 {
+		parts = append(parts, fmt.Sprintf("selected dogus not installed: %s", strings.Join(a.unknownDogus, ", ")))
+	}
+	if len(a.incompatibleComponents) > 0 {
+		parts = append(parts, fmt.Sprintf("target log level %s not supported by components: %s", a.targetLogLevel, strings.Join(a.incompatibleComponents, ", ")))
+	}
+	if len(a.untouchedComponents) > 0 {
+		parts = append(parts, fmt.Sprintf("kept more verbose components: %s", strings.Join(a.untouchedComponents, ", ")))
+	}
+	if len(a.notInstalled) > 0 {
+		parts = append(parts, fmt.Sprintf("dogu config declared for dogus not installed: %s", strings.Join(a.notInstalled, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// raiseOnlyFor returns whether the given debug mode may only raise the verbosity of dogus.
+// An invalid annotation is ignored, so the debug mode is never blocked by it.
+func (r *DebugModeReconciler) raiseOnlyFor(cr *k8sCRLib.DebugMode, logger logging.Logger) bool {
+	if cr == nil {
+		return r.raiseOnly
+	}
+	value, found := cr.Annotations[raiseOnlyAnnotation]
+	if !found {
+		return r.raiseOnly
+	}
+	raiseOnly, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		logger.Error("ERROR: ignore annotation", "annotation", raiseOnlyAnnotation, "error", err)
+		return r.raiseOnly
+	}
+	return raiseOnly
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"testing"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_activation_skips(t *testing.T) {
+	act := &activation{targetLogLevel: loglevel.LevelInfo, raiseOnly: true}
+
+	assert.True(t, act.skips(loglevel.LevelDebug))
+	assert.True(t, act.skips(loglevel.LevelTrace))
+	assert.False(t, act.skips(loglevel.LevelInfo))
+	assert.False(t, act.skips(loglevel.LevelWarn))
+	assert.False(t, act.skips(loglevel.LevelUnknown))
+
+	act.raiseOnly = false
+	assert.False(t, act.skips(loglevel.LevelTrace))
+}
+
+func Test_activation_summary(t *testing.T) {
+	t.Run("should return empty summary", func(t *testing.T) {
+		assert.Empty(t, (&activation{}).summary())
+	})
+	t.Run("should list all deviations", func(t *testing.T) {
+		// given
+		act := &activation{targetLogLevel: loglevel.LevelDebug, incompatible: []string{"ldap"}, untouched: []string{"cas", "redmine"}}
+
+		// when
+		summary := act.summary()
+
+		// then
+		assert.Equal(t, "target log level DEBUG not supported by dogus: ldap; kept more verbose dogus: cas, redmine", summary)
+	})
+	t.Run("should list deviations of components", func(t *testing.T) {
+		// given
+		act := &activation{targetLogLevel: loglevel.LevelTrace, incompatibleComponents: []string{"k8s-dogu-operator"}, untouchedComponents: []string{"k8s-service-discovery"}}
+
+		// when
+		summary := act.summary()
+
+		// then
+		assert.Equal(t, "target log level TRACE not supported by components: k8s-dogu-operator; kept more verbose components: k8s-service-discovery", summary)
+	})
+}
+
+func Test_DebugModeReconciler_raiseOnlyFor(t *testing.T) {
+	logger := logging.FromContext(t.Context())
+	dmc := &DebugModeReconciler{raiseOnly: true}
+
+	t.Run("should use operator setting without cr", func(t *testing.T) {
+		assert.True(t, dmc.raiseOnlyFor(nil, logger))
+	})
+	t.Run("should use operator setting without annotation", func(t *testing.T) {
+		assert.True(t, dmc.raiseOnlyFor(&k8sCRLib.DebugMode{}, logger))
+	})
+	t.Run("should use setting of annotation", func(t *testing.T) {
+		// given
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{raiseOnlyAnnotation: "false"}}}
+
+		// when
+		raiseOnly := dmc.raiseOnlyFor(cr, logger)
+
+		// then
+		assert.False(t, raiseOnly)
+	})
+	t.Run("should ignore invalid annotation", func(t *testing.T) {
+		// given
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{raiseOnlyAnnotation: "maybe"}}}
+
+		// when
+		raiseOnly := dmc.raiseOnlyFor(cr, logger)
+
+		// then
+		assert.True(t, raiseOnly)
+	})
+}
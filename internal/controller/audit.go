@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SetAuditTrail sets the trail recording every debug mode session. Sessions are not recorded if it is not set.
+func (r *DebugModeReconciler) SetAuditTrail(trail auditTrail) {
+	r.auditTrail = trail
+}
+
+// The audit trail must never block a debug mode, so all errors are only logged.
+
+// observeAudit records a new session or a changed deactivate timestamp.
+func (r *DebugModeReconciler) observeAudit(ctx context.Context, cr *k8sCRLib.DebugMode, logger logging.Logger) {
+	if r.auditTrail == nil || cr == nil || cr.DeletionTimestamp != nil {
+		return
+	}
+	err := r.auditTrail.Observe(ctx, cr)
+	if err != nil {
+		logger.Error("ERROR: failed to write audit record", "error", err)
+	}
+}
+
+// auditChanges records all elements the debug mode has changed according to the state map.
+func (r *DebugModeReconciler) auditChanges(ctx context.Context, cr *k8sCRLib.DebugMode, stateMap *StateMap, logger logging.Logger) {
+	if r.auditTrail == nil {
+		return
+	}
+	err := r.auditTrail.RecordChanges(ctx, cr.UID, auditChangesOf(stateMap, cr.Spec.TargetLogLevel))
+	if err != nil {
+		logger.Error("ERROR: failed to write audit record", "error", err)
+	}
+}
+
+// auditFailure records a failed reconcile of the session.
+func (r *DebugModeReconciler) auditFailure(ctx context.Context, cr *k8sCRLib.DebugMode, failure error, logger logging.Logger) {
+	if r.auditTrail == nil || cr == nil {
+		return
+	}
+	err := r.auditTrail.RecordFailure(ctx, cr.UID, failure)
+	if err != nil {
+		logger.Error("ERROR: failed to write audit record", "error", err)
+	}
+}
+
+// auditEnd closes the record of the session.
+func (r *DebugModeReconciler) auditEnd(ctx context.Context, uid types.UID, result audit.Result, message string, logger logging.Logger) {
+	if r.auditTrail == nil || uid == "" {
+		return
+	}
+	err := r.auditTrail.RecordEnd(ctx, uid, result, message)
+	if err != nil {
+		logger.Error("ERROR: failed to write audit record", "error", err)
+	}
+}
+
+// auditChangesOf converts the entries of the state map into changes of the audit record.
+func auditChangesOf(stateMap *StateMap, target string) []audit.Change {
+	changes := []audit.Change{}
+	for key := range stateMap.configMap.Data {
+		kind, name, found := strings.Cut(key, ".")
+		if !found {
+			continue
+		}
+		entry, _, err := stateMap.getEntry(key)
+		if err != nil {
+			continue
+		}
+		change := audit.Change{Kind: kind, Name: name}
+		if len(entry.Values) > 0 {
+			for configKey := range entry.Values {
+				change.Keys = append(change.Keys, configKey)
+			}
+			slices.Sort(change.Keys)
+		} else {
+			change.Original = entry.Level
+			change.Target = target
+		}
+		changes = append(changes, change)
+	}
+	slices.SortFunc(changes, func(a, b audit.Change) int {
+		return strings.Compare(a.Kind+"."+a.Name, b.Kind+"."+b.Name)
+	})
+	return changes
+}
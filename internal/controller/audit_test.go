@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func Test_auditChangesOf(t *testing.T) {
+	t.Run("should convert state map entries", func(t *testing.T) {
+		// given
+		configEntry, err := newConfigStateEntry(map[string]loglevel.ConfigValueState{
+			"logging/sql":     {Unset: true},
+			"logging/org.foo": {Value: "INFO"},
+		}, "5.1.0-1").marshal()
+		require.NoError(t, err)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{
+			"dogu.redmine":                testStateEntry(t, "WARN"),
+			"component.k8s-dogu-operator": testStateEntry(t, "INFO"),
+			"doguconfig.redmine":          configEntry,
+			"dogu.corrupt":                `{"version":1,"level":"INFO","checksum":"manipulated"}`,
+		}}}
+
+		// when
+		changes := auditChangesOf(stateMap, "DEBUG")
+
+		// then
+		assert.Equal(t, []audit.Change{
+			{Kind: "component", Name: "k8s-dogu-operator", Original: "INFO", Target: "DEBUG"},
+			{Kind: "dogu", Name: "redmine", Original: "WARN", Target: "DEBUG"},
+			{Kind: "doguconfig", Name: "redmine", Keys: []string{"logging/org.foo", "logging/sql"}},
+		}, changes)
+	})
+}
+
+func Test_DebugModeReconciler_audit(t *testing.T) {
+	logger := logging.FromContext(t.Context())
+	cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", UID: testDebugModeUID}}
+
+	t.Run("should do nothing without audit trail", func(t *testing.T) {
+		dmc := &DebugModeReconciler{}
+
+		dmc.observeAudit(t.Context(), cr, logger)
+		dmc.auditFailure(t.Context(), cr, assert.AnError, logger)
+		dmc.auditEnd(t.Context(), cr.UID, audit.ResultCompleted, "", logger)
+		dmc.auditChanges(t.Context(), cr, &StateMap{configMap: &corev1.ConfigMap{}}, logger)
+	})
+	t.Run("should not observe deleted debug mode", func(t *testing.T) {
+		dmc := &DebugModeReconciler{auditTrail: newMockAuditTrail(t)}
+		deleted := cr.DeepCopy()
+		deleted.DeletionTimestamp = &metav1.Time{}
+
+		dmc.observeAudit(t.Context(), deleted, logger)
+		dmc.observeAudit(t.Context(), nil, logger)
+	})
+	t.Run("should ignore errors of the audit trail", func(t *testing.T) {
+		// given
+		trail := newMockAuditTrail(t)
+		dmc := &DebugModeReconciler{auditTrail: trail}
+		trail.EXPECT().Observe(t.Context(), cr).Return(assert.AnError)
+		trail.EXPECT().RecordFailure(t.Context(), cr.UID, assert.AnError).Return(assert.AnError)
+		trail.EXPECT().RecordEnd(t.Context(), cr.UID, audit.ResultForceDeleted, "message").Return(assert.AnError)
+		trail.EXPECT().RecordChanges(t.Context(), cr.UID, []audit.Change{}).Return(assert.AnError)
+
+		// when
+		dmc.observeAudit(t.Context(), cr, logger)
+		dmc.auditFailure(t.Context(), cr, assert.AnError, logger)
+		dmc.auditEnd(t.Context(), cr.UID, audit.ResultForceDeleted, "message", logger)
+		dmc.auditChanges(t.Context(), cr, &StateMap{configMap: &corev1.ConfigMap{}}, logger)
+	})
+	t.Run("should record session and failure of reconcile", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		trail := newMockAuditTrail(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), configMapClient, NewMockLogLevelHandler(t))
+		dmc.SetAuditTrail(trail)
+
+		active := cr.DeepCopy()
+		active.Finalizers = []string{debugModeFinalizer}
+		active.Spec = k8sCRLib.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(time.Now().Add(time.Hour)), TargetLogLevel: "debug"}
+		debugModeClient.EXPECT().Get(mock.Anything, cr.Name, metav1.GetOptions{}).Return(active, nil)
+		configMapClient.EXPECT().Get(mock.Anything, testStateMapName, metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		debugModeClient.EXPECT().UpdateStatusDebugModeSet(mock.Anything, active).Return(nil, assert.AnError)
+		debugModeClient.EXPECT().UpdateStatusFailed(mock.Anything, active).Return(active, nil)
+		trail.EXPECT().Observe(mock.Anything, active).Return(nil)
+		trail.EXPECT().RecordFailure(mock.Anything, cr.UID, mock.Anything).Return(nil)
+
+		// when
+		_, err := dmc.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: cr.Name}})
+
+		// then
+		require.Error(t, err)
+	})
+}
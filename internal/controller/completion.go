@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// completedTTLAnnotation overrides the operator wide time a completed DebugMode is kept, e.g. "24h". "0" keeps it.
+const completedTTLAnnotation = "debugmode.k8s.cloudogu.com/completed-ttl"
+
+// SetCompletedTTL sets the time after which completed DebugModes are deleted. They are kept if it is not positive.
+func (r *DebugModeReconciler) SetCompletedTTL(ttl time.Duration) {
+	r.completedTTL = ttl
+}
+
+// ParseCompletedTTL parses the time completed DebugModes are kept. An empty value keeps them.
+func ParseCompletedTTL(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid completed TTL %q: %w", value, err)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("invalid completed TTL %q: must not be negative", value)
+	}
+	return ttl, nil
+}
+
+func (r *DebugModeReconciler) completedTTLFor(cr *k8sCRLib.DebugMode, logger logging.Logger) time.Duration {
+	value, found := cr.Annotations[completedTTLAnnotation]
+	if !found {
+		return r.completedTTL
+	}
+	ttl, err := ParseCompletedTTL(value)
+	if err != nil {
+		logger.Error("ERROR: ignore annotation", "annotation", completedTTLAnnotation, "error", err)
+		return r.completedTTL
+	}
+	return ttl
+}
+
+// isRearmed returns true if the deactivate timestamp of a completed DebugMode was moved into the future,
+// so it starts a new debug mode instead of being deleted and recreated.
+func (r *DebugModeReconciler) isRearmed(debugCR *k8sCRLib.DebugMode) bool {
+	return r.isCompleted(debugCR) && r.isActive(debugCR)
+}
+
+// rearm replaces the completed condition, so the DebugMode is activated again. The audit record of the finished
+// session is archived first, so the new session gets a record of its own.
+func (r *DebugModeReconciler) rearm(ctx context.Context, cr *k8sCRLib.DebugMode, logger logging.Logger) (*k8sCRLib.DebugMode, error) {
+	logger.Info("Completed DebugMode re-armed", "deactivateTimestamp", cr.Spec.DeactivateTimestamp)
+	if r.auditTrail != nil {
+		err := r.auditTrail.Archive(ctx, cr)
+		if err != nil {
+			logger.Error("ERROR: failed to archive audit record", "error", err)
+		}
+	}
+
+	cr, err := r.debugModeInterface.AddOrUpdateLogLevelsSet(ctx, cr, false, "Debug-Mode re-armed", string(k8sCRLib.DebugModeStatusSet))
+	if err != nil {
+		return nil, fmt.Errorf(conditionErrorString, k8sCRLib.DebugModeStatusSet, err)
+	}
+	return cr, nil
+}
+
+// cleanupCompleted deletes a completed DebugMode once its TTL has expired, so a new debug mode can be created.
+// The summary of the session is kept in the audit trail and the log.
+func (r *DebugModeReconciler) cleanupCompleted(ctx context.Context, cr *k8sCRLib.DebugMode, logger logging.Logger) (ctrl.Result, error) {
+	if cr.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+	ttl := r.completedTTLFor(cr, logger)
+	condition := meta.FindStatusCondition(cr.Status.Conditions, k8sCRLib.ConditionLogLevelSet)
+	if ttl <= 0 || condition == nil {
+		return ctrl.Result{}, nil
+	}
+
+	// the condition changes to false when the rollback starts, so the TTL includes the rollback itself
+	remaining := condition.LastTransitionTime.Add(ttl).Sub(timeNow())
+	if remaining > 0 {
+		logger.Info("DebugMode completed - delete later", "after", remaining.Round(time.Second))
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	logger.Info("Delete completed DebugMode after TTL", "ttl", ttl, "summary", condition.Message)
+	r.auditEnd(ctx, cr.UID, audit.ResultCompleted, condition.Message, logger)
+	// the precondition prevents deleting a DebugMode that was recreated in the meantime
+	uid := cr.UID
+	err := r.debugModeInterface.Delete(ctx, cr.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("ERROR: failed to delete completed DebugMode: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestParseCompletedTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "empty keeps completed debug modes", value: "", want: 0},
+		{name: "duration", value: " 24h ", want: 24 * time.Hour},
+		{name: "zero", value: "0", want: 0},
+		{name: "invalid", value: "one day", wantErr: `invalid completed TTL "one day"`},
+		{name: "negative", value: "-1h", wantErr: "must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := ParseCompletedTTL(tt.value)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ttl)
+		})
+	}
+}
+
+func Test_DebugModeReconciler_completedTTLFor(t *testing.T) {
+	logger := logging.FromContext(t.Context())
+	dmc := &DebugModeReconciler{}
+	dmc.SetCompletedTTL(time.Hour)
+
+	t.Run("should use operator wide TTL", func(t *testing.T) {
+		assert.Equal(t, time.Hour, dmc.completedTTLFor(&k8sCRLib.DebugMode{}, logger))
+	})
+	t.Run("should use TTL of annotation", func(t *testing.T) {
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{completedTTLAnnotation: "0"}}}
+
+		assert.Equal(t, time.Duration(0), dmc.completedTTLFor(cr, logger))
+	})
+	t.Run("should ignore invalid annotation", func(t *testing.T) {
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{completedTTLAnnotation: "soon"}}}
+
+		assert.Equal(t, time.Hour, dmc.completedTTLFor(cr, logger))
+	})
+}
+
+func Test_DebugModeReconciler_Reconcile_completed(t *testing.T) {
+	ctx := t.Context()
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: "debug-mode"}}
+	fixTimeNow(t)
+	completedAt := timeNow().Add(-30 * time.Minute)
+
+	newCompleted := func(deactivate time.Time) *k8sCRLib.DebugMode {
+		return &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID},
+			Spec:       k8sCRLib.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(deactivate), TargetLogLevel: "DEBUG"},
+			Status: k8sCRLib.DebugModeStatus{Conditions: []metav1.Condition{{
+				Type:               k8sCRLib.ConditionLogLevelSet,
+				Status:             metav1.ConditionFalse,
+				Reason:             string(k8sCRLib.DebugModeStatusCompleted),
+				Message:            "Debug-Mode deactivated",
+				LastTransitionTime: metav1.NewTime(completedAt),
+			}}},
+		}
+	}
+
+	t.Run("should keep completed debug mode without TTL", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(newCompleted(completedAt), nil)
+
+		// when
+		result, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+	t.Run("should requeue completed debug mode until the TTL expires", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetCompletedTTL(time.Hour)
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(newCompleted(completedAt), nil)
+
+		// when
+		result, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Minute}, result)
+	})
+	t.Run("should delete completed debug mode after the TTL", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		trail := newMockAuditTrail(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetCompletedTTL(time.Hour)
+		dmc.SetAuditTrail(trail)
+		cr := newCompleted(completedAt)
+		cr.Annotations = map[string]string{completedTTLAnnotation: "10m"}
+		uid := cr.UID
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		trail.EXPECT().RecordEnd(ctx, cr.UID, audit.ResultCompleted, "Debug-Mode deactivated").Return(nil)
+		debugModeClient.EXPECT().Delete(ctx, request.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}).Return(nil)
+
+		// when
+		result, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+	t.Run("should fail to delete completed debug mode", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetCompletedTTL(time.Minute)
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(newCompleted(completedAt), nil)
+		debugModeClient.EXPECT().Delete(ctx, request.Name, mock.Anything).Return(assert.AnError)
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to delete completed DebugMode")
+	})
+	t.Run("should not delete completed debug mode that is already deleted", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetCompletedTTL(time.Minute)
+		cr := newCompleted(completedAt)
+		cr.DeletionTimestamp = &metav1.Time{Time: completedAt}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+
+		// when
+		result, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+	t.Run("should re-arm completed debug mode with deactivate timestamp in the future", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		trail := newMockAuditTrail(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetCompletedTTL(time.Minute)
+		dmc.SetAuditTrail(trail)
+		cr := newCompleted(time.Now().Add(time.Hour))
+		rearmed := cr.DeepCopy()
+		rearmed.Status.Conditions = nil
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		trail.EXPECT().Archive(ctx, cr).Return(assert.AnError)
+		debugModeClient.EXPECT().AddOrUpdateLogLevelsSet(ctx, cr, false, "Debug-Mode re-armed", string(k8sCRLib.DebugModeStatusSet)).Return(rearmed, nil)
+		trail.EXPECT().Observe(ctx, rearmed).Return(nil)
+		// the activation continues as for a new debug mode
+		debugModeClient.EXPECT().AddFinalizer(ctx, rearmed, debugModeFinalizer).Return(nil, assert.AnError)
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to add finalizer")
+	})
+	t.Run("should fail to re-arm completed debug mode", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		cr := newCompleted(time.Now().Add(time.Hour))
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		debugModeClient.EXPECT().AddOrUpdateLogLevelsSet(ctx, cr, false, "Debug-Mode re-armed", string(k8sCRLib.DebugModeStatusSet)).Return(nil, assert.AnError)
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// componentSelectorAnnotation overrides the operator wide label selector of the component deployments for a single DebugMode.
+// An empty value excludes all components.
+const componentSelectorAnnotation = "debugmode.k8s.cloudogu.com/component-selector"
+
+// SetComponents sets the handler and the client used to change the log level of platform components during a debug mode
+// and the label selector of their deployments. An empty selector excludes all components by default.
+func (r *DebugModeReconciler) SetComponents(handler LogLevelHandler, deployments deploymentInterface, selector string) {
+	r.componentLogLevelHandler = handler
+	r.deploymentInterface = deployments
+	r.componentSelector = selector
+}
+
+// ParseComponentSelector validates the given label selector of component deployments.
+func ParseComponentSelector(value string) (string, error) {
+	selector := strings.TrimSpace(value)
+	if _, err := labels.Parse(selector); err != nil {
+		return "", fmt.Errorf("invalid component selector %q: %w", value, err)
+	}
+	return selector, nil
+}
+
+// componentSelectorFor returns the label selector of the component deployments of the given debug mode.
+// An invalid annotation is ignored, so the debug mode is never blocked by it.
+func (r *DebugModeReconciler) componentSelectorFor(cr *k8sCRLib.DebugMode, logger logging.Logger) string {
+	if cr == nil {
+		return r.componentSelector
+	}
+	value, found := cr.Annotations[componentSelectorAnnotation]
+	if !found {
+		return r.componentSelector
+	}
+	selector, err := ParseComponentSelector(value)
+	if err != nil {
+		logger.Error("ERROR: ignore annotation", "annotation", componentSelectorAnnotation, "error", err)
+		return r.componentSelector
+	}
+	return selector
+}
+
+func (r *DebugModeReconciler) iterateComponentsForDebugMode(ctx context.Context, act *activation) (bool, error) {
+	if r.componentLogLevelHandler == nil || act.componentSelector == "" {
+		return false, nil
+	}
+
+	deploymentList, err := r.deploymentInterface.List(ctx, metav1.ListOptions{LabelSelector: act.componentSelector})
+	if err != nil {
+		return false, fmt.Errorf("ERROR: Failed to list component deployments: %w", err)
+	}
+	if deploymentList == nil || len(deploymentList.Items) == 0 {
+		return false, nil
+	}
+
+	return r.activateComponents(ctx, deploymentList.Items, act)
+}
+
+// activateComponents first stores the original log levels of all components in a single state map update
+// and only afterward sets the target log level, like activateDogus.
+// Components that do not accept the target log level or whose log level cannot be restored are recorded as incompatible.
+func (r *DebugModeReconciler) activateComponents(ctx context.Context, deployments []appsv1.Deployment, act *activation) (bool, error) {
+	handler := r.componentLogLevelHandler
+	currentLevels := make([]loglevel.LogLevel, len(deployments))
+	skipped := make([]bool, len(deployments))
+	pending := map[string]StateEntry{}
+	for i, deployment := range deployments {
+		level, err := r.captureStateForElement(ctx, handler, deployment.Name, deployment, "", act.stateMap, pending, act.logger)
+		if errors.Is(err, loglevel.ErrUnsupportedLogLevel) {
+			elementLogger(act.logger, handler, deployment.Name).Info("Skip unsupported log level", "error", err)
+			skipped[i] = true
+			act.incompatibleComponents = append(act.incompatibleComponents, deployment.Name)
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		currentLevels[i] = level
+
+		if act.skips(level) {
+			elementLogger(act.logger, handler, deployment.Name).Info("Keep more verbose log level", "level", level, "target", act.targetLogLevel)
+			delete(pending, stateKey(handler.Kind(), deployment.Name))
+			skipped[i] = true
+			act.untouchedComponents = append(act.untouchedComponents, deployment.Name)
+		}
+	}
+
+	err := act.stateMap.storeEntries(ctx, pending)
+	if err != nil {
+		return false, fmt.Errorf("ERROR: failed to store original log levels: %w", err)
+	}
+
+	change := false
+	for i, deployment := range deployments {
+		if skipped[i] {
+			continue
+		}
+		componentChange, err := r.activateDebugModeForElement(ctx, handler, deployment.Name, deployment, currentLevels[i], act.targetLogLevel, act.logger)
+		change = change || componentChange
+		if errors.Is(err, loglevel.ErrUnsupportedLogLevel) {
+			elementLogger(act.logger, handler, deployment.Name).Info("Skip unsupported log level", "error", err)
+			act.incompatibleComponents = append(act.incompatibleComponents, deployment.Name)
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return change, nil
+}
+
+// rollbackComponents restores the stored log levels of all components and returns the names of the changed components.
+// Components are looked up by their stored entries instead of the label selector, so a changed selector or label
+// never prevents a rollback. Deleted deployments are recorded as vanished.
+func (r *DebugModeReconciler) rollbackComponents(ctx context.Context, rb *rollback) ([]string, error) {
+	if r.componentLogLevelHandler == nil {
+		return nil, nil
+	}
+
+	handler := r.componentLogLevelHandler
+	prefix := handler.Kind() + "."
+	var changed []string
+	for _, key := range slices.Sorted(maps.Keys(rb.stateMap.configMap.Data)) {
+		name, found := strings.CutPrefix(key, prefix)
+		if !found {
+			continue
+		}
+
+		deployment, err := r.deploymentInterface.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			rb.vanishedComponents = append(rb.vanishedComponents, name)
+			continue
+		}
+		if err != nil {
+			return changed, fmt.Errorf("ERROR: Failed to get deployment of component %s: %w", name, err)
+		}
+
+		componentChange, err := r.deactivateDebugModeForElement(ctx, handler, name, *deployment, "", rb)
+		if err != nil {
+			return changed, err
+		}
+		if componentChange {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed, nil
+}
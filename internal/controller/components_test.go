@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const testComponentSelector = "k8s.cloudogu.com/component.name=k8s-dogu-operator"
+
+func Test_ParseComponentSelector(t *testing.T) {
+	t.Run("should return trimmed selector", func(t *testing.T) {
+		selector, err := ParseComponentSelector(" " + testComponentSelector + " ")
+
+		require.NoError(t, err)
+		assert.Equal(t, testComponentSelector, selector)
+	})
+	t.Run("should accept empty selector", func(t *testing.T) {
+		selector, err := ParseComponentSelector("")
+
+		require.NoError(t, err)
+		assert.Empty(t, selector)
+	})
+	t.Run("should fail on invalid selector", func(t *testing.T) {
+		_, err := ParseComponentSelector("name in (a")
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid component selector")
+	})
+}
+
+func Test_DebugModeReconciler_componentSelectorFor(t *testing.T) {
+	logger := logging.FromContext(t.Context())
+	dmc := &DebugModeReconciler{componentSelector: testComponentSelector}
+
+	t.Run("should use operator setting without annotation", func(t *testing.T) {
+		assert.Equal(t, testComponentSelector, dmc.componentSelectorFor(&k8sCRLib.DebugMode{}, logger))
+	})
+	t.Run("should use selector of annotation", func(t *testing.T) {
+		// given
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{componentSelectorAnnotation: ""}}}
+
+		// when
+		selector := dmc.componentSelectorFor(cr, logger)
+
+		// then
+		assert.Empty(t, selector)
+	})
+	t.Run("should ignore invalid annotation", func(t *testing.T) {
+		// given
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{componentSelectorAnnotation: "name in (a"}}}
+
+		// when
+		selector := dmc.componentSelectorFor(cr, logger)
+
+		// then
+		assert.Equal(t, testComponentSelector, selector)
+	})
+}
+
+func Test_DebugModeReconciler_iterateComponentsForDebugMode(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("should skip components without selector", func(t *testing.T) {
+		// given
+		dmc := &DebugModeReconciler{componentLogLevelHandler: NewMockLogLevelHandler(t), deploymentInterface: newMockDeploymentInterface(t)}
+
+		// when
+		changed, err := dmc.iterateComponentsForDebugMode(ctx, &activation{logger: logging.FromContext(ctx)})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("should list deployments by selector", func(t *testing.T) {
+		// given
+		deployments := newMockDeploymentInterface(t)
+		deployments.EXPECT().List(ctx, metav1.ListOptions{LabelSelector: testComponentSelector}).Return(&appsv1.DeploymentList{}, nil)
+		dmc := &DebugModeReconciler{componentLogLevelHandler: NewMockLogLevelHandler(t), deploymentInterface: deployments}
+
+		// when
+		changed, err := dmc.iterateComponentsForDebugMode(ctx, &activation{logger: logging.FromContext(ctx), componentSelector: testComponentSelector})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("error listing deployments", func(t *testing.T) {
+		// given
+		deployments := newMockDeploymentInterface(t)
+		deployments.EXPECT().List(ctx, mock.Anything).Return(nil, assert.AnError)
+		dmc := &DebugModeReconciler{componentLogLevelHandler: NewMockLogLevelHandler(t), deploymentInterface: deployments}
+
+		// when
+		_, err := dmc.iterateComponentsForDebugMode(ctx, &activation{logger: logging.FromContext(ctx), componentSelector: testComponentSelector})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_DebugModeReconciler_activateComponents(t *testing.T) {
+	ctx := t.Context()
+	doguOperator := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "k8s-dogu-operator"}}
+	serviceDiscovery := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "k8s-service-discovery"}}
+	blueprintOperator := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "k8s-blueprint-operator"}}
+
+	t.Run("should store original log levels before setting the target log level", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		handler := NewMockLogLevelHandler(t)
+		handler.EXPECT().Kind().Return("component")
+		handler.EXPECT().GetLogLevelState(ctx, doguOperator).Return(loglevel.LogLevelState{Level: loglevel.LevelInfo, Unset: true}, nil)
+		handler.EXPECT().GetLogLevelState(ctx, serviceDiscovery).Return(loglevel.LogLevelState{}, fmt.Errorf("%w: set from a reference", loglevel.ErrUnsupportedLogLevel))
+		handler.EXPECT().GetLogLevelState(ctx, blueprintOperator).Return(explicitLevel(loglevel.LevelDebug), nil)
+		configMapClient := newMockConfigurationMap(t)
+		var stored *corev1.ConfigMap
+		configMapClient.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(
+			func(_ context.Context, cm *corev1.ConfigMap, _ metav1.UpdateOptions) (*corev1.ConfigMap, error) {
+				stored = cm
+				return cm, nil
+			})
+		handler.EXPECT().SetLogLevel(ctx, doguOperator, loglevel.LevelDebug).Return(nil)
+		dmc := &DebugModeReconciler{componentLogLevelHandler: handler}
+		act := &activation{
+			stateMap:       &StateMap{configMap: &corev1.ConfigMap{}, configMapInterface: configMapClient, logger: logging.FromContext(ctx)},
+			targetLogLevel: loglevel.LevelDebug,
+			logger:         logging.FromContext(ctx),
+		}
+
+		// when
+		changed, err := dmc.activateComponents(ctx, []appsv1.Deployment{doguOperator, serviceDiscovery, blueprintOperator}, act)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []string{"k8s-service-discovery"}, act.incompatibleComponents)
+		require.NotNil(t, stored)
+		expected, err := newStateEntry("INFO", true, "", "").marshal()
+		require.NoError(t, err)
+		assert.Equal(t, expected, stored.Data["component.k8s-dogu-operator"])
+		assert.Contains(t, stored.Data, "component.k8s-blueprint-operator")
+		assert.NotContains(t, stored.Data, "component.k8s-service-discovery")
+	})
+	t.Run("should keep more verbose components with raise-only", func(t *testing.T) {
+		// given
+		handler := NewMockLogLevelHandler(t)
+		handler.EXPECT().Kind().Return("component")
+		handler.EXPECT().GetLogLevelState(ctx, doguOperator).Return(explicitLevel(loglevel.LevelDebug), nil)
+		dmc := &DebugModeReconciler{componentLogLevelHandler: handler}
+		act := &activation{
+			stateMap:       &StateMap{configMap: &corev1.ConfigMap{}},
+			targetLogLevel: loglevel.LevelInfo,
+			logger:         logging.FromContext(ctx),
+			raiseOnly:      true,
+		}
+
+		// when
+		changed, err := dmc.activateComponents(ctx, []appsv1.Deployment{doguOperator}, act)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, []string{"k8s-dogu-operator"}, act.untouchedComponents)
+	})
+	t.Run("should record components not accepting the target log level", func(t *testing.T) {
+		// given
+		handler := NewMockLogLevelHandler(t)
+		handler.EXPECT().Kind().Return("component")
+		handler.EXPECT().GetLogLevelState(ctx, doguOperator).Return(explicitLevel(loglevel.LevelInfo), nil)
+		handler.EXPECT().SetLogLevel(ctx, doguOperator, loglevel.LevelTrace).Return(fmt.Errorf("%w: components do not accept TRACE", loglevel.ErrUnsupportedLogLevel))
+		dmc := &DebugModeReconciler{componentLogLevelHandler: handler}
+		act := &activation{
+			stateMap: &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{
+				"component.k8s-dogu-operator": `{"level":"INFO"}`,
+			}}},
+			targetLogLevel: loglevel.LevelTrace,
+			logger:         logging.FromContext(ctx),
+		}
+
+		// when
+		changed, err := dmc.activateComponents(ctx, []appsv1.Deployment{doguOperator}, act)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, []string{"k8s-dogu-operator"}, act.incompatibleComponents)
+	})
+	t.Run("error getting log level", func(t *testing.T) {
+		// given
+		handler := NewMockLogLevelHandler(t)
+		handler.EXPECT().Kind().Return("component")
+		handler.EXPECT().GetLogLevelState(ctx, doguOperator).Return(loglevel.LogLevelState{}, assert.AnError)
+		dmc := &DebugModeReconciler{componentLogLevelHandler: handler}
+		act := &activation{stateMap: &StateMap{configMap: &corev1.ConfigMap{}}, targetLogLevel: loglevel.LevelDebug, logger: logging.FromContext(ctx)}
+
+		// when
+		_, err := dmc.activateComponents(ctx, []appsv1.Deployment{doguOperator}, act)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_DebugModeReconciler_rollbackComponents(t *testing.T) {
+	ctx := t.Context()
+	doguOperator := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "k8s-dogu-operator"}}
+	storedEntry := func(t *testing.T, level string, unset bool) string {
+		value, err := newStateEntry(level, unset, level, "").marshal()
+		require.NoError(t, err)
+		return value
+	}
+
+	t.Run("should skip components without handler", func(t *testing.T) {
+		// given
+		dmc := &DebugModeReconciler{}
+		rb := &rollback{stateMap: &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"component.k8s-dogu-operator": "INFO"}}}}
+
+		// when
+		changed, err := dmc.rollbackComponents(ctx, rb)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, changed)
+	})
+	t.Run("should restore stored log levels and record deleted deployments", func(t *testing.T) {
+		// given
+		handler := NewMockLogLevelHandler(t)
+		handler.EXPECT().Kind().Return("component")
+		handler.EXPECT().GetLogLevelState(ctx, *doguOperator).Return(explicitLevel(loglevel.LevelDebug), nil)
+		handler.EXPECT().RestoreLogLevel(ctx, *doguOperator, loglevel.LogLevelState{Level: loglevel.LevelWarn, RawValue: "WARN"}).Return(nil)
+		deployments := newMockDeploymentInterface(t)
+		deployments.EXPECT().Get(ctx, "k8s-dogu-operator", metav1.GetOptions{}).Return(doguOperator, nil)
+		deployments.EXPECT().Get(ctx, "k8s-service-discovery", metav1.GetOptions{}).Return(nil,
+			apierrors.NewNotFound(schema.GroupResource{Resource: "deployments"}, "k8s-service-discovery"))
+		dmc := &DebugModeReconciler{componentLogLevelHandler: handler, deploymentInterface: deployments}
+		rb := &rollback{
+			stateMap: &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{
+				"dogu.nexus":                      storedEntry(t, "INFO", false),
+				"component.k8s-dogu-operator":     storedEntry(t, "WARN", false),
+				"component.k8s-service-discovery": storedEntry(t, "INFO", true),
+			}}},
+			logger: logging.FromContext(ctx),
+		}
+
+		// when
+		changed, err := dmc.rollbackComponents(ctx, rb)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"k8s-dogu-operator"}, changed)
+		assert.Equal(t, []string{"k8s-service-discovery"}, rb.vanishedComponents)
+		assert.Equal(t, "skipped deleted components: k8s-service-discovery", rb.summary())
+	})
+	t.Run("error getting deployment", func(t *testing.T) {
+		// given
+		handler := NewMockLogLevelHandler(t)
+		handler.EXPECT().Kind().Return("component")
+		deployments := newMockDeploymentInterface(t)
+		deployments.EXPECT().Get(ctx, "k8s-dogu-operator", metav1.GetOptions{}).Return(nil, assert.AnError)
+		dmc := &DebugModeReconciler{componentLogLevelHandler: handler, deploymentInterface: deployments}
+		rb := &rollback{
+			stateMap: &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"component.k8s-dogu-operator": storedEntry(t, "WARN", false)}}},
+			logger:   logging.FromContext(ctx),
+		}
+
+		// when
+		_, err := dmc.rollbackComponents(ctx, rb)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
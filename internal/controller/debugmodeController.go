@@ -2,27 +2,51 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
 	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
 	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	reconcilerTimeoutInSec = 60
-	phaseErrorString       = "ERROR failed to set phase %s: %w"
-	conditionErrorString   = "ERROR failed to set condition %s: %w"
+	// DefaultRequeueInterval is the default time after which a debug mode is checked again while log levels are changed.
+	DefaultRequeueInterval = 60 * time.Second
+
+	phaseErrorString     = "ERROR failed to set phase %s: %w"
+	conditionErrorString = "ERROR failed to set condition %s: %w"
+
+	// debugModeFinalizer keeps a deleted DebugMode until the original log levels of all dogus are restored.
+	debugModeFinalizer = "debugmode.k8s.cloudogu.com/rollback"
+	// forceDeleteAnnotation releases a deleted DebugMode without restoring the log levels if set to "true".
+	forceDeleteAnnotation = "debugmode.k8s.cloudogu.com/force-delete"
+
+	// ReasonReconcileFailed is the reason of the Warning events reporting a failed reconcile of a DebugMode.
+	ReasonReconcileFailed = "ReconcileFailed"
+	reconcileAction       = "Reconcile"
+
+	// concurrentChangeRequeueDelay is the time after which a debug mode is reconciled again after another writer has
+	// changed its state concurrently. The merged state is complete, so the next reconcile continues right away.
+	concurrentChangeRequeueDelay = time.Second
 )
 
 var (
@@ -35,6 +59,30 @@ type DebugModeReconciler struct {
 	doguInterface       doguInterface
 	configMapInterface  configurationMap
 	doguLogLevelHandler LogLevelHandler
+	addedDoguPolicy     AddedDoguPolicy
+	raiseOnly           bool
+	requeueInterval     time.Duration
+	doguConfigHandler   ConfigHandler
+	doguConfigProfiles  map[string]DoguConfigOverrides
+	// sensitiveDoguConfigHandler and secretInterface are nil if sensitive dogu config is not configured.
+	sensitiveDoguConfigHandler ConfigHandler
+	secretInterface            secretInterface
+	// componentLogLevelHandler and deploymentInterface are nil if components are not configured.
+	componentLogLevelHandler LogLevelHandler
+	deploymentInterface      deploymentInterface
+	componentSelector        string
+	// operatorLogLevel is nil if the log level of the operator is not adjusted during a debug mode.
+	operatorLogLevel operatorLogLevel
+	// eventRecorder is nil if the errors of failed reconciles are only logged.
+	eventRecorder eventRecorder
+	// auditTrail is nil if debug mode sessions are not recorded.
+	auditTrail auditTrail
+	// notifications is nil if no notifications are sent.
+	notifications *notifications
+	// completedTTL is the time completed DebugModes are kept. They are kept until deleted manually if it is not positive.
+	completedTTL time.Duration
+	// watchdog is nil if stuck reconciles are not detected.
+	watchdog reconcileWatchdog
 }
 
 func NewDebugModeReconciler(debugModeInterface debugModeInterface,
@@ -46,9 +94,54 @@ func NewDebugModeReconciler(debugModeInterface debugModeInterface,
 		doguInterface:       doguInterface,
 		configMapInterface:  configMapInterface,
 		doguLogLevelHandler: doguLogLevelHandler,
+		addedDoguPolicy:     AddedDoguPolicyKeep,
+		requeueInterval:     DefaultRequeueInterval,
 	}
 }
 
+// SetAddedDoguPolicy sets the operator wide policy for dogus installed while a debug mode is active.
+func (r *DebugModeReconciler) SetAddedDoguPolicy(policy AddedDoguPolicy) {
+	r.addedDoguPolicy = policy
+}
+
+// SetRaiseOnly sets whether debug modes only raise the verbosity of dogus by default.
+func (r *DebugModeReconciler) SetRaiseOnly(raiseOnly bool) {
+	r.raiseOnly = raiseOnly
+}
+
+// SetRequeueInterval sets the time after which a debug mode is checked again while log levels are changed.
+func (r *DebugModeReconciler) SetRequeueInterval(interval time.Duration) {
+	r.requeueInterval = interval
+}
+
+// SetDoguConfigHandler sets the handler used to change further dogu config keys during a debug mode.
+func (r *DebugModeReconciler) SetDoguConfigHandler(handler ConfigHandler) {
+	r.doguConfigHandler = handler
+}
+
+// SetSensitiveDoguConfig sets the handler used to change sensitive dogu config keys during a debug mode and the client
+// for the Secrets holding the sensitive overrides and the original sensitive values.
+func (r *DebugModeReconciler) SetSensitiveDoguConfig(handler ConfigHandler, secrets secretInterface) {
+	r.sensitiveDoguConfigHandler = handler
+	r.secretInterface = secrets
+}
+
+// SetDoguConfigProfiles sets the named dogu config overrides that can be selected by a DebugMode.
+func (r *DebugModeReconciler) SetDoguConfigProfiles(profiles map[string]DoguConfigOverrides) {
+	r.doguConfigProfiles = profiles
+}
+
+// SetEventRecorder sets the recorder reporting the errors of failed reconciles as Warning events of the DebugMode.
+// Unlike memory, the events are shared by all replicas, so the status API of every replica can serve them.
+func (r *DebugModeReconciler) SetEventRecorder(recorder eventRecorder) {
+	r.eventRecorder = recorder
+}
+
+// SetWatchdog sets the watchdog informed about every reconcile, so a stuck reconciler fails the liveness probe.
+func (r *DebugModeReconciler) SetWatchdog(watchdog reconcileWatchdog) {
+	r.watchdog = watchdog
+}
+
 // +kubebuilder:rbac:groups=k8s.cloudogu.com.k8s.cloudogu.com,resources=debugmodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=k8s.cloudogu.com.k8s.cloudogu.com,resources=debugmodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=k8s.cloudogu.com.k8s.cloudogu.com,resources=debugmodes/finalizers,verbs=update
@@ -56,49 +149,100 @@ func NewDebugModeReconciler(debugModeInterface debugModeInterface,
 func (r *DebugModeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
 	logger := logging.FromContext(ctx)
 
+	if r.watchdog != nil {
+		r.watchdog.Started(req.NamespacedName)
+	}
+	// failed is the DebugMode a failure is reported on, because cr is replaced by the result of every update
+	var failed *k8sCRLib.DebugMode
 	defer func() {
-		logger.Info(fmt.Sprintf("Finished Reconcile %v : %v", res, err))
+		logger.Info("Finished Reconcile", "result", res, "error", err)
+		if err != nil && failed != nil && r.eventRecorder != nil {
+			r.eventRecorder.Eventf(failed, nil, corev1.EventTypeWarning, ReasonReconcileFailed, reconcileAction, "%s", err.Error())
+		}
+		if r.watchdog != nil {
+			r.watchdog.Finished(req.NamespacedName, res, err)
+		}
 	}()
 
 	cr, err := r.debugModeInterface.Get(ctx, req.Name, metav1.GetOptions{})
+	failed = cr
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
-			logger.Error(fmt.Sprintf("ERROR: failed to get CR with name %s, %v", req.Name, err))
+			logger.Error("ERROR: failed to get CR", "name", req.Name, "error", err)
 			return ctrl.Result{}, ctrlclient.IgnoreNotFound(err)
 		}
 		cr = nil
 	}
-	logger.Info(fmt.Sprintf("Starting Reconcile for DebugMode: %v", cr))
+	logger.Info("Starting Reconcile", "debugMode", cr)
 
-	stateMap := NewStateMap(ctx, cr, r.configMapInterface)
+	if r.isRearmed(cr) {
+		cr, err = r.rearm(ctx, cr, logger)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if r.isCompleted(cr) {
+		// a completed debug mode has restored all log levels, so its deletion must not be blocked
+		_, err = r.releaseFinalizer(ctx, cr)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.cleanupCompleted(ctx, cr, logger)
+	}
 
-	var result ctrl.Result
+	r.observeAudit(ctx, cr, logger)
+	r.notifyExtended(ctx, cr)
+
+	if cr != nil && cr.DeletionTimestamp == nil && !controllerutil.ContainsFinalizer(cr, debugModeFinalizer) {
+		// the finalizer must be set before any log level is changed, so a deletion always leads to a rollback
+		cr, err = r.debugModeInterface.AddFinalizer(ctx, cr, debugModeFinalizer)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("ERROR: failed to add finalizer: %w", err)
+		}
+	}
+
+	// a completed CR must not create a new state map, so load it only afterward
+	stateMap, err := NewStateMap(ctx, req.Name, cr, r.configMapInterface)
+	if err != nil {
+		logger.Error("ERROR: failed to load state map", "error", err)
+		return ctrl.Result{}, fmt.Errorf("ERROR: failed to load state map: %w", err)
+	}
 
-	if r.isCompleted(cr) {
-		return ctrl.Result{}, nil
+	if r.isForceDeleted(cr) {
+		return ctrl.Result{}, r.forceDelete(ctx, cr, stateMap)
 	}
 
+	var result ctrl.Result
+
 	if r.isActive(cr) {
 		result, err = r.activateDebugMode(ctx, cr, stateMap)
 	} else {
 		result, err = r.deactivateDebugMode(ctx, cr, stateMap)
 	}
 
+	if errors.Is(err, errStateChangedConcurrently) {
+		// nothing failed, the entries of the other writer have been kept and must be used by the next reconcile
+		logger.Info("State changed concurrently - reconcile again", "error", err)
+		return ctrl.Result{RequeueAfter: concurrentChangeRequeueDelay}, nil
+	}
 	if err != nil {
 		var updateerror error
 		_, updateerror = r.debugModeInterface.UpdateStatusFailed(ctx, cr)
 		if updateerror != nil {
 			return ctrl.Result{}, updateerror
 		}
-		logger.Error(fmt.Sprintf("Reconciling failed: %v", err))
+		logger.Error("ERROR: reconciling failed", "error", err)
+		r.auditFailure(ctx, cr, err, logger)
+		r.notifyFailure(ctx, cr, err)
 		return ctrl.Result{}, err
 	}
 	return result, nil
 }
 
 func (r *DebugModeReconciler) activateDebugMode(ctx context.Context, cr *k8sCRLib.DebugMode, stateMap *StateMap) (ctrl.Result, error) {
-	logger := logging.FromContext(ctx)
+	logger := logging.FromContext(ctx).WithValues("phase", k8sCRLib.DebugModeStatusSet)
 	logger.Info("Activate DebugMode")
+	// the condition is reset below, so it tells afterward whether the activation has already been finished before
+	activated := meta.IsStatusConditionTrue(cr.Status.Conditions, k8sCRLib.ConditionLogLevelSet)
 
 	cr, err := r.debugModeInterface.UpdateStatusDebugModeSet(ctx, cr)
 	if err != nil {
@@ -115,24 +259,62 @@ func (r *DebugModeReconciler) activateDebugMode(ctx context.Context, cr *k8sCRLi
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("ERROR: invalid target log level %s", cr.Spec.TargetLogLevel)
 	}
+	r.raiseOperatorLogLevel(targetLevel)
+
+	doguConfig, err := r.doguConfigOverridesFor(cr)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ERROR: invalid dogu config: %w", err)
+	}
 
-	change, err = r.iterateElementsForDebugMode(ctx, true, stateMap, targetLevel, logger)
+	sensitiveDoguConfig, err := r.sensitiveDoguConfigOverridesFor(ctx, cr)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ERROR: invalid sensitive dogu config: %w", err)
+	}
+
+	var sensitiveState *SensitiveState
+	if len(sensitiveDoguConfig) > 0 {
+		sensitiveState, err = r.loadSensitiveState(ctx, stateMap, logger)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("ERROR: %w", err)
+		}
+	}
+
+	act := &activation{
+		stateMap:            stateMap,
+		targetLogLevel:      targetLevel,
+		logger:              logger,
+		raiseOnly:           r.raiseOnlyFor(cr, logger),
+		doguConfig:          doguConfig,
+		sensitiveDoguConfig: sensitiveDoguConfig,
+		sensitiveState:      sensitiveState,
+		componentSelector:   r.componentSelectorFor(cr, logger),
+		dogus:               doguSelectionFor(cr),
+	}
+	change, err = r.iterateElementsForDebugMode(ctx, act)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	if change {
 		// Trigger reconcile with timeout
-		logger.Info(fmt.Sprintf("Change detected - reconcile in %d seconds", reconcilerTimeoutInSec))
-		return ctrl.Result{RequeueAfter: reconcilerTimeoutInSec * time.Second}, nil
+		logger.Info("Change detected - reconcile again", "after", r.requeueInterval)
+		return ctrl.Result{RequeueAfter: r.requeueInterval}, nil
 	}
 
-	logger.Info(fmt.Sprintf("Done setting debug mode - reconcile at %s", cr.Spec.DeactivateTimestamp))
+	logger.Info("Done setting debug mode", "reconcileAt", cr.Spec.DeactivateTimestamp)
 
-	cr, err = r.debugModeInterface.AddOrUpdateLogLevelsSet(ctx, cr, true, "Debug-Mode set for all dogus and components", string(k8sCRLib.DebugModeStatusSet))
+	message := "Debug-Mode set for all dogus and components"
+	if summary := act.summary(); summary != "" {
+		message = fmt.Sprintf("%s - %s", message, summary)
+	}
+	cr, err = r.debugModeInterface.AddOrUpdateLogLevelsSet(ctx, cr, true, message, string(k8sCRLib.DebugModeStatusSet))
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf(conditionErrorString, k8sCRLib.DebugModeStatusSet, err)
 	}
+	r.auditChanges(ctx, cr, stateMap, logger)
+	if !activated {
+		r.notifyActivated(ctx, cr, stateMap)
+	}
 	cr, err = r.debugModeInterface.UpdateStatusWaitForRollback(ctx, cr)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf(phaseErrorString, k8sCRLib.DebugModeStatusWaitForRollback, err)
@@ -142,30 +324,34 @@ func (r *DebugModeReconciler) activateDebugMode(ctx context.Context, cr *k8sCRLi
 	return ctrl.Result{RequeueAfter: time.Until(cr.Spec.DeactivateTimestamp.Time)}, nil
 }
 
-func (r *DebugModeReconciler) activateDebugModeForElement(ctx context.Context, handler loglevel.LogLevelHandler, name string, element any, stateMap *StateMap, targetLogLevel loglevel.LogLevel, logger logging.Logger) (bool, error) {
-	key := fmt.Sprintf("%s.%s", handler.Kind(), name)
-	logLevel, e := handler.GetLogLevel(ctx, element)
+// captureStateForElement reads the current log level of the element and adds it to the pending state entries,
+// if the state map does not already hold an original level for it.
+func (r *DebugModeReconciler) captureStateForElement(ctx context.Context, handler loglevel.LogLevelHandler, name string, element any, version string, stateMap *StateMap, pending map[string]StateEntry, logger logging.Logger) (loglevel.LogLevel, error) {
+	logger = elementLogger(logger, handler, name)
+	key := stateKey(handler.Kind(), name)
+	state, e := handler.GetLogLevelState(ctx, element)
 	if e != nil {
-		return false, fmt.Errorf("ERROR: Failed to get LogLevel for %s %s: %w", handler.Kind(), name, e)
+		return loglevel.LevelUnknown, fmt.Errorf("ERROR: Failed to get LogLevel for %s %s: %w", handler.Kind(), name, e)
 	}
 
 	current := stateMap.getValueFromMap(key)
 
-	logger.Info(fmt.Sprintf("Loglevel for %s '%s' - current:%s - cr-state: %s", handler.Kind(), name, logLevel, current))
+	logger.Info("Read log level", "level", state.Level, "unset", state.Unset, "stored", current)
 
-	// this is the first time this dogu is checked -> store current level in configMap
+	// this is the first time this element is checked -> store current level in configMap
 	if current == "" {
-		logger.Info(fmt.Sprintf("Update state map for %s '%s': %s", handler.Kind(), name, logLevel))
-		e = stateMap.updateStateMap(ctx, key, logLevel.String())
-		if e != nil {
-			return false, fmt.Errorf("ERROR: failed to update configmap for %s with level: %s :%w", key, logLevel.String(), e)
-		}
+		logger.Info("Update state map", "level", state.Level)
+		pending[key] = newStateEntry(state.Level.String(), state.Unset, state.RawValue, version)
 	}
 
+	return state.Level, nil
+}
+
+func (r *DebugModeReconciler) activateDebugModeForElement(ctx context.Context, handler loglevel.LogLevelHandler, name string, element any, logLevel loglevel.LogLevel, targetLogLevel loglevel.LogLevel, logger logging.Logger) (bool, error) {
 	// current log level does not match target level
 	if !strings.EqualFold(logLevel.String(), targetLogLevel.String()) {
-		logger.Info(fmt.Sprintf("Change loglevel for '%s': from %s to %s", name, logLevel, targetLogLevel.String()))
-		e = handler.SetLogLevel(ctx, element, targetLogLevel)
+		elementLogger(logger, handler, name).Info("Change log level", "from", logLevel, "level", targetLogLevel)
+		e := handler.SetLogLevel(ctx, element, targetLogLevel)
 		if e != nil {
 			return false, fmt.Errorf("ERROR: failed to set log level %s for %s: %s :%w", targetLogLevel.String(), handler.Kind(), name, e)
 		}
@@ -176,7 +362,7 @@ func (r *DebugModeReconciler) activateDebugModeForElement(ctx context.Context, h
 }
 
 func (r *DebugModeReconciler) deactivateDebugMode(ctx context.Context, cr *k8sCRLib.DebugMode, stateMap *StateMap) (ctrl.Result, error) {
-	logger := logging.FromContext(ctx)
+	logger := logging.FromContext(ctx).WithValues("phase", k8sCRLib.DebugModeStatusRollback)
 	logger.Info("Deactivate DebugMode")
 	var err error
 	var targetLevel loglevel.LogLevel
@@ -187,7 +373,11 @@ func (r *DebugModeReconciler) deactivateDebugMode(ctx context.Context, cr *k8sCR
 			return ctrl.Result{}, fmt.Errorf(phaseErrorString, k8sCRLib.DebugModeStatusRollback, err)
 		}
 
-		cr, err = r.debugModeInterface.AddOrUpdateLogLevelsSet(ctx, cr, false, "Deactivating Debug-Mode in progress", string(k8sCRLib.DebugModeStatusRollback))
+		message := "Deactivating Debug-Mode in progress"
+		if cr.DeletionTimestamp != nil {
+			message = "Debug-Mode deleted - restoring log levels in progress"
+		}
+		cr, err = r.debugModeInterface.AddOrUpdateLogLevelsSet(ctx, cr, false, message, string(k8sCRLib.DebugModeStatusRollback))
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf(conditionErrorString, k8sCRLib.DebugModeStatusRollback, err)
 		}
@@ -198,18 +388,44 @@ func (r *DebugModeReconciler) deactivateDebugMode(ctx context.Context, cr *k8sCR
 		}
 	}
 	change := false
+	rb := &rollback{
+		stateMap:        stateMap,
+		addedDoguPolicy: r.addedDoguPolicyFor(cr, logger),
+		targetLogLevel:  targetLevel,
+		logger:          logger,
+		raiseOnly:       r.raiseOnlyFor(cr, logger),
+		dogus:           doguSelectionFor(cr),
+	}
 
-	change, err = r.iterateElementsForDebugMode(ctx, false, stateMap, targetLevel, logger)
+	rb.sensitiveState, err = r.loadSensitiveState(ctx, stateMap, logger)
 	if err != nil {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, fmt.Errorf("ERROR: %w", err)
+	}
+
+	if stateMap.isEmpty() && rb.sensitiveState.isEmpty() {
+		// original log levels are always stored before any change, so no dogu has been changed by this debug mode
+		logger.Info("No original log levels stored - nothing to restore")
+	} else {
+		change, err = r.rollbackElements(ctx, rb)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
 	if change {
 		// Trigger reconcile with timeout
-		logger.Info(fmt.Sprintf("Change detected - reconcile in %d seconds", reconcilerTimeoutInSec))
-		return ctrl.Result{RequeueAfter: reconcilerTimeoutInSec * time.Second}, nil
+		logger.Info("Change detected - reconcile again", "after", r.requeueInterval)
+		return ctrl.Result{RequeueAfter: r.requeueInterval}, nil
+	}
+
+	// the sensitive values are deleted first, so they never remain without the state map referring to them
+	err = rb.sensitiveState.Destroy(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ERROR failed to delete secret: %w", err)
 	}
 
+	// the changes are collected before the state map holding them is deleted
+	changes := r.notifiedChanges(stateMap, cr)
 	// the current statemap stores the values of this debugmode - if the debug mode is deactivated, the statemap is no longer needed
 	destroy, err := stateMap.Destroy(ctx)
 	if err != nil {
@@ -218,47 +434,105 @@ func (r *DebugModeReconciler) deactivateDebugMode(ctx context.Context, cr *k8sCR
 	if destroy {
 		logger.Debug("StateMap deleted")
 	}
+	r.resetOperatorLogLevel()
+
+	message := "Debug-Mode deactivated"
+	if summary := rb.summary(); summary != "" {
+		logger.Info("Rollback finished with deviations", "deviations", summary)
+		message = fmt.Sprintf("%s - %s", message, summary)
+	}
 
 	// if the CR is deleted, the status must not be set
 	if cr != nil {
-		logger.Info(fmt.Sprintf("Done unsetting debug mode - reconcile at %s", cr.Spec.DeactivateTimestamp))
-		cr, err = r.debugModeInterface.AddOrUpdateLogLevelsSet(ctx, cr, false, "Debug-Mode deactivated", string(k8sCRLib.DebugModeStatusCompleted))
+		logger.Info("Done unsetting debug mode", "reconcileAt", cr.Spec.DeactivateTimestamp)
+		cr, err = r.debugModeInterface.AddOrUpdateLogLevelsSet(ctx, cr, false, message, string(k8sCRLib.DebugModeStatusCompleted))
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf(conditionErrorString, k8sCRLib.DebugModeStatusCompleted, err)
 		}
-		_, err = r.debugModeInterface.UpdateStatusCompleted(ctx, cr)
+		cr, err = r.debugModeInterface.UpdateStatusCompleted(ctx, cr)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf(phaseErrorString, k8sCRLib.DebugModeStatusCompleted, err)
 		}
+		result := audit.ResultCompleted
+		if cr.DeletionTimestamp != nil {
+			result = audit.ResultDeleted
+		}
+		r.auditEnd(ctx, cr.UID, result, message, logger)
+		r.notifyCompleted(ctx, newNotification(notify.EventCompleted, cr), result, message, changes)
+		// all log levels are restored - a pending deletion may proceed now
+		_, err = r.releaseFinalizer(ctx, cr)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 	// there were no log level changes, so we wait for the debugMode to end
 	return ctrl.Result{}, nil
 }
 
-func (r *DebugModeReconciler) deactivateDebugModeForElement(ctx context.Context, handler loglevel.LogLevelHandler, name string, element any, stateMap *StateMap, logger logging.Logger) (bool, error) {
-	key := fmt.Sprintf("%s.%s", handler.Kind(), name)
-	logLevel, e := handler.GetLogLevel(ctx, element)
+// deactivateDebugModeForElement restores the stored log level of the element.
+// Elements without a stored log level are handled according to the added dogu policy of the rollback.
+func (r *DebugModeReconciler) deactivateDebugModeForElement(ctx context.Context, handler loglevel.LogLevelHandler, name string, element any, version string, rb *rollback) (bool, error) {
+	logger := elementLogger(rb.logger, handler, name)
+	key := stateKey(handler.Kind(), name)
+	state, e := handler.GetLogLevelState(ctx, element)
 	if e != nil {
 		return false, fmt.Errorf("ERROR: Failed to get LogLevel for %s %s: %w", handler.Kind(), name, e)
 	}
+	logLevel := state.Level
 
-	current := stateMap.getValueFromMap(key)
+	entry, found, err := rb.stateMap.getEntry(key)
+	if err != nil {
+		return false, fmt.Errorf("ERROR: invalid stored state for %s: %w", name, err)
+	}
 
-	logger.Info(fmt.Sprintf("Loglevel for %s '%s' - current:%s - cr-state: %s", handler.Kind(), name, logLevel, current))
+	logger.Info("Read log level", "level", logLevel, "unset", state.Unset, "stored", entry.Level, "storedUnset", entry.Unset)
 
-	if current == "" {
-		return false, fmt.Errorf("ERROR: no stored fallback loglevel for %s", name)
+	if !found && !selects(rb.dogus, name) {
+		logger.Info("No stored log level - not selected by the debug mode")
+		return false, nil
+	}
+
+	if !found && rb.untouched(state) {
+		logger.Info("No stored log level - keep more verbose level", "level", state.Level)
+		return false, nil
+	}
+
+	if !found {
+		rb.added = append(rb.added, name)
+		return r.rollbackAddedElement(ctx, handler, name, element, state, rb)
+	}
+
+	// the log level was not configured before the debug mode -> remove it instead of pinning the default
+	if entry.Unset {
+		return r.resetElement(ctx, handler, name, element, state, logger)
 	}
 
-	storedLevel, err := loglevel.CreateLogLevelFromString(current)
+	// an original value the operator could not parse is written back verbatim, it cannot be checked against the version
+	if entry.Level == loglevel.LevelUnknown.String() && entry.RawValue != "" {
+		return r.restoreRawValue(ctx, handler, name, element, state, entry.RawValue, logger)
+	}
+
+	storedLevel, err := loglevel.CreateLogLevelFromString(entry.Level)
 	if err != nil {
-		return false, fmt.Errorf("ERROR: invalid stored log level %s", storedLevel)
+		return false, fmt.Errorf("ERROR: invalid stored log level %s", entry.Level)
+	}
+
+	if entry.DoguVersion != "" && version != "" && entry.DoguVersion != version {
+		supported, e := handler.IsLogLevelSupported(ctx, element, storedLevel)
+		if e != nil {
+			return false, fmt.Errorf("ERROR: failed to check log level %s for %s %s: %w", storedLevel, handler.Kind(), name, e)
+		}
+		if !supported {
+			logger.Info("Stored log level is not supported by the current version anymore", "level", storedLevel, "version", version, "capturedVersion", entry.DoguVersion)
+			rb.fallback = append(rb.fallback, name)
+			return r.resetElement(ctx, handler, name, element, state, logger)
+		}
 	}
 
 	// current log level does not match stored level
 	if !strings.EqualFold(logLevel.String(), storedLevel.String()) {
-		logger.Info(fmt.Sprintf("Change loglevel for '%s': from %s to %s", name, logLevel, storedLevel))
-		e = handler.SetLogLevel(ctx, element, storedLevel)
+		logger.Info("Change log level", "from", logLevel, "level", storedLevel)
+		e = handler.RestoreLogLevel(ctx, element, loglevel.LogLevelState{Level: storedLevel, RawValue: entry.RawValue})
 		if e != nil {
 			return false, fmt.Errorf("ERROR: failed to set log level %s for %s: %s :%w", storedLevel.String(), handler.Kind(), name, e)
 		}
@@ -268,22 +542,65 @@ func (r *DebugModeReconciler) deactivateDebugModeForElement(ctx context.Context,
 	return false, nil
 }
 
+// restoreRawValue writes an original value back that is no known log level, e.g. "verbose".
+func (r *DebugModeReconciler) restoreRawValue(ctx context.Context, handler loglevel.LogLevelHandler, name string, element any, state loglevel.LogLevelState, rawValue string, logger logging.Logger) (bool, error) {
+	if state.RawValue == rawValue {
+		return false, nil
+	}
+	logger.Info("Restore unknown log level verbatim", "from", state.Level, "value", rawValue)
+	e := handler.RestoreLogLevel(ctx, element, loglevel.LogLevelState{Level: loglevel.LevelUnknown, RawValue: rawValue})
+	if e != nil {
+		return false, fmt.Errorf("ERROR: failed to restore log level %s for %s: %s :%w", rawValue, handler.Kind(), name, e)
+	}
+	return true, nil
+}
+
+// rollbackAddedElement applies the added dogu policy to an element that has no stored log level.
+func (r *DebugModeReconciler) rollbackAddedElement(ctx context.Context, handler loglevel.LogLevelHandler, name string, element any, state loglevel.LogLevelState, rb *rollback) (bool, error) {
+	switch rb.addedDoguPolicy {
+	case AddedDoguPolicyRestoreDefault:
+		return r.resetElement(ctx, handler, name, element, state, rb.logger)
+	case AddedDoguPolicyTargeted:
+		if state.Level != rb.targetLogLevel {
+			return false, nil
+		}
+		return r.resetElement(ctx, handler, name, element, state, rb.logger)
+	default:
+		elementLogger(rb.logger, handler, name).Info("No stored log level - keep level", "level", state.Level)
+		return false, nil
+	}
+}
+
+// resetElement removes an explicitly set log level, so the element uses its default again.
+func (r *DebugModeReconciler) resetElement(ctx context.Context, handler loglevel.LogLevelHandler, name string, element any, state loglevel.LogLevelState, logger logging.Logger) (bool, error) {
+	if state.Unset {
+		return false, nil
+	}
+	elementLogger(logger, handler, name).Info("Reset log level", "from", state.Level)
+	e := handler.ResetLogLevel(ctx, element)
+	if e != nil {
+		return false, fmt.Errorf("ERROR: failed to reset log level for %s: %s :%w", handler.Kind(), name, e)
+	}
+	return true, nil
+}
+
 func (r *DebugModeReconciler) isActive(debugCR *k8sCRLib.DebugMode) bool {
 	if debugCR == nil {
-		defLogger.Info(fmt.Sprintf("CR deleted, active: %t", false))
+		defLogger.Info("CR deleted", "active", false)
 		return false
 	}
 	if debugCR.DeletionTimestamp != nil {
-		defLogger.Info(fmt.Sprintf("CR marked for deletion, active: %s: %t", debugCR.DeletionTimestamp, false))
+		defLogger.Info("CR marked for deletion", "deletionTimestamp", debugCR.DeletionTimestamp, "active", false)
+		return false
 	}
 	after := debugCR.Spec.DeactivateTimestamp.After(time.Now())
-	defLogger.Info(fmt.Sprintf("Check if active: %s: %t", debugCR.Spec.DeactivateTimestamp, after))
+	defLogger.Info("Check if active", "deactivateTimestamp", debugCR.Spec.DeactivateTimestamp, "active", after)
 	return after
 }
 
 func (r *DebugModeReconciler) isCompleted(debugCR *k8sCRLib.DebugMode) bool {
 	if debugCR == nil {
-		defLogger.Info(fmt.Sprintf("CR deleted, completed: %t", false))
+		defLogger.Info("CR deleted", "completed", false)
 		return false
 	}
 	for _, cond := range debugCR.Status.Conditions {
@@ -294,50 +611,365 @@ func (r *DebugModeReconciler) isCompleted(debugCR *k8sCRLib.DebugMode) bool {
 	return false
 }
 
-func (r *DebugModeReconciler) iterateElementsForDebugMode(ctx context.Context, activate bool, stateMap *StateMap, targetLogLevel loglevel.LogLevel, logger logging.Logger) (bool, error) {
-	doguChange, err := r.iterateDogusForDebugMode(ctx, activate, stateMap, targetLogLevel, logger)
+// isForceDeleted returns true if the DebugMode is deleted and should be released without restoring the log levels.
+func (r *DebugModeReconciler) isForceDeleted(debugCR *k8sCRLib.DebugMode) bool {
+	if debugCR == nil || debugCR.DeletionTimestamp == nil {
+		return false
+	}
+	return debugCR.Annotations[forceDeleteAnnotation] == "true"
+}
+
+// forceDelete releases a deleted DebugMode without restoring the log levels. The stored original levels are
+// discarded as well, because nothing would ever restore them after the DebugMode is gone.
+func (r *DebugModeReconciler) forceDelete(ctx context.Context, cr *k8sCRLib.DebugMode, stateMap *StateMap) error {
+	logger := logging.FromContext(ctx)
+	logger.Info("WARNING: DebugMode is force deleted - log levels are not restored", "name", cr.Name, "stateMap", stateMap.configMap.Data)
+
+	sensitiveState, err := r.loadSensitiveState(ctx, stateMap, logger)
+	if err != nil {
+		return fmt.Errorf("ERROR: %w", err)
+	}
+	err = sensitiveState.Destroy(ctx)
+	if err != nil {
+		return fmt.Errorf("ERROR failed to delete secret: %w", err)
+	}
+
+	changes := r.notifiedChanges(stateMap, cr)
+	_, err = stateMap.Destroy(ctx)
+	if err != nil {
+		return fmt.Errorf("ERROR failed to delete configmap: %w", err)
+	}
+	r.resetOperatorLogLevel()
+	message := "Debug-Mode force deleted - log levels not restored"
+	r.auditEnd(ctx, cr.UID, audit.ResultForceDeleted, message, logger)
+	r.notifyCompleted(ctx, newNotification(notify.EventCompleted, cr), audit.ResultForceDeleted, message, changes)
+
+	_, err = r.releaseFinalizer(ctx, cr)
+	return err
+}
+
+// releaseFinalizer removes the rollback finalizer from the DebugMode if it is set.
+func (r *DebugModeReconciler) releaseFinalizer(ctx context.Context, cr *k8sCRLib.DebugMode) (*k8sCRLib.DebugMode, error) {
+	if cr == nil || !controllerutil.ContainsFinalizer(cr, debugModeFinalizer) {
+		return cr, nil
+	}
+
+	cr, err := r.debugModeInterface.RemoveFinalizer(ctx, cr, debugModeFinalizer)
+	if err != nil {
+		return nil, fmt.Errorf("ERROR: failed to remove finalizer: %w", err)
+	}
+	return cr, nil
+}
+
+func (r *DebugModeReconciler) iterateElementsForDebugMode(ctx context.Context, act *activation) (bool, error) {
+	doguChange, err := r.iterateDogusForDebugMode(ctx, act)
 	if err != nil {
 		return doguChange, fmt.Errorf("ERROR failed to iterate dogus: %w", err)
 	}
 
-	return doguChange, nil
+	componentChange, err := r.iterateComponentsForDebugMode(ctx, act)
+	if err != nil {
+		return doguChange || componentChange, fmt.Errorf("ERROR failed to iterate components: %w", err)
+	}
+
+	return doguChange || componentChange, nil
 }
 
-func (r *DebugModeReconciler) iterateDogusForDebugMode(ctx context.Context, activate bool, stateMap *StateMap, targetLogLevel loglevel.LogLevel, logger logging.Logger) (bool, error) {
-	change := false
+func (r *DebugModeReconciler) iterateDogusForDebugMode(ctx context.Context, act *activation) (bool, error) {
 	// Dogus
 	doguList, err := r.doguInterface.List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return false, fmt.Errorf("ERROR: Failed to list dogus: %w", err)
 	}
-	if doguList != nil && len(doguList.Items) > 0 {
-		doguChange := false
-		for _, dogu := range doguList.Items {
-			if activate {
-				doguChange, err = r.activateDebugModeForElement(ctx, r.doguLogLevelHandler, dogu.Name, dogu, stateMap, targetLogLevel, logger)
-			} else {
-				doguChange, err = r.deactivateDebugModeForElement(ctx, r.doguLogLevelHandler, dogu.Name, dogu, stateMap, logger)
+	if doguList == nil {
+		doguList = &v2.DoguList{}
+	}
+	for _, name := range act.dogus {
+		if !slices.ContainsFunc(doguList.Items, func(dogu v2.Dogu) bool { return dogu.Name == name }) {
+			act.unknownDogus = append(act.unknownDogus, name)
+		}
+	}
+	if len(doguList.Items) == 0 {
+		return false, nil
+	}
+
+	return r.activateDogus(ctx, doguList.Items, act)
+}
+
+func (r *DebugModeReconciler) rollbackElements(ctx context.Context, rb *rollback) (bool, error) {
+	doguChange, err := r.rollbackDogus(ctx, rb)
+	if err != nil {
+		return doguChange, fmt.Errorf("ERROR failed to iterate dogus: %w", err)
+	}
+
+	changedComponents, err := r.rollbackComponents(ctx, rb)
+	if err != nil {
+		return doguChange || len(changedComponents) > 0, fmt.Errorf("ERROR failed to iterate components: %w", err)
+	}
+
+	return doguChange || len(changedComponents) > 0, nil
+}
+
+// rollbackDogus restores the stored log levels of all installed dogus and records dogus
+// that have been uninstalled since their log level was stored.
+func (r *DebugModeReconciler) rollbackDogus(ctx context.Context, rb *rollback) (bool, error) {
+	change := false
+	doguList, err := r.doguInterface.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("ERROR: Failed to list dogus: %w", err)
+	}
+	if doguList == nil {
+		doguList = &v2.DoguList{}
+	}
+
+	installed := make([]string, 0, len(doguList.Items))
+	for _, dogu := range doguList.Items {
+		installed = append(installed, dogu.Name)
+		doguChange, err := r.deactivateDebugModeForElement(ctx, r.doguLogLevelHandler, dogu.Name, dogu, doguVersion(dogu), rb)
+		change = change || doguChange
+		if err != nil {
+			return false, err
+		}
+		configChange, err := r.rollbackDoguConfig(ctx, dogu, rb)
+		change = change || configChange
+		if err != nil {
+			return false, err
+		}
+	}
+	rb.recordVanished(r.doguLogLevelHandler.Kind(), installed)
+	rb.recordVanished(doguConfigStateKind, installed)
+
+	return change, nil
+}
+
+// activateDogus first stores the original log levels of all dogus in a single state map update
+// and only afterward sets the target log level, so no dogu is changed without a stored fallback.
+// Dogus that do not accept the target log level keep their log level and are recorded as incompatible.
+func (r *DebugModeReconciler) activateDogus(ctx context.Context, dogus []v2.Dogu, act *activation) (bool, error) {
+	currentLevels := make([]loglevel.LogLevel, len(dogus))
+	skipped := make([]bool, len(dogus))
+	pending := map[string]StateEntry{}
+	for i, dogu := range dogus {
+		if !selects(act.dogus, dogu.Name) {
+			skipped[i] = true
+			continue
+		}
+		level, err := r.captureStateForElement(ctx, r.doguLogLevelHandler, dogu.Name, dogu, doguVersion(dogu), act.stateMap, pending, act.logger)
+		if err != nil {
+			return false, err
+		}
+		currentLevels[i] = level
+
+		if act.skips(level) {
+			elementLogger(act.logger, r.doguLogLevelHandler, dogu.Name).Info("Keep more verbose log level", "level", level, "target", act.targetLogLevel)
+			delete(pending, stateKey(r.doguLogLevelHandler.Kind(), dogu.Name))
+			skipped[i] = true
+			act.untouched = append(act.untouched, dogu.Name)
+		}
+	}
+
+	err := act.stateMap.storeEntries(ctx, pending)
+	if err != nil {
+		return false, fmt.Errorf("ERROR: failed to store original log levels: %w", err)
+	}
+
+	change := false
+	for i, dogu := range dogus {
+		if skipped[i] {
+			continue
+		}
+		doguChange, err := r.activateDebugModeForElement(ctx, r.doguLogLevelHandler, dogu.Name, dogu, currentLevels[i], act.targetLogLevel, act.logger)
+		change = change || doguChange
+		if errors.Is(err, loglevel.ErrUnsupportedLogLevel) {
+			elementLogger(act.logger, r.doguLogLevelHandler, dogu.Name).Info("Skip unsupported log level", "error", err)
+			act.incompatible = append(act.incompatible, dogu.Name)
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	configChange, err := r.activateDoguConfig(ctx, dogus, act)
+	if err != nil {
+		return false, err
+	}
+
+	return change || configChange, nil
+}
+
+// activateDoguConfig applies the overrides of the dogu config and of the sensitive dogu config.
+func (r *DebugModeReconciler) activateDoguConfig(ctx context.Context, dogus []v2.Dogu, act *activation) (bool, error) {
+	change, err := r.activateConfigOverrides(ctx, dogus, act.doguConfig, r.doguConfigHandler, act.stateMap, act)
+	if err != nil {
+		return false, err
+	}
+
+	sensitiveChange, err := r.activateConfigOverrides(ctx, dogus, act.sensitiveDoguConfig, r.sensitiveDoguConfigHandler, act.sensitiveState, act)
+	if err != nil {
+		return false, fmt.Errorf("ERROR: failed to apply sensitive dogu config: %w", err)
+	}
+	return change || sensitiveChange, nil
+}
+
+// activateConfigOverrides stores the original values of all overridden config keys in a single update of the store
+// and only afterward writes the overrides. Keys stored by a previous reconcile keep their original value.
+func (r *DebugModeReconciler) activateConfigOverrides(ctx context.Context, dogus []v2.Dogu, overrides DoguConfigOverrides, handler ConfigHandler, store stateStore, act *activation) (bool, error) {
+	if len(overrides) == 0 || handler == nil {
+		return false, nil
+	}
+
+	installed := make(map[string]v2.Dogu, len(dogus))
+	for _, dogu := range dogus {
+		installed[dogu.Name] = dogu
+	}
+
+	pending := map[string]StateEntry{}
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		dogu, found := installed[name]
+		if !found {
+			if !slices.Contains(act.notInstalled, name) {
+				act.notInstalled = append(act.notInstalled, name)
 			}
-			change = change || doguChange
-			if err != nil {
-				return false, err
+			continue
+		}
+
+		key := stateKey(doguConfigStateKind, name)
+		entry, stored, err := store.getEntry(key)
+		if err != nil {
+			return false, fmt.Errorf("ERROR: invalid stored dogu config for %s: %w", name, err)
+		}
+
+		var missingKeys []string
+		for configKey := range overrides[name] {
+			if _, captured := entry.Values[configKey]; !captured {
+				missingKeys = append(missingKeys, configKey)
 			}
 		}
+		if len(missingKeys) == 0 {
+			continue
+		}
+		slices.Sort(missingKeys)
+
+		states, err := handler.GetConfigValues(ctx, dogu, missingKeys)
+		if err != nil {
+			return false, fmt.Errorf("ERROR: failed to get dogu config of %s: %w", name, err)
+		}
+		act.logger.Info("Store original dogu config", "dogu", name, "keys", missingKeys)
+
+		values := maps.Clone(entry.Values)
+		if values == nil {
+			values = map[string]loglevel.ConfigValueState{}
+		}
+		maps.Copy(values, states)
+		version := doguVersion(dogu)
+		if stored {
+			version = entry.DoguVersion
+		}
+		pending[key] = newConfigStateEntry(values, version)
 	}
 
+	err := store.storeEntries(ctx, pending)
+	if err != nil {
+		return false, fmt.Errorf("ERROR: failed to store original dogu config: %w", err)
+	}
+
+	change := false
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		dogu, found := installed[name]
+		if !found {
+			continue
+		}
+		changed, err := handler.SetConfigValues(ctx, dogu, overrides[name])
+		if err != nil {
+			return false, fmt.Errorf("ERROR: failed to set dogu config of %s: %w", name, err)
+		}
+		if changed {
+			act.logger.Info("Changed dogu config", "dogu", name)
+		}
+		change = change || changed
+	}
 	return change, nil
 }
 
+// rollbackDoguConfig restores the original values of all dogu config and sensitive dogu config keys stored for the dogu.
+func (r *DebugModeReconciler) rollbackDoguConfig(ctx context.Context, dogu v2.Dogu, rb *rollback) (bool, error) {
+	change, err := r.rollbackConfig(ctx, dogu, r.doguConfigHandler, rb.stateMap, rb)
+	if err != nil {
+		return false, err
+	}
+
+	sensitiveChange, err := r.rollbackConfig(ctx, dogu, r.sensitiveDoguConfigHandler, rb.sensitiveState, rb)
+	if err != nil {
+		return false, fmt.Errorf("ERROR: failed to restore sensitive dogu config: %w", err)
+	}
+	return change || sensitiveChange, nil
+}
+
+// rollbackConfig restores the original values of all config keys stored for the dogu in the given store.
+func (r *DebugModeReconciler) rollbackConfig(ctx context.Context, dogu v2.Dogu, handler ConfigHandler, store stateStore, rb *rollback) (bool, error) {
+	entry, found, err := store.getEntry(stateKey(doguConfigStateKind, dogu.Name))
+	if err != nil {
+		return false, fmt.Errorf("ERROR: invalid stored dogu config for %s: %w", dogu.Name, err)
+	}
+	if !found || len(entry.Values) == 0 {
+		return false, nil
+	}
+	if handler == nil {
+		return false, fmt.Errorf("ERROR: no handler to restore dogu config of %s", dogu.Name)
+	}
+
+	changed, err := handler.RestoreConfigValues(ctx, dogu, entry.Values)
+	if err != nil {
+		return false, fmt.Errorf("ERROR: failed to restore dogu config of %s: %w", dogu.Name, err)
+	}
+	if changed {
+		rb.logger.Info("Restored dogu config", "dogu", dogu.Name)
+	}
+	return changed, nil
+}
+
+// loadSensitiveState loads the sensitive state of the state map. It is nil if sensitive dogu config is not configured.
+func (r *DebugModeReconciler) loadSensitiveState(ctx context.Context, stateMap *StateMap, logger logging.Logger) (*SensitiveState, error) {
+	if r.secretInterface == nil {
+		return nil, nil
+	}
+	return loadSensitiveState(ctx, r.secretInterface, stateMap, logger)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DebugModeReconciler) SetupWithManager(mgr controllerManager) error {
+	return setupWithManager(mgr, r)
+}
+
+func setupWithManager(mgr controllerManager, reconciler reconcile.Reconciler) error {
 	controllerOptions := mgr.GetControllerOptions()
 	options := controller.TypedOptions[reconcile.Request]{
 		SkipNameValidation: controllerOptions.SkipNameValidation,
 		RecoverPanic:       controllerOptions.RecoverPanic,
 	}
 	return ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		// annotations do not change the generation, but the force delete annotation must trigger a reconcile
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})).
 		WithOptions(options).
 		For(&k8sCRLib.DebugMode{}).
-		Complete(r)
+		Complete(reconciler)
+}
+
+// elementLogger returns a logger adding the kind and the name of the element, e.g. "kind", "dogu", "dogu", "nexus".
+func elementLogger(logger logging.Logger, handler loglevel.LogLevelHandler, name string) logging.Logger {
+	return logger.WithValues("kind", handler.Kind(), handler.Kind(), name)
+}
+
+// stateKey returns the key of the element in the state map.
+func stateKey(kind string, name string) string {
+	return fmt.Sprintf("%s.%s", kind, name)
+}
+
+// doguVersion returns the installed version of the dogu or the requested one if it is not installed yet.
+func doguVersion(dogu v2.Dogu) string {
+	if dogu.Status.InstalledVersion != "" {
+		return dogu.Status.InstalledVersion
+	}
+	return dogu.Spec.Version
 }
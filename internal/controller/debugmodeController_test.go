@@ -1,13 +1,19 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
 	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +24,41 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/config"
 )
 
+const (
+	testDebugModeUID = types.UID("debugmode-uid")
+	testStateMapName = "debugmode-state-debugmode-uid"
+	testStateMapUID  = types.UID("statemap-uid")
+)
+
+var testStateMapDeleteOptions = metav1.DeleteOptions{Preconditions: metav1.NewUIDPreconditions(string(testStateMapUID))}
+
+// testStateEntry returns the serialized state entry the reconciler writes for the given level.
+func testStateEntry(t *testing.T, level string) string {
+	t.Helper()
+	fixTimeNow(t)
+	value, err := newStateEntry(level, false, level, "").marshal()
+	require.NoError(t, err)
+	return value
+}
+
+// restoredLevel returns the state a rollback restores for a log level stored with testStateEntry.
+func restoredLevel(level loglevel.LogLevel) loglevel.LogLevelState {
+	return loglevel.LogLevelState{Level: level, RawValue: level.String()}
+}
+
+// explicitLevel returns the state of an explicitly configured log level.
+func explicitLevel(level loglevel.LogLevel) loglevel.LogLevelState {
+	return loglevel.LogLevelState{Level: level, RawValue: level.String()}
+}
+
+// fixTimeNow sets the time used for captured state entries to a fixed value for the duration of the test.
+func fixTimeNow(t *testing.T) {
+	t.Helper()
+	original := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { timeNow = original })
+}
+
 func Test_DebugModeReconciler_New(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		// given
@@ -59,6 +100,7 @@ func Test_DebugModeReconciler_isActive(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 			},
@@ -88,6 +130,7 @@ func Test_DebugModeReconciler_isActive(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 			},
@@ -196,6 +239,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -203,7 +247,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -215,10 +259,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -264,26 +309,25 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelInfo, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelInfo), nil)
 
 		cm = &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
-				"dogu.doguA": "INFO",
+				"dogu.doguA": testStateEntry(t, "INFO"),
 			},
 		}
 
-		configMapClient.EXPECT().Update(ctx, cm, metav1.UpdateOptions{}).Return(cm, nil).Once()
-
 		// - set log level
 		doguLevelHandler.EXPECT().SetLogLevel(ctx, doguList.Items[0], loglevel.LevelDebug).Return(nil)
 
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelWarn, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelWarn), nil)
 		cm2 := cm.DeepCopy()
-		cm2.Data["dogu.doguB"] = "WARN"
+		cm2.Data["dogu.doguB"] = testStateEntry(t, "WARN")
 		configMapClient.EXPECT().Update(ctx, cm2, metav1.UpdateOptions{}).Return(cm2, nil).Once()
 
 		// - set log level
@@ -292,7 +336,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// when
 		reconcile, err := dmc.Reconcile(ctx, request)
 
-		assert.Equal(t, ctrl.Result{RequeueAfter: reconcilerTimeoutInSec * time.Second}, reconcile)
+		assert.Equal(t, ctrl.Result{RequeueAfter: DefaultRequeueInterval}, reconcile)
 		assert.NoError(t, err)
 	})
 	t.Run("success active with already set debug mode", func(t *testing.T) {
@@ -314,6 +358,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -321,7 +366,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -333,10 +378,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -382,23 +428,22 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelDebug, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelDebug), nil)
 
 		cm = &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
-				"dogu.doguA": "DEBUG",
+				"dogu.doguA": testStateEntry(t, "DEBUG"),
 			},
 		}
 
-		configMapClient.EXPECT().Update(ctx, cm, metav1.UpdateOptions{}).Return(cm, nil).Once()
-
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelDebug, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelDebug), nil)
 		cm2 := cm.DeepCopy()
-		cm2.Data["dogu.doguB"] = "DEBUG"
+		cm2.Data["dogu.doguB"] = testStateEntry(t, "DEBUG")
 		configMapClient.EXPECT().Update(ctx, cm2, metav1.UpdateOptions{}).Return(cm2, nil).Once()
 
 		// - all levels should be set to debug - so the mode is done.
@@ -448,6 +493,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -455,7 +501,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -467,10 +513,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -512,6 +559,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -519,7 +567,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -531,10 +579,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -590,6 +639,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "invalid",
@@ -597,7 +647,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -609,10 +659,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -668,6 +719,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -675,7 +727,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -687,10 +739,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -736,23 +789,22 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelDebug, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelDebug), nil)
 
 		cm = &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
-				"dogu.doguA": "DEBUG",
+				"dogu.doguA": testStateEntry(t, "DEBUG"),
 			},
 		}
 
-		configMapClient.EXPECT().Update(ctx, cm, metav1.UpdateOptions{}).Return(cm, nil).Once()
-
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelDebug, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelDebug), nil)
 		cm2 := cm.DeepCopy()
-		cm2.Data["dogu.doguB"] = "DEBUG"
+		cm2.Data["dogu.doguB"] = testStateEntry(t, "DEBUG")
 		configMapClient.EXPECT().Update(ctx, cm2, metav1.UpdateOptions{}).Return(cm2, nil).Once()
 
 		// - all levels should be set to debug - so the mode is done.
@@ -803,6 +855,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -810,7 +863,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -822,10 +875,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -871,23 +925,22 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelDebug, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelDebug), nil)
 
 		cm = &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
-				"dogu.doguA": "DEBUG",
+				"dogu.doguA": testStateEntry(t, "DEBUG"),
 			},
 		}
 
-		configMapClient.EXPECT().Update(ctx, cm, metav1.UpdateOptions{}).Return(cm, nil).Once()
-
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelDebug, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelDebug), nil)
 		cm2 := cm.DeepCopy()
-		cm2.Data["dogu.doguB"] = "DEBUG"
+		cm2.Data["dogu.doguB"] = testStateEntry(t, "DEBUG")
 		configMapClient.EXPECT().Update(ctx, cm2, metav1.UpdateOptions{}).Return(cm2, nil).Once()
 
 		// - all levels should be set to debug - so the mode is done.
@@ -943,6 +996,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -950,7 +1004,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -962,10 +1016,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -1042,6 +1097,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -1049,7 +1105,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1061,10 +1117,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -1110,7 +1167,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelInfo, assert.AnError)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelInfo), assert.AnError)
 
 		crWithState1.Status = k8sCRLib.DebugModeStatus{
 			Phase: "Failed",
@@ -1144,6 +1201,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -1151,7 +1209,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1163,10 +1221,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -1212,14 +1271,17 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelInfo, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelInfo), nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelWarn), nil)
 
 		cm = &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
-				"dogu.doguA": "INFO",
+				"dogu.doguA": testStateEntry(t, "INFO"),
+				"dogu.doguB": testStateEntry(t, "WARN"),
 			},
 		}
 
@@ -1237,6 +1299,56 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		assert.Equal(t, ctrl.Result{RequeueAfter: 0}, reconcile)
 		assert.Error(t, err)
 	})
+	t.Run("should requeue without failing if another writer changed the state map", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		doguClient := newMockDoguInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+
+		dmc := NewDebugModeReconciler(debugModeClient, doguClient, configMapClient, doguLevelHandler)
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
+			Spec: k8sCRLib.DebugModeSpec{
+				DeactivateTimestamp: metav1.NewTime(time.Now().Add(5 * time.Minute)),
+				TargetLogLevel:      "debug",
+			},
+		}
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: "my_debug_mode"}}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testStateMapName, UID: testStateMapUID}}
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil).Once()
+
+		crWithState := cr.DeepCopy()
+		crWithState.Status = k8sCRLib.DebugModeStatus{Phase: "SetDebugMode"}
+		debugModeClient.EXPECT().UpdateStatusDebugModeSet(ctx, cr).Return(crWithState, nil)
+		debugModeClient.EXPECT().AddOrUpdateLogLevelsSet(ctx, crWithState, false, "Activating Debug-Mode in progress", string(k8sCRLib.DebugModeStatusSet)).Return(crWithState, nil)
+
+		dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "doguA", Namespace: "ecosystem"}}
+		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{dogu}}, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+
+		// another replica has captured the original before the log level was raised
+		conflictErr := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, testStateMapName, assert.AnError)
+		configMapClient.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, conflictErr).Once()
+		latest := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: testStateMapName, UID: testStateMapUID, ResourceVersion: "2"},
+			Data:       map[string]string{"dogu.doguA": testStateEntry(t, "INFO")},
+		}
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(latest, nil).Once()
+		configMapClient.EXPECT().Update(ctx, latest, metav1.UpdateOptions{}).Return(latest, nil).Once()
+
+		// when
+		result, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: concurrentChangeRequeueDelay}, result)
+		debugModeClient.AssertNotCalled(t, "UpdateStatusFailed", mock.Anything, mock.Anything)
+	})
 	t.Run("error setting loglevel active", func(t *testing.T) {
 		// given
 		debugModeClient := newMockDebugModeInterface(t)
@@ -1257,6 +1369,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -1264,7 +1377,7 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1276,10 +1389,11 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -1325,14 +1439,17 @@ func Test_DebugModeReconciler_ActivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelInfo, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelInfo), nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelWarn), nil)
 
 		cm = &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
-				"dogu.doguA": "INFO",
+				"dogu.doguA": testStateEntry(t, "INFO"),
+				"dogu.doguB": testStateEntry(t, "WARN"),
 			},
 		}
 
@@ -1378,6 +1495,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -1385,7 +1503,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1397,14 +1515,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -1450,21 +1569,21 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelDebug, nil).Once()
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelDebug), nil).Once()
 
 		// - set log level
-		doguLevelHandler.EXPECT().SetLogLevel(ctx, doguList.Items[0], loglevel.LevelInfo).Return(nil).Once()
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, doguList.Items[0], restoredLevel(loglevel.LevelInfo)).Return(nil).Once()
 
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelDebug, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelDebug), nil)
 
 		// - set log level
-		doguLevelHandler.EXPECT().SetLogLevel(ctx, doguList.Items[1], loglevel.LevelWarn).Return(nil)
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, doguList.Items[1], restoredLevel(loglevel.LevelWarn)).Return(nil)
 
 		// when
 		reconcile, err := dmc.Reconcile(ctx, request)
 
-		assert.Equal(t, ctrl.Result{RequeueAfter: reconcilerTimeoutInSec * time.Second}, reconcile)
+		assert.Equal(t, ctrl.Result{RequeueAfter: DefaultRequeueInterval}, reconcile)
 		assert.NoError(t, err)
 	})
 	t.Run("success deactive on deleted CR", func(t *testing.T) {
@@ -1485,7 +1604,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		)
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1505,14 +1624,16 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().List(ctx, metav1.ListOptions{LabelSelector: "debugmode.k8s.cloudogu.com/owner=my_debug_mode"}).
+			Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*cm}}, nil)
 
 		// - iterate dogu list
 
@@ -1536,21 +1657,21 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelDebug, nil).Once()
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelDebug), nil).Once()
 
 		// - set log level
-		doguLevelHandler.EXPECT().SetLogLevel(ctx, doguList.Items[0], loglevel.LevelInfo).Return(nil).Once()
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, doguList.Items[0], restoredLevel(loglevel.LevelInfo)).Return(nil).Once()
 
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelDebug, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelDebug), nil)
 
 		// - set log level
-		doguLevelHandler.EXPECT().SetLogLevel(ctx, doguList.Items[1], loglevel.LevelWarn).Return(nil)
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, doguList.Items[1], restoredLevel(loglevel.LevelWarn)).Return(nil)
 
 		// when
 		reconcile, err := dmc.Reconcile(ctx, request)
 
-		assert.Equal(t, ctrl.Result{RequeueAfter: reconcilerTimeoutInSec * time.Second}, reconcile)
+		assert.Equal(t, ctrl.Result{RequeueAfter: DefaultRequeueInterval}, reconcile)
 		assert.NoError(t, err)
 	})
 	t.Run("success and complete deactive", func(t *testing.T) {
@@ -1573,6 +1694,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -1580,7 +1702,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1592,14 +1714,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -1645,13 +1768,13 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelInfo, nil).Once()
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelInfo), nil).Once()
 
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelWarn, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelWarn), nil)
 
 		// - delete config map
-		configMapClient.EXPECT().Delete(ctx, "debugmode-state", metav1.DeleteOptions{}).Return(nil)
+		configMapClient.EXPECT().Delete(ctx, testStateMapName, testStateMapDeleteOptions).Return(nil)
 
 		// - all levels should be set to normal - so the mode is done.
 		// - update condition
@@ -1675,6 +1798,11 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 
 		debugModeClient.EXPECT().UpdateStatusCompleted(ctx, crWithState3).Return(crWithState4, nil)
 
+		// - release the finalizer
+		crWithoutFinalizer := crWithState4.DeepCopy()
+		crWithoutFinalizer.Finalizers = nil
+		debugModeClient.EXPECT().RemoveFinalizer(ctx, crWithState4, debugModeFinalizer).Return(crWithoutFinalizer, nil)
+
 		// when
 		reconcile, err := dmc.Reconcile(ctx, request)
 
@@ -1700,6 +1828,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -1707,7 +1836,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1719,14 +1848,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -1767,6 +1897,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -1774,7 +1905,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1786,14 +1917,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -1848,6 +1980,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "invalid",
@@ -1855,7 +1988,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1867,14 +2000,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -1930,6 +2064,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -1937,7 +2072,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -1949,14 +2084,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -2033,6 +2169,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -2040,7 +2177,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -2052,14 +2189,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -2105,7 +2243,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelDebug, assert.AnError).Once()
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelDebug), assert.AnError).Once()
 
 		crWithState1.Status = k8sCRLib.DebugModeStatus{
 			Phase: "Failed",
@@ -2119,7 +2257,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		assert.Equal(t, ctrl.Result{RequeueAfter: 0}, reconcile)
 		assert.Error(t, err)
 	})
-	t.Run("error no stored log level after debug mode", func(t *testing.T) {
+	t.Run("should keep dogu without stored log level", func(t *testing.T) {
 		// given
 		debugModeClient := newMockDebugModeInterface(t)
 		doguClient := newMockDoguInterface(t)
@@ -2139,6 +2277,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -2146,7 +2285,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -2158,7 +2297,8 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguB":           "WARN",
@@ -2166,7 +2306,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 				"component.componentB": "ERROR",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -2211,20 +2351,18 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
-		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelDebug, nil).Once()
+		// - doguA was installed during the debug mode and is kept
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelDebug), nil).Once()
 
-		crWithState1.Status = k8sCRLib.DebugModeStatus{
-			Phase: "Failed",
-		}
-
-		debugModeClient.EXPECT().UpdateStatusFailed(ctx, cr).Return(crWithState1, nil)
+		// - doguB
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelDebug), nil).Once()
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, doguList.Items[1], restoredLevel(loglevel.LevelWarn)).Return(nil).Once()
 
 		// when
 		reconcile, err := dmc.Reconcile(ctx, request)
 
-		assert.Equal(t, ctrl.Result{RequeueAfter: 0}, reconcile)
-		assert.Error(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: DefaultRequeueInterval}, reconcile)
+		assert.NoError(t, err)
 	})
 	t.Run("success deactive", func(t *testing.T) {
 		// given
@@ -2246,6 +2384,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -2253,7 +2392,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -2265,14 +2404,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INVALID",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -2318,7 +2458,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelDebug, nil).Once()
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelDebug), nil).Once()
 
 		crWithState1.Status = k8sCRLib.DebugModeStatus{
 			Phase: "Failed",
@@ -2351,6 +2491,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -2358,7 +2499,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -2370,7 +2511,8 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA":           "INFO",
@@ -2379,7 +2521,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 				"component.componentB": "ERROR",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -2425,10 +2567,10 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelDebug, nil).Once()
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelDebug), nil).Once()
 
 		// - set log level
-		doguLevelHandler.EXPECT().SetLogLevel(ctx, doguList.Items[0], loglevel.LevelInfo).Return(assert.AnError).Once()
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, doguList.Items[0], restoredLevel(loglevel.LevelInfo)).Return(assert.AnError).Once()
 
 		crWithState1.Status = k8sCRLib.DebugModeStatus{
 			Phase: "Failed",
@@ -2461,6 +2603,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -2468,7 +2611,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -2480,7 +2623,8 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA":           "INFO",
@@ -2489,7 +2633,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 				"component.componentB": "ERROR",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -2535,13 +2679,13 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelInfo, nil).Once()
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelInfo), nil).Once()
 
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelWarn, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelWarn), nil)
 
 		// - delete config map
-		configMapClient.EXPECT().Delete(ctx, "debugmode-state", metav1.DeleteOptions{}).Return(assert.AnError)
+		configMapClient.EXPECT().Delete(ctx, testStateMapName, testStateMapDeleteOptions).Return(assert.AnError)
 
 		crWithState1.Status = k8sCRLib.DebugModeStatus{
 			Phase: "Failed",
@@ -2574,6 +2718,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -2581,7 +2726,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -2593,14 +2738,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -2646,13 +2792,13 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelInfo, nil).Once()
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelInfo), nil).Once()
 
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelWarn, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelWarn), nil)
 
 		// - delete config map
-		configMapClient.EXPECT().Delete(ctx, "debugmode-state", metav1.DeleteOptions{}).Return(nil)
+		configMapClient.EXPECT().Delete(ctx, testStateMapName, testStateMapDeleteOptions).Return(nil)
 
 		// - all levels should be set to normal - so the mode is done.
 		// - update condition
@@ -2700,6 +2846,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -2707,7 +2854,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -2719,14 +2866,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -2772,13 +2920,13 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(doguList, nil)
 
 		// - doguA
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[0]).Return(loglevel.LevelInfo, nil).Once()
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[0]).Return(explicitLevel(loglevel.LevelInfo), nil).Once()
 
 		// - doguB
-		doguLevelHandler.EXPECT().GetLogLevel(ctx, doguList.Items[1]).Return(loglevel.LevelWarn, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguList.Items[1]).Return(explicitLevel(loglevel.LevelWarn), nil)
 
 		// - delete config map
-		configMapClient.EXPECT().Delete(ctx, "debugmode-state", metav1.DeleteOptions{}).Return(nil)
+		configMapClient.EXPECT().Delete(ctx, testStateMapName, testStateMapDeleteOptions).Return(nil)
 
 		// - all levels should be set to normal - so the mode is done.
 		// - update condition
@@ -2832,6 +2980,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "debug",
@@ -2839,7 +2988,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -2872,6 +3021,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		deactivationTime := time.Now().Add(-5 * time.Minute)
 
 		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
 			Spec: k8sCRLib.DebugModeSpec{
 				DeactivateTimestamp: metav1.NewTime(deactivationTime),
 				TargetLogLevel:      "invalid",
@@ -2879,7 +3029,7 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		}
 
 		request := ctrl.Request{
-			types.NamespacedName{
+			NamespacedName: types.NamespacedName{
 				Namespace: "ecosystem",
 				Name:      "my_debug_mode",
 			},
@@ -2891,14 +3041,15 @@ func Test_DebugModeReconciler_DeactivateDebugMode(t *testing.T) {
 		// - create new statemap
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "debugmode-state",
+				Name: testStateMapName,
+				UID:  testStateMapUID,
 			},
 			Data: map[string]string{
 				"dogu.doguA": "INFO",
 				"dogu.doguB": "WARN",
 			},
 		}
-		configMapClient.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
 
 		// - set status  SetDebugMode
 		crWithState1 := cr.DeepCopy()
@@ -2968,6 +3119,236 @@ func Test_DebugModeReconciler_Setup(t *testing.T) {
 	})
 }
 
+func Test_DebugModeReconciler_Finalizer(t *testing.T) {
+	ctx := t.Context()
+	request := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: "ecosystem",
+			Name:      "my_debug_mode",
+		},
+	}
+	deletionTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	notFoundErr := apierrors.NewNotFound(schema.GroupResource{}, "CM")
+	doguA := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "doguA", Namespace: "ecosystem"}}
+
+	t.Run("should add finalizer before any log level is changed", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), configMapClient, NewMockLogLevelHandler(t))
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID},
+			Spec: k8sCRLib.DebugModeSpec{
+				DeactivateTimestamp: metav1.NewTime(time.Now().Add(5 * time.Minute)),
+				TargetLogLevel:      "debug",
+			},
+		}
+		crWithFinalizer := cr.DeepCopy()
+		crWithFinalizer.Finalizers = []string{debugModeFinalizer}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		debugModeClient.EXPECT().AddFinalizer(ctx, cr, debugModeFinalizer).Return(crWithFinalizer, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		debugModeClient.EXPECT().UpdateStatusDebugModeSet(ctx, crWithFinalizer).Return(nil, assert.AnError)
+		debugModeClient.EXPECT().UpdateStatusFailed(ctx, crWithFinalizer).Return(crWithFinalizer, nil)
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("error adding finalizer", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID}}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		debugModeClient.EXPECT().AddFinalizer(ctx, cr, debugModeFinalizer).Return(nil, assert.AnError)
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to add finalizer")
+	})
+	t.Run("should restore log levels of deleted debug mode with visible status", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		doguClient := newMockDoguInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		dmc := NewDebugModeReconciler(debugModeClient, doguClient, configMapClient, doguLevelHandler)
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}, DeletionTimestamp: &deletionTime},
+			Spec: k8sCRLib.DebugModeSpec{
+				DeactivateTimestamp: metav1.NewTime(time.Now().Add(5 * time.Minute)),
+				TargetLogLevel:      "debug",
+			},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: testStateMapName, UID: testStateMapUID},
+			Data:       map[string]string{"dogu.doguA": testStateEntry(t, "INFO")},
+		}
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
+		debugModeClient.EXPECT().UpdateStatusRollback(ctx, cr).Return(cr, nil)
+		debugModeClient.EXPECT().AddOrUpdateLogLevelsSet(ctx, cr, false, "Debug-Mode deleted - restoring log levels in progress", string(k8sCRLib.DebugModeStatusRollback)).Return(cr, nil)
+		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{doguA}}, nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguA).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, doguA, restoredLevel(loglevel.LevelInfo)).Return(nil)
+
+		// when
+		result, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: DefaultRequeueInterval}, result)
+	})
+	t.Run("should release deleted debug mode without stored state", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), configMapClient, NewMockLogLevelHandler(t))
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}, DeletionTimestamp: &deletionTime},
+			Spec:       k8sCRLib.DebugModeSpec{TargetLogLevel: "debug"},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(nil, notFoundErr)
+		configMapClient.EXPECT().Get(ctx, DEFAULT_CM_NAME, metav1.GetOptions{}).Return(nil, notFoundErr)
+		debugModeClient.EXPECT().UpdateStatusRollback(ctx, cr).Return(cr, nil)
+		debugModeClient.EXPECT().AddOrUpdateLogLevelsSet(ctx, cr, false, "Debug-Mode deleted - restoring log levels in progress", string(k8sCRLib.DebugModeStatusRollback)).Return(cr, nil)
+		debugModeClient.EXPECT().AddOrUpdateLogLevelsSet(ctx, cr, false, "Debug-Mode deactivated", string(k8sCRLib.DebugModeStatusCompleted)).Return(cr, nil)
+		debugModeClient.EXPECT().UpdateStatusCompleted(ctx, cr).Return(cr, nil)
+		debugModeClient.EXPECT().RemoveFinalizer(ctx, cr, debugModeFinalizer).Return(cr, nil)
+
+		// when
+		result, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+	t.Run("should keep finalizer if rollback fails", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		doguClient := newMockDoguInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		dmc := NewDebugModeReconciler(debugModeClient, doguClient, configMapClient, NewMockLogLevelHandler(t))
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}, DeletionTimestamp: &deletionTime},
+			Spec:       k8sCRLib.DebugModeSpec{TargetLogLevel: "debug"},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: testStateMapName, UID: testStateMapUID},
+			Data:       map[string]string{"dogu.doguA": testStateEntry(t, "INFO")},
+		}
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
+		debugModeClient.EXPECT().UpdateStatusRollback(ctx, cr).Return(cr, nil)
+		debugModeClient.EXPECT().AddOrUpdateLogLevelsSet(ctx, cr, false, "Debug-Mode deleted - restoring log levels in progress", string(k8sCRLib.DebugModeStatusRollback)).Return(cr, nil)
+		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(nil, assert.AnError)
+		debugModeClient.EXPECT().UpdateStatusFailed(ctx, cr).Return(cr, nil)
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should release force deleted debug mode without rollback", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), configMapClient, NewMockLogLevelHandler(t))
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              request.Name,
+				UID:               testDebugModeUID,
+				Finalizers:        []string{debugModeFinalizer},
+				DeletionTimestamp: &deletionTime,
+				Annotations:       map[string]string{forceDeleteAnnotation: "true"},
+			},
+			Spec: k8sCRLib.DebugModeSpec{TargetLogLevel: "debug"},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: testStateMapName, UID: testStateMapUID},
+			Data:       map[string]string{"dogu.doguA": testStateEntry(t, "INFO")},
+		}
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Delete(ctx, testStateMapName, testStateMapDeleteOptions).Return(nil)
+		debugModeClient.EXPECT().RemoveFinalizer(ctx, cr, debugModeFinalizer).Return(cr, nil)
+
+		// when
+		result, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+	t.Run("error releasing force deleted debug mode", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), configMapClient, NewMockLogLevelHandler(t))
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              request.Name,
+				UID:               testDebugModeUID,
+				Finalizers:        []string{debugModeFinalizer},
+				DeletionTimestamp: &deletionTime,
+				Annotations:       map[string]string{forceDeleteAnnotation: "true"},
+			},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(nil, notFoundErr)
+		configMapClient.EXPECT().Get(ctx, DEFAULT_CM_NAME, metav1.GetOptions{}).Return(nil, notFoundErr)
+		debugModeClient.EXPECT().RemoveFinalizer(ctx, cr, debugModeFinalizer).Return(nil, assert.AnError)
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to remove finalizer")
+	})
+	t.Run("should release completed debug mode", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}, DeletionTimestamp: &deletionTime},
+			Status: k8sCRLib.DebugModeStatus{Conditions: []metav1.Condition{
+				{Reason: string(k8sCRLib.DebugModeStatusCompleted)},
+			}},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		debugModeClient.EXPECT().RemoveFinalizer(ctx, cr, debugModeFinalizer).Return(cr, nil)
+
+		// when
+		result, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+}
+
 func Test_DebugModeReconciler_isCompleted(t *testing.T) {
 	t.Run("success completed", func(t *testing.T) {
 		// given
@@ -3069,3 +3450,803 @@ func Test_DebugModeReconciler_isCompleted(t *testing.T) {
 
 	})
 }
+
+func Test_DebugModeReconciler_deactivateDebugModeForElement(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas"}}
+
+	t.Run("should restore level from structured state entry", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, dogu, restoredLevel(loglevel.LevelWarn)).Return(nil)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"dogu.cas": testStateEntry(t, "WARN")}}}
+		dmc := &DebugModeReconciler{}
+
+		// when
+		changed, err := dmc.deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "", &rollback{stateMap: stateMap, logger: logging.FromContext(ctx)})
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+	t.Run("should remove log level that was unset before the debug mode", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguLevelHandler.EXPECT().ResetLogLevel(ctx, dogu).Return(nil)
+		unsetEntry, err := newStateEntry("WARN", true, "", "").marshal()
+		require.NoError(t, err)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"dogu.cas": unsetEntry}}}
+		dmc := &DebugModeReconciler{}
+
+		// when
+		changed, err := dmc.deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "", &rollback{stateMap: stateMap, logger: logging.FromContext(ctx)})
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+	t.Run("should not change log level that is unset again", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(loglevel.LogLevelState{Level: loglevel.LevelWarn, Unset: true}, nil)
+		unsetEntry, err := newStateEntry("WARN", true, "", "").marshal()
+		require.NoError(t, err)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"dogu.cas": unsetEntry}}}
+		dmc := &DebugModeReconciler{}
+
+		// when
+		changed, err := dmc.deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "", &rollback{stateMap: stateMap, logger: logging.FromContext(ctx)})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("error removing log level", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguLevelHandler.EXPECT().ResetLogLevel(ctx, dogu).Return(assert.AnError)
+		unsetEntry, err := newStateEntry("WARN", true, "", "").marshal()
+		require.NoError(t, err)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"dogu.cas": unsetEntry}}}
+		dmc := &DebugModeReconciler{}
+
+		// when
+		changed, err := dmc.deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "", &rollback{stateMap: stateMap, logger: logging.FromContext(ctx)})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, changed)
+	})
+	t.Run("should restore unparseable original level verbatim", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, dogu, loglevel.LogLevelState{Level: loglevel.LevelUnknown, RawValue: "verbose"}).Return(nil)
+		unknownEntry, err := newStateEntry(loglevel.LevelUnknown.String(), false, "verbose", "1.0.0-1").marshal()
+		require.NoError(t, err)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"dogu.cas": unknownEntry}}}
+		dmc := &DebugModeReconciler{}
+
+		// when
+		changed, err := dmc.deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "2.0.0-1", &rollback{stateMap: stateMap, logger: logging.FromContext(ctx)})
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+	t.Run("should not change unparseable original level that is set again", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(loglevel.LogLevelState{Level: loglevel.LevelUnknown, RawValue: "verbose"}, nil)
+		unknownEntry, err := newStateEntry(loglevel.LevelUnknown.String(), false, "verbose", "").marshal()
+		require.NoError(t, err)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"dogu.cas": unknownEntry}}}
+		dmc := &DebugModeReconciler{}
+
+		// when
+		changed, err := dmc.deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "", &rollback{stateMap: stateMap, logger: logging.FromContext(ctx)})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("should refuse to restore from corrupt state entry", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{
+			"dogu.cas": `{"version":1,"level":"ERROR","checksum":"manipulated"}`,
+		}}}
+		dmc := &DebugModeReconciler{}
+
+		// when
+		changed, err := dmc.deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "", &rollback{stateMap: stateMap, logger: logging.FromContext(ctx)})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errStateEntryChecksum)
+		assert.False(t, changed)
+	})
+}
+
+func Test_DebugModeReconciler_deactivateDebugModeForElement_changedDogus(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas"}}
+	newRollback := func(data map[string]string, policy AddedDoguPolicy) *rollback {
+		return &rollback{
+			stateMap:        &StateMap{configMap: &corev1.ConfigMap{Data: data}},
+			addedDoguPolicy: policy,
+			targetLogLevel:  loglevel.LevelDebug,
+			logger:          logging.FromContext(ctx),
+		}
+	}
+	versionedEntry := func(t *testing.T, level string, version string) string {
+		fixTimeNow(t)
+		value, err := newStateEntry(level, false, level, version).marshal()
+		require.NoError(t, err)
+		return value
+	}
+
+	t.Run("should keep more verbose dogu untouched by raise-only debug mode", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelTrace), nil)
+		rb := newRollback(map[string]string{}, AddedDoguPolicyRestoreDefault)
+		rb.raiseOnly = true
+
+		// when
+		changed, err := (&DebugModeReconciler{}).deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "7.0.5-1", rb)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, rb.added)
+	})
+	t.Run("should keep dogu not selected by the debug mode", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelError), nil)
+		rb := newRollback(map[string]string{}, AddedDoguPolicyRestoreDefault)
+		rb.dogus = []string{"ldap"}
+
+		// when
+		changed, err := (&DebugModeReconciler{}).deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "7.0.5-1", rb)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, rb.added)
+	})
+	t.Run("should keep added dogu", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		rb := newRollback(map[string]string{}, AddedDoguPolicyKeep)
+
+		// when
+		changed, err := (&DebugModeReconciler{}).deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "7.0.5-1", rb)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, []string{"cas"}, rb.added)
+	})
+	t.Run("should restore default of added dogu", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelError), nil)
+		doguLevelHandler.EXPECT().ResetLogLevel(ctx, dogu).Return(nil)
+		rb := newRollback(map[string]string{}, AddedDoguPolicyRestoreDefault)
+
+		// when
+		changed, err := (&DebugModeReconciler{}).deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "7.0.5-1", rb)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []string{"cas"}, rb.added)
+	})
+	t.Run("should restore default of added dogu with target level", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguLevelHandler.EXPECT().ResetLogLevel(ctx, dogu).Return(nil)
+		rb := newRollback(map[string]string{}, AddedDoguPolicyTargeted)
+
+		// when
+		changed, err := (&DebugModeReconciler{}).deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "7.0.5-1", rb)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+	t.Run("should keep added dogu with other than target level", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelError), nil)
+		rb := newRollback(map[string]string{}, AddedDoguPolicyTargeted)
+
+		// when
+		changed, err := (&DebugModeReconciler{}).deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "7.0.5-1", rb)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("should restore stored level supported by upgraded dogu", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguLevelHandler.EXPECT().IsLogLevelSupported(ctx, dogu, loglevel.LevelWarn).Return(true, nil)
+		doguLevelHandler.EXPECT().RestoreLogLevel(ctx, dogu, restoredLevel(loglevel.LevelWarn)).Return(nil)
+		rb := newRollback(map[string]string{"dogu.cas": versionedEntry(t, "WARN", "7.0.5-1")}, AddedDoguPolicyKeep)
+
+		// when
+		changed, err := (&DebugModeReconciler{}).deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "7.1.0-1", rb)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Empty(t, rb.fallback)
+	})
+	t.Run("should reset stored level not supported by upgraded dogu", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguLevelHandler.EXPECT().IsLogLevelSupported(ctx, dogu, loglevel.LevelWarn).Return(false, nil)
+		doguLevelHandler.EXPECT().ResetLogLevel(ctx, dogu).Return(nil)
+		rb := newRollback(map[string]string{"dogu.cas": versionedEntry(t, "WARN", "7.0.5-1")}, AddedDoguPolicyKeep)
+
+		// when
+		changed, err := (&DebugModeReconciler{}).deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "7.1.0-1", rb)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []string{"cas"}, rb.fallback)
+	})
+	t.Run("error checking stored level of upgraded dogu", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguLevelHandler.EXPECT().IsLogLevelSupported(ctx, dogu, loglevel.LevelWarn).Return(false, assert.AnError)
+		rb := newRollback(map[string]string{"dogu.cas": versionedEntry(t, "WARN", "7.0.5-1")}, AddedDoguPolicyKeep)
+
+		// when
+		_, err := (&DebugModeReconciler{}).deactivateDebugModeForElement(ctx, doguLevelHandler, "cas", dogu, "7.1.0-1", rb)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_DebugModeReconciler_rollbackUninstalledDogus(t *testing.T) {
+	// given
+	ctx := t.Context()
+	debugModeClient := newMockDebugModeInterface(t)
+	doguClient := newMockDoguInterface(t)
+	configMapClient := newMockConfigurationMap(t)
+	doguLevelHandler := NewMockLogLevelHandler(t)
+	doguLevelHandler.EXPECT().Kind().Return("dogu")
+	dmc := NewDebugModeReconciler(debugModeClient, doguClient, configMapClient, doguLevelHandler)
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: "my_debug_mode"}}
+	cr := &k8sCRLib.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
+		Spec: k8sCRLib.DebugModeSpec{
+			DeactivateTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+			TargetLogLevel:      "debug",
+		},
+	}
+	debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: testStateMapName, UID: testStateMapUID},
+		Data: map[string]string{
+			"dogu.doguA":    testStateEntry(t, "INFO"),
+			"dogu.doguGone": testStateEntry(t, "WARN"),
+		},
+	}
+	configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
+	debugModeClient.EXPECT().UpdateStatusRollback(ctx, cr).Return(cr, nil)
+	debugModeClient.EXPECT().AddOrUpdateLogLevelsSet(ctx, cr, false, "Deactivating Debug-Mode in progress", string(k8sCRLib.DebugModeStatusRollback)).Return(cr, nil)
+	doguA := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "doguA"}}
+	doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{doguA}}, nil)
+	doguLevelHandler.EXPECT().GetLogLevelState(ctx, doguA).Return(explicitLevel(loglevel.LevelInfo), nil)
+	configMapClient.EXPECT().Delete(ctx, testStateMapName, testStateMapDeleteOptions).Return(nil)
+	debugModeClient.EXPECT().AddOrUpdateLogLevelsSet(ctx, cr, false, "Debug-Mode deactivated - skipped uninstalled dogus: doguGone", string(k8sCRLib.DebugModeStatusCompleted)).Return(cr, nil)
+	debugModeClient.EXPECT().UpdateStatusCompleted(ctx, cr).Return(cr, nil)
+	debugModeClient.EXPECT().RemoveFinalizer(ctx, cr, debugModeFinalizer).Return(cr, nil)
+
+	// when
+	result, err := dmc.Reconcile(ctx, request)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+}
+
+func Test_DebugModeReconciler_captureStateForElement(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas"}}
+
+	t.Run("should capture unset log level", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(loglevel.LogLevelState{Level: loglevel.LevelWarn, Unset: true}, nil)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{}}
+		pending := map[string]StateEntry{}
+		dmc := &DebugModeReconciler{}
+
+		// when
+		level, err := dmc.captureStateForElement(ctx, doguLevelHandler, "cas", dogu, "7.0.5-1", stateMap, pending, logging.FromContext(ctx))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, loglevel.LevelWarn, level)
+		assert.Equal(t, map[string]StateEntry{"dogu.cas": newStateEntry("WARN", true, "", "7.0.5-1")}, pending)
+	})
+	t.Run("should not capture already stored element", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, dogu).Return(explicitLevel(loglevel.LevelDebug), nil)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"dogu.cas": testStateEntry(t, "WARN")}}}
+		pending := map[string]StateEntry{}
+		dmc := &DebugModeReconciler{}
+
+		// when
+		level, err := dmc.captureStateForElement(ctx, doguLevelHandler, "cas", dogu, "7.0.5-1", stateMap, pending, logging.FromContext(ctx))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, loglevel.LevelDebug, level)
+		assert.Empty(t, pending)
+	})
+}
+
+func Test_DebugModeReconciler_activateDogus(t *testing.T) {
+	ctx := t.Context()
+	cas := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas"}}
+	ldap := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "ldap"}}
+	newActivation := func(t *testing.T) *activation {
+		return &activation{
+			stateMap: &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{
+				"dogu.cas":  testStateEntry(t, "INFO"),
+				"dogu.ldap": testStateEntry(t, "INFO"),
+			}}},
+			targetLogLevel: loglevel.LevelDebug,
+			logger:         logging.FromContext(ctx),
+		}
+	}
+
+	t.Run("should record dogu not supporting the target log level", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, cas).Return(explicitLevel(loglevel.LevelInfo), nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, ldap).Return(explicitLevel(loglevel.LevelInfo), nil)
+		doguLevelHandler.EXPECT().SetLogLevel(ctx, cas, loglevel.LevelDebug).Return(nil)
+		doguLevelHandler.EXPECT().SetLogLevel(ctx, ldap, loglevel.LevelDebug).Return(loglevel.ErrUnsupportedLogLevel)
+		dmc := &DebugModeReconciler{doguLogLevelHandler: doguLevelHandler}
+		act := newActivation(t)
+
+		// when
+		changed, err := dmc.activateDogus(ctx, []v2.Dogu{cas, ldap}, act)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []string{"ldap"}, act.incompatible)
+		assert.Equal(t, "target log level DEBUG not supported by dogus: ldap", act.summary())
+	})
+	t.Run("should neither change nor record more verbose dogu with raise-only", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, cas).Return(explicitLevel(loglevel.LevelWarn), nil)
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, ldap).Return(explicitLevel(loglevel.LevelTrace), nil)
+		doguLevelHandler.EXPECT().SetLogLevel(ctx, cas, loglevel.LevelInfo).Return(nil)
+		dmc := &DebugModeReconciler{doguLogLevelHandler: doguLevelHandler}
+		act := &activation{
+			// no config map client is set, so storing an entry for ldap would fail
+			stateMap:       &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"dogu.cas": testStateEntry(t, "WARN")}}},
+			targetLogLevel: loglevel.LevelInfo,
+			logger:         logging.FromContext(ctx),
+			raiseOnly:      true,
+		}
+
+		// when
+		changed, err := dmc.activateDogus(ctx, []v2.Dogu{cas, ldap}, act)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []string{"ldap"}, act.untouched)
+		assert.NotContains(t, act.stateMap.configMap.Data, "dogu.ldap")
+	})
+	t.Run("should fail on other errors setting the log level", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, cas).Return(explicitLevel(loglevel.LevelInfo), nil)
+		doguLevelHandler.EXPECT().SetLogLevel(ctx, cas, loglevel.LevelDebug).Return(assert.AnError)
+		dmc := &DebugModeReconciler{doguLogLevelHandler: doguLevelHandler}
+		act := newActivation(t)
+
+		// when
+		_, err := dmc.activateDogus(ctx, []v2.Dogu{cas}, act)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, act.incompatible)
+	})
+	t.Run("should only change selected dogus", func(t *testing.T) {
+		// given
+		doguLevelHandler := NewMockLogLevelHandler(t)
+		doguLevelHandler.EXPECT().Kind().Return("dogu")
+		doguLevelHandler.EXPECT().GetLogLevelState(ctx, cas).Return(explicitLevel(loglevel.LevelInfo), nil)
+		doguLevelHandler.EXPECT().SetLogLevel(ctx, cas, loglevel.LevelDebug).Return(nil)
+		dmc := &DebugModeReconciler{doguLogLevelHandler: doguLevelHandler}
+		act := newActivation(t)
+		act.dogus = []string{"cas"}
+
+		// when
+		changed, err := dmc.activateDogus(ctx, []v2.Dogu{cas, ldap}, act)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Empty(t, act.untouched)
+	})
+}
+
+func Test_DebugModeReconciler_iterateDogusForDebugMode_selection(t *testing.T) {
+	t.Run("should record selected dogus not installed", func(t *testing.T) {
+		// given
+		ctx := t.Context()
+		doguClient := newMockDoguInterface(t)
+		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(&v2.DoguList{}, nil)
+		dmc := &DebugModeReconciler{doguInterface: doguClient}
+		act := &activation{dogus: []string{"cas", "ldap"}, targetLogLevel: loglevel.LevelDebug, logger: logging.FromContext(ctx)}
+
+		// when
+		changed, err := dmc.iterateDogusForDebugMode(ctx, act)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, "selected dogus not installed: cas, ldap", act.summary())
+	})
+}
+
+func Test_DebugModeReconciler_activateDoguConfig(t *testing.T) {
+	ctx := t.Context()
+	nexus := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "nexus"}, Spec: v2.DoguSpec{Version: "3.70.1-1"}}
+	overrides := DoguConfigOverrides{
+		"nexus":   {"logging/org.sonatype": "DEBUG", "logging/sql": "true"},
+		"redmine": {"logging/sql": "true"},
+	}
+	storedEntry := func(t *testing.T, values map[string]loglevel.ConfigValueState) string {
+		fixTimeNow(t)
+		value, err := newConfigStateEntry(values, "3.70.1-1").marshal()
+		require.NoError(t, err)
+		return value
+	}
+
+	t.Run("should store original values before writing overrides", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		configHandler := NewMockConfigHandler(t)
+		configMapClient := newMockConfigurationMap(t)
+		originals := map[string]loglevel.ConfigValueState{"logging/org.sonatype": {Value: "WARN"}, "logging/sql": {Unset: true}}
+		configHandler.EXPECT().GetConfigValues(ctx, nexus, []string{"logging/org.sonatype", "logging/sql"}).Return(originals, nil)
+		var stored *corev1.ConfigMap
+		configMapClient.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(
+			func(_ context.Context, cm *corev1.ConfigMap, _ metav1.UpdateOptions) (*corev1.ConfigMap, error) {
+				stored = cm
+				return cm, nil
+			})
+		configHandler.EXPECT().SetConfigValues(ctx, nexus, overrides["nexus"]).Return(true, nil)
+		dmc := &DebugModeReconciler{doguConfigHandler: configHandler}
+		act := &activation{
+			stateMap:   &StateMap{configMap: &corev1.ConfigMap{}, configMapInterface: configMapClient, logger: logging.FromContext(ctx)},
+			logger:     logging.FromContext(ctx),
+			doguConfig: overrides,
+		}
+
+		// when
+		changed, err := dmc.activateDoguConfig(ctx, []v2.Dogu{nexus}, act)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []string{"redmine"}, act.notInstalled)
+		require.NotNil(t, stored)
+		expected, err := newConfigStateEntry(originals, "3.70.1-1").marshal()
+		require.NoError(t, err)
+		assert.Equal(t, expected, stored.Data["doguconfig.nexus"])
+	})
+	t.Run("should keep stored original values", func(t *testing.T) {
+		// given
+		configHandler := NewMockConfigHandler(t)
+		configHandler.EXPECT().SetConfigValues(ctx, nexus, overrides["nexus"]).Return(false, nil)
+		dmc := &DebugModeReconciler{doguConfigHandler: configHandler}
+		act := &activation{
+			stateMap: &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{
+				"doguconfig.nexus": storedEntry(t, map[string]loglevel.ConfigValueState{"logging/org.sonatype": {Value: "WARN"}, "logging/sql": {Unset: true}}),
+			}}},
+			logger:     logging.FromContext(ctx),
+			doguConfig: DoguConfigOverrides{"nexus": overrides["nexus"]},
+		}
+
+		// when
+		changed, err := dmc.activateDoguConfig(ctx, []v2.Dogu{nexus}, act)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("should do nothing without overrides", func(t *testing.T) {
+		// when
+		changed, err := (&DebugModeReconciler{doguConfigHandler: NewMockConfigHandler(t)}).activateDoguConfig(ctx, []v2.Dogu{nexus}, &activation{})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("should not write overrides if original values cannot be read", func(t *testing.T) {
+		// given
+		configHandler := NewMockConfigHandler(t)
+		configHandler.EXPECT().GetConfigValues(ctx, nexus, mock.Anything).Return(nil, assert.AnError)
+		dmc := &DebugModeReconciler{doguConfigHandler: configHandler}
+		act := &activation{
+			stateMap:   &StateMap{configMap: &corev1.ConfigMap{}},
+			logger:     logging.FromContext(ctx),
+			doguConfig: overrides,
+		}
+
+		// when
+		_, err := dmc.activateDoguConfig(ctx, []v2.Dogu{nexus}, act)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to get dogu config of nexus")
+	})
+
+	t.Run("should store original sensitive values in the sensitive state", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		sensitiveHandler := NewMockConfigHandler(t)
+		secrets := newMockSecretInterface(t)
+		originals := map[string]loglevel.ConfigValueState{"smtp/password": {Value: "original"}}
+		sensitiveHandler.EXPECT().GetConfigValues(ctx, nexus, []string{"smtp/password"}).Return(originals, nil)
+		secrets.EXPECT().Create(ctx, mock.MatchedBy(func(secret *corev1.Secret) bool {
+			return len(secret.Data["doguconfig.nexus"]) > 0
+		}), metav1.CreateOptions{}).Return(&corev1.Secret{}, nil)
+		sensitiveHandler.EXPECT().SetConfigValues(ctx, nexus, map[string]string{"smtp/password": "debug"}).Return(true, nil)
+		dmc := &DebugModeReconciler{sensitiveDoguConfigHandler: sensitiveHandler, secretInterface: secrets}
+		act := &activation{
+			// the state map has no client, so storing sensitive values in it would fail
+			stateMap:            &StateMap{configMap: &corev1.ConfigMap{}},
+			sensitiveState:      &SensitiveState{debugCR: &k8sCRLib.DebugMode{}, secretInterface: secrets, name: testStateMapName},
+			logger:              logging.FromContext(ctx),
+			sensitiveDoguConfig: DoguConfigOverrides{"nexus": {"smtp/password": "debug"}},
+		}
+
+		// when
+		changed, err := dmc.activateDoguConfig(ctx, []v2.Dogu{nexus}, act)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Empty(t, act.stateMap.configMap.Data)
+	})
+	t.Run("should restore sensitive values on rollback", func(t *testing.T) {
+		// given
+		originals := map[string]loglevel.ConfigValueState{"smtp/password": {Value: "original"}}
+		sensitiveHandler := NewMockConfigHandler(t)
+		sensitiveHandler.EXPECT().RestoreConfigValues(ctx, nexus, originals).Return(true, nil)
+		dmc := &DebugModeReconciler{sensitiveDoguConfigHandler: sensitiveHandler}
+		rb := &rollback{
+			stateMap: &StateMap{configMap: &corev1.ConfigMap{}},
+			sensitiveState: &SensitiveState{secret: &corev1.Secret{Data: map[string][]byte{
+				"doguconfig.nexus": []byte(storedEntry(t, originals)),
+			}}},
+			logger: logging.FromContext(ctx),
+		}
+
+		// when
+		changed, err := dmc.rollbackDoguConfig(ctx, nexus, rb)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+	t.Run("should restore stored values on rollback", func(t *testing.T) {
+		// given
+		originals := map[string]loglevel.ConfigValueState{"logging/org.sonatype": {Value: "WARN"}, "logging/sql": {Unset: true}}
+		configHandler := NewMockConfigHandler(t)
+		configHandler.EXPECT().RestoreConfigValues(ctx, nexus, originals).Return(true, nil)
+		dmc := &DebugModeReconciler{doguConfigHandler: configHandler}
+		rb := &rollback{
+			stateMap: &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"doguconfig.nexus": storedEntry(t, originals)}}},
+			logger:   logging.FromContext(ctx),
+		}
+
+		// when
+		changed, err := dmc.rollbackDoguConfig(ctx, nexus, rb)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+	t.Run("should skip rollback without stored values", func(t *testing.T) {
+		// given
+		dmc := &DebugModeReconciler{}
+		rb := &rollback{stateMap: &StateMap{configMap: &corev1.ConfigMap{}}, logger: logging.FromContext(ctx)}
+
+		// when
+		changed, err := dmc.rollbackDoguConfig(ctx, nexus, rb)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("should fail rollback on restore error", func(t *testing.T) {
+		// given
+		originals := map[string]loglevel.ConfigValueState{"logging/sql": {Unset: true}}
+		configHandler := NewMockConfigHandler(t)
+		configHandler.EXPECT().RestoreConfigValues(ctx, nexus, originals).Return(false, assert.AnError)
+		dmc := &DebugModeReconciler{doguConfigHandler: configHandler}
+		rb := &rollback{
+			stateMap: &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"doguconfig.nexus": storedEntry(t, originals)}}},
+			logger:   logging.FromContext(ctx),
+		}
+
+		// when
+		_, err := dmc.rollbackDoguConfig(ctx, nexus, rb)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to restore dogu config of nexus")
+	})
+}
+
+func Test_DebugModeReconciler_Reconcile_eventRecorder(t *testing.T) {
+	ctx := t.Context()
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: "debug-mode"}}
+
+	t.Run("should report error of failed reconcile as event", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		recorder := newMockEventRecorder(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetEventRecorder(recorder)
+
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID}}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		debugModeClient.EXPECT().AddFinalizer(ctx, cr, debugModeFinalizer).Return(nil, assert.AnError)
+		recorder.EXPECT().Eventf(cr, nil, corev1.EventTypeWarning, "ReconcileFailed", "Reconcile", "%s",
+			mock.MatchedBy(func(message string) bool {
+				return strings.Contains(message, assert.AnError.Error())
+			})).Return()
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+	})
+	t.Run("should not report error without debug mode", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetEventRecorder(newMockEventRecorder(t))
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+	})
+	t.Run("should not report successful reconcile", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetEventRecorder(newMockEventRecorder(t))
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID},
+			Status:     k8sCRLib.DebugModeStatus{Conditions: []metav1.Condition{{Reason: string(k8sCRLib.DebugModeStatusCompleted)}}},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func Test_DebugModeReconciler_Reconcile_watchdog(t *testing.T) {
+	ctx := t.Context()
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: "debug-mode"}}
+
+	t.Run("should inform watchdog about failed reconcile", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		watchdog := newMockReconcileWatchdog(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetWatchdog(watchdog)
+
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID}}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		debugModeClient.EXPECT().AddFinalizer(ctx, cr, debugModeFinalizer).Return(nil, assert.AnError)
+		watchdog.EXPECT().Started(request.NamespacedName).Return().Once()
+		watchdog.EXPECT().Finished(request.NamespacedName, ctrl.Result{}, mock.MatchedBy(func(err error) bool {
+			return errors.Is(err, assert.AnError)
+		})).Return().Once()
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+	})
+	t.Run("should inform watchdog about successful reconcile", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		watchdog := newMockReconcileWatchdog(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		dmc.SetWatchdog(watchdog)
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID},
+			Status:     k8sCRLib.DebugModeStatus{Conditions: []metav1.Condition{{Reason: string(k8sCRLib.DebugModeStatusCompleted)}}},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		watchdog.EXPECT().Started(request.NamespacedName).Return().Once()
+		watchdog.EXPECT().Finished(request.NamespacedName, ctrl.Result{}, nil).Return().Once()
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+	})
+}
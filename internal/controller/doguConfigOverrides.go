@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// doguConfigStateKind is the kind of state entries holding the original values of dogu config keys.
+	doguConfigStateKind = "doguconfig"
+	// doguLogLevelKey is managed by the log level handler and cannot be overridden.
+	doguLogLevelKey = "logging/root"
+
+	// doguConfigAnnotation declares dogu config keys set during a single DebugMode as JSON,
+	// e.g. {"nexus": {"logging/org.sonatype": "DEBUG"}}.
+	doguConfigAnnotation = "debugmode.k8s.cloudogu.com/dogu-config"
+	// sensitiveDoguConfigAnnotation names a Secret with sensitive dogu config keys set during a single DebugMode.
+	// The Secret holds the overrides in the format of doguConfigAnnotation in the key sensitiveDoguConfigSecretKey,
+	// so sensitive values never appear in the DebugMode itself.
+	sensitiveDoguConfigAnnotation = "debugmode.k8s.cloudogu.com/sensitive-dogu-config-secret"
+	sensitiveDoguConfigSecretKey  = "dogu-config"
+	// sensitiveDoguConfigLabel must be "true" on a Secret named in sensitiveDoguConfigAnnotation, so a DebugMode cannot
+	// make the operator read arbitrary Secrets of the namespace.
+	sensitiveDoguConfigLabel = "debugmode.k8s.cloudogu.com/sensitive-dogu-config"
+	// profileAnnotation selects a comma separated list of operator wide dogu config profiles for a single DebugMode.
+	profileAnnotation = "debugmode.k8s.cloudogu.com/profile"
+)
+
+// DoguConfigOverrides maps dogu names to the config keys and values set while the debug mode is active.
+type DoguConfigOverrides map[string]map[string]string
+
+// ParseDoguConfigOverrides reads dogu config overrides from JSON, e.g. {"nexus": {"logging/org.sonatype": "DEBUG"}}.
+// An empty string results in no overrides.
+func ParseDoguConfigOverrides(data string) (DoguConfigOverrides, error) {
+	overrides := DoguConfigOverrides{}
+	if strings.TrimSpace(data) == "" {
+		return overrides, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse dogu config overrides: %w", err)
+	}
+	if err := overrides.validate(); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// ParseDoguConfigProfiles reads named sets of dogu config overrides from JSON,
+// e.g. {"sql-logging": {"redmine": {"logging/sql": "true"}}}. An empty string results in no profiles.
+func ParseDoguConfigProfiles(data string) (map[string]DoguConfigOverrides, error) {
+	profiles := map[string]DoguConfigOverrides{}
+	if strings.TrimSpace(data) == "" {
+		return profiles, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse dogu config profiles: %w", err)
+	}
+	if err := ValidateDoguConfigProfiles(profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// ValidateDoguConfigProfiles returns an error if a profile contains an empty or a reserved config key.
+func ValidateDoguConfigProfiles(profiles map[string]DoguConfigOverrides) error {
+	for name, overrides := range profiles {
+		if err := overrides.validate(); err != nil {
+			return fmt.Errorf("invalid profile %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (o DoguConfigOverrides) validate() error {
+	for doguName, values := range o {
+		for key := range values {
+			if strings.TrimSpace(key) == "" {
+				return fmt.Errorf("empty config key for dogu %s", doguName)
+			}
+			if key == doguLogLevelKey {
+				return fmt.Errorf("config key %s of dogu %s is set by the target log level and cannot be overridden", key, doguName)
+			}
+		}
+	}
+	return nil
+}
+
+// merge adds all overrides of the other overrides. Values of the other overrides win.
+func (o DoguConfigOverrides) merge(other DoguConfigOverrides) {
+	for doguName, values := range other {
+		if o[doguName] == nil {
+			o[doguName] = map[string]string{}
+		}
+		maps.Copy(o[doguName], values)
+	}
+}
+
+// doguConfigOverridesFor returns the dogu config overrides of the given debug mode.
+// Selected profiles are applied first, so the annotation of the DebugMode can override single values.
+func (r *DebugModeReconciler) doguConfigOverridesFor(cr *k8sCRLib.DebugMode) (DoguConfigOverrides, error) {
+	overrides := DoguConfigOverrides{}
+	if cr == nil {
+		return overrides, nil
+	}
+
+	for _, name := range strings.Split(cr.Annotations[profileAnnotation], ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		profile, found := r.doguConfigProfiles[name]
+		if !found {
+			return nil, fmt.Errorf("unknown dogu config profile %q in annotation %s", name, profileAnnotation)
+		}
+		overrides.merge(profile)
+	}
+
+	declared, err := ParseDoguConfigOverrides(cr.Annotations[doguConfigAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", doguConfigAnnotation, err)
+	}
+	overrides.merge(declared)
+	return overrides, nil
+}
+
+// sensitiveDoguConfigOverridesFor returns the sensitive dogu config overrides of the given debug mode
+// read from the Secret named in its annotation. Only labeled Secrets are accepted.
+func (r *DebugModeReconciler) sensitiveDoguConfigOverridesFor(ctx context.Context, cr *k8sCRLib.DebugMode) (DoguConfigOverrides, error) {
+	if cr == nil {
+		return DoguConfigOverrides{}, nil
+	}
+	secretName := strings.TrimSpace(cr.Annotations[sensitiveDoguConfigAnnotation])
+	if secretName == "" {
+		return DoguConfigOverrides{}, nil
+	}
+	if r.secretInterface == nil || r.sensitiveDoguConfigHandler == nil {
+		return nil, fmt.Errorf("annotation %s is set, but sensitive dogu config is not configured", sensitiveDoguConfigAnnotation)
+	}
+
+	secret, err := r.secretInterface.Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", secretName, err)
+	}
+	if secret.Labels[sensitiveDoguConfigLabel] != "true" {
+		return nil, fmt.Errorf("secret %s is not labeled %s=true", secretName, sensitiveDoguConfigLabel)
+	}
+	overrides, err := ParseDoguConfigOverrides(string(secret.Data[sensitiveDoguConfigSecretKey]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %s in secret %s: %w", sensitiveDoguConfigSecretKey, secretName, err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"testing"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ParseDoguConfigOverrides(t *testing.T) {
+	t.Run("should parse overrides", func(t *testing.T) {
+		// when
+		overrides, err := ParseDoguConfigOverrides(`{"nexus": {"logging/org.sonatype": "DEBUG"}}`)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, DoguConfigOverrides{"nexus": {"logging/org.sonatype": "DEBUG"}}, overrides)
+	})
+	t.Run("should return no overrides for empty string", func(t *testing.T) {
+		// when
+		overrides, err := ParseDoguConfigOverrides("")
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, overrides)
+	})
+	t.Run("should fail on invalid json", func(t *testing.T) {
+		// when
+		_, err := ParseDoguConfigOverrides(`{"nexus": ["logging/sql"]}`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse dogu config overrides")
+	})
+	t.Run("should refuse log level key", func(t *testing.T) {
+		// when
+		_, err := ParseDoguConfigOverrides(`{"nexus": {"logging/root": "DEBUG"}}`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "config key logging/root of dogu nexus is set by the target log level")
+	})
+	t.Run("should refuse empty key", func(t *testing.T) {
+		// when
+		_, err := ParseDoguConfigOverrides(`{"nexus": {" ": "DEBUG"}}`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "empty config key for dogu nexus")
+	})
+}
+
+func Test_ParseDoguConfigProfiles(t *testing.T) {
+	t.Run("should parse profiles", func(t *testing.T) {
+		// when
+		profiles, err := ParseDoguConfigProfiles(`{"sql-logging": {"redmine": {"logging/sql": "true"}}}`)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]DoguConfigOverrides{"sql-logging": {"redmine": {"logging/sql": "true"}}}, profiles)
+	})
+	t.Run("should fail on invalid profile", func(t *testing.T) {
+		// when
+		_, err := ParseDoguConfigProfiles(`{"root": {"redmine": {"logging/root": "DEBUG"}}}`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid profile root")
+	})
+	t.Run("should fail on invalid json", func(t *testing.T) {
+		// when
+		_, err := ParseDoguConfigProfiles(`[]`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse dogu config profiles")
+	})
+}
+
+func Test_DebugModeReconciler_doguConfigOverridesFor(t *testing.T) {
+	dmc := &DebugModeReconciler{doguConfigProfiles: map[string]DoguConfigOverrides{
+		"sql-logging": {"redmine": {"logging/sql": "true"}},
+		"java":        {"nexus": {"logging/org.sonatype": "DEBUG"}, "redmine": {"logging/sql": "verbose"}},
+	}}
+	withAnnotations := func(annotations map[string]string) *k8sCRLib.DebugMode {
+		return &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+
+	t.Run("should return no overrides without cr", func(t *testing.T) {
+		overrides, err := dmc.doguConfigOverridesFor(nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, overrides)
+	})
+	t.Run("should merge profiles and annotation", func(t *testing.T) {
+		// given
+		cr := withAnnotations(map[string]string{
+			profileAnnotation:    "sql-logging, java",
+			doguConfigAnnotation: `{"nexus": {"logging/org.sonatype": "TRACE"}, "cas": {"logging/ldap": "DEBUG"}}`,
+		})
+
+		// when
+		overrides, err := dmc.doguConfigOverridesFor(cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, DoguConfigOverrides{
+			"redmine": {"logging/sql": "verbose"},
+			"nexus":   {"logging/org.sonatype": "TRACE"},
+			"cas":     {"logging/ldap": "DEBUG"},
+		}, overrides)
+		assert.Equal(t, "DEBUG", dmc.doguConfigProfiles["java"]["nexus"]["logging/org.sonatype"], "profiles must not be changed")
+	})
+	t.Run("should fail on unknown profile", func(t *testing.T) {
+		// when
+		_, err := dmc.doguConfigOverridesFor(withAnnotations(map[string]string{profileAnnotation: "unknown"}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `unknown dogu config profile "unknown"`)
+	})
+	t.Run("should fail on invalid annotation", func(t *testing.T) {
+		// when
+		_, err := dmc.doguConfigOverridesFor(withAnnotations(map[string]string{doguConfigAnnotation: "{"}))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid annotation debugmode.k8s.cloudogu.com/dogu-config")
+	})
+}
+
+func Test_DebugModeReconciler_sensitiveDoguConfigOverridesFor(t *testing.T) {
+	ctx := t.Context()
+	cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{sensitiveDoguConfigAnnotation: "debug-secrets"}}}
+	labeled := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{sensitiveDoguConfigLabel: "true"}}, Data: data}
+	}
+
+	t.Run("should read overrides from secret", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		secrets.EXPECT().Get(ctx, "debug-secrets", metav1.GetOptions{}).Return(labeled(map[string][]byte{
+			"dogu-config": []byte(`{"redmine": {"logging/smtp-trace-token": "debug", "smtp/trace-token": "s3cr3t"}}`),
+		}), nil)
+		dmc := &DebugModeReconciler{secretInterface: secrets, sensitiveDoguConfigHandler: NewMockConfigHandler(t)}
+
+		// when
+		overrides, err := dmc.sensitiveDoguConfigOverridesFor(ctx, cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, DoguConfigOverrides{"redmine": {"logging/smtp-trace-token": "debug", "smtp/trace-token": "s3cr3t"}}, overrides)
+	})
+	t.Run("should reject secret without label", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		secrets.EXPECT().Get(ctx, "debug-secrets", metav1.GetOptions{}).Return(&corev1.Secret{Data: map[string][]byte{
+			"dogu-config": []byte(`{"redmine": {"logging/smtp-trace-token": "debug"}}`),
+		}}, nil)
+		dmc := &DebugModeReconciler{secretInterface: secrets, sensitiveDoguConfigHandler: NewMockConfigHandler(t)}
+
+		// when
+		_, err := dmc.sensitiveDoguConfigOverridesFor(ctx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "secret debug-secrets is not labeled debugmode.k8s.cloudogu.com/sensitive-dogu-config=true")
+	})
+	t.Run("should return no overrides without annotation", func(t *testing.T) {
+		overrides, err := (&DebugModeReconciler{}).sensitiveDoguConfigOverridesFor(ctx, &k8sCRLib.DebugMode{})
+
+		require.NoError(t, err)
+		assert.Empty(t, overrides)
+	})
+	t.Run("should fail if sensitive dogu config is not configured", func(t *testing.T) {
+		_, err := (&DebugModeReconciler{}).sensitiveDoguConfigOverridesFor(ctx, cr)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "sensitive dogu config is not configured")
+	})
+	t.Run("error getting secret", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		secrets.EXPECT().Get(ctx, "debug-secrets", metav1.GetOptions{}).Return(nil, assert.AnError)
+		dmc := &DebugModeReconciler{secretInterface: secrets, sensitiveDoguConfigHandler: NewMockConfigHandler(t)}
+
+		// when
+		_, err := dmc.sensitiveDoguConfigOverridesFor(ctx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should fail on invalid overrides in secret", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		secrets.EXPECT().Get(ctx, "debug-secrets", metav1.GetOptions{}).Return(labeled(map[string][]byte{"dogu-config": []byte("{")}), nil)
+		dmc := &DebugModeReconciler{secretInterface: secrets, sensitiveDoguConfigHandler: NewMockConfigHandler(t)}
+
+		// when
+		_, err := dmc.sensitiveDoguConfigOverridesFor(ctx, cr)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid key dogu-config in secret debug-secrets")
+	})
+}
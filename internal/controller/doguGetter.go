@@ -47,3 +47,12 @@ func (r *DoguGetter) GetCurrentOfAll(ctx context.Context) ([]*core.Dogu, error)
 	}
 	return allCurrentDogus, nil
 }
+
+// CheckAccess returns an error if the dogu version registry cannot be read.
+func (r *DoguGetter) CheckAccess(ctx context.Context) error {
+	_, err := r.versionRegistry.GetCurrentOfAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read dogu version registry: %w", err)
+	}
+	return nil
+}
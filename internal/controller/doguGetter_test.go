@@ -187,3 +187,32 @@ func Test_DoguGetter_GeteCurrentOfAll(t *testing.T) {
 		assert.Nil(t, retdogu)
 	})
 }
+
+func Test_DoguGetter_CheckAccess(t *testing.T) {
+	ctx := t.Context()
+	t.Run("success", func(t *testing.T) {
+		// given
+		versionRegistry := newMockDoguVersionRegistry(t)
+		doguGetter := NewDoguGetter(versionRegistry, newMockLocalDoguDescriptorRepository(t))
+		versionRegistry.EXPECT().GetCurrentOfAll(ctx).Return([]dogu2.SimpleNameVersion{}, nil)
+
+		// when
+		err := doguGetter.CheckAccess(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+	t.Run("error on get current of all", func(t *testing.T) {
+		// given
+		versionRegistry := newMockDoguVersionRegistry(t)
+		doguGetter := NewDoguGetter(versionRegistry, newMockLocalDoguDescriptorRepository(t))
+		versionRegistry.EXPECT().GetCurrentOfAll(ctx).Return(nil, assert.AnError)
+
+		// when
+		err := doguGetter.CheckAccess(ctx)
+
+		// then
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to read dogu version registry")
+	})
+}
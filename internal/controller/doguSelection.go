@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"slices"
+	"strings"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+// DogusAnnotation restricts a DebugMode to the log levels of the listed dogus, e.g. "cas,ldap".
+// Without the annotation the log levels of all dogus are changed.
+const DogusAnnotation = "debugmode.k8s.cloudogu.com/dogus"
+
+// ParseDoguSelection returns the sorted names of a comma separated list of dogus. Empty names are ignored.
+func ParseDoguSelection(value string) []string {
+	var dogus []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			dogus = append(dogus, name)
+		}
+	}
+	slices.Sort(dogus)
+	return slices.Compact(dogus)
+}
+
+// doguSelectionFor returns the dogus selected by the given debug mode or nil if all dogus are selected.
+func doguSelectionFor(cr *k8sCRLib.DebugMode) []string {
+	if cr == nil {
+		return nil
+	}
+	return ParseDoguSelection(cr.Annotations[DogusAnnotation])
+}
+
+// selects returns true if the dogu is part of the selection. An empty selection selects all dogus.
+func selects(selection []string, name string) bool {
+	return len(selection) == 0 || slices.Contains(selection, name)
+}
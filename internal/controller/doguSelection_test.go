@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"testing"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ParseDoguSelection(t *testing.T) {
+	assert.Nil(t, ParseDoguSelection(""))
+	assert.Nil(t, ParseDoguSelection(" , "))
+	assert.Equal(t, []string{"cas", "ldap"}, ParseDoguSelection("ldap, cas,,ldap"))
+}
+
+func Test_doguSelectionFor(t *testing.T) {
+	assert.Nil(t, doguSelectionFor(nil))
+	assert.Nil(t, doguSelectionFor(&k8sCRLib.DebugMode{}))
+
+	cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{DogusAnnotation: "cas,ldap"}}}
+	assert.Equal(t, []string{"cas", "ldap"}, doguSelectionFor(cr))
+}
+
+func Test_selects(t *testing.T) {
+	assert.True(t, selects(nil, "cas"))
+	assert.True(t, selects([]string{"cas"}, "cas"))
+	assert.False(t, selects([]string{"ldap"}, "cas"))
+}
@@ -1,11 +1,20 @@
 package controller
 
 import (
+	"context"
+
 	"github.com/cloudogu/ces-commons-lib/dogu"
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
 	libclient "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
 	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/types"
+	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 	typev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -41,6 +50,53 @@ type configurationMap interface {
 	typev1.ConfigMapInterface
 }
 
+type eventRecorder interface {
+	events.EventRecorder
+}
+
 type LogLevelHandler interface {
 	loglevel.LogLevelHandler
 }
+
+type ConfigHandler interface {
+	loglevel.ConfigHandler
+}
+
+type secretInterface interface {
+	typev1.SecretInterface
+}
+
+type deploymentInterface interface {
+	appsv1.DeploymentInterface
+}
+
+// operatorLogLevel is the runtime adjustable log level of the operator itself.
+type operatorLogLevel interface {
+	Raise(level zapcore.Level)
+	Reset()
+}
+
+// auditTrail records the history of all debug mode sessions.
+type auditTrail interface {
+	Observe(ctx context.Context, cr *k8sCRLib.DebugMode) error
+	RecordChanges(ctx context.Context, uid types.UID, changes []audit.Change) error
+	RecordFailure(ctx context.Context, uid types.UID, failure error) error
+	RecordEnd(ctx context.Context, uid types.UID, result audit.Result, message string) error
+	Archive(ctx context.Context, cr *k8sCRLib.DebugMode) error
+}
+
+// reconcilerProvider provides the reconciler of the DebugModes in a namespace.
+type reconcilerProvider interface {
+	ForNamespace(namespace string) (*DebugModeReconciler, error)
+}
+
+// reconcileWatchdog detects a stuck reconciler for the liveness probe.
+type reconcileWatchdog interface {
+	Started(key types.NamespacedName)
+	Finished(key types.NamespacedName, result ctrl.Result, err error)
+}
+
+// notifier informs external systems about the events of debug modes.
+type notifier interface {
+	Notify(ctx context.Context, notification notify.Notification)
+}
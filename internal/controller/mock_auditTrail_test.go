@@ -0,0 +1,281 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package controller
+
+import (
+	context "context"
+
+	audit "github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "k8s.io/apimachinery/pkg/types"
+
+	v1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+)
+
+// mockAuditTrail is an autogenerated mock type for the auditTrail type
+type mockAuditTrail struct {
+	mock.Mock
+}
+
+type mockAuditTrail_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockAuditTrail) EXPECT() *mockAuditTrail_Expecter {
+	return &mockAuditTrail_Expecter{mock: &_m.Mock}
+}
+
+// Archive provides a mock function with given fields: ctx, cr
+func (_m *mockAuditTrail) Archive(ctx context.Context, cr *v1.DebugMode) error {
+	ret := _m.Called(ctx, cr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.DebugMode) error); ok {
+		r0 = rf(ctx, cr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockAuditTrail_Archive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Archive'
+type mockAuditTrail_Archive_Call struct {
+	*mock.Call
+}
+
+// Archive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cr *v1.DebugMode
+func (_e *mockAuditTrail_Expecter) Archive(ctx interface{}, cr interface{}) *mockAuditTrail_Archive_Call {
+	return &mockAuditTrail_Archive_Call{Call: _e.mock.On("Archive", ctx, cr)}
+}
+
+func (_c *mockAuditTrail_Archive_Call) Run(run func(ctx context.Context, cr *v1.DebugMode)) *mockAuditTrail_Archive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.DebugMode))
+	})
+	return _c
+}
+
+func (_c *mockAuditTrail_Archive_Call) Return(_a0 error) *mockAuditTrail_Archive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockAuditTrail_Archive_Call) RunAndReturn(run func(context.Context, *v1.DebugMode) error) *mockAuditTrail_Archive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Observe provides a mock function with given fields: ctx, cr
+func (_m *mockAuditTrail) Observe(ctx context.Context, cr *v1.DebugMode) error {
+	ret := _m.Called(ctx, cr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Observe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.DebugMode) error); ok {
+		r0 = rf(ctx, cr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockAuditTrail_Observe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Observe'
+type mockAuditTrail_Observe_Call struct {
+	*mock.Call
+}
+
+// Observe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cr *v1.DebugMode
+func (_e *mockAuditTrail_Expecter) Observe(ctx interface{}, cr interface{}) *mockAuditTrail_Observe_Call {
+	return &mockAuditTrail_Observe_Call{Call: _e.mock.On("Observe", ctx, cr)}
+}
+
+func (_c *mockAuditTrail_Observe_Call) Run(run func(ctx context.Context, cr *v1.DebugMode)) *mockAuditTrail_Observe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.DebugMode))
+	})
+	return _c
+}
+
+func (_c *mockAuditTrail_Observe_Call) Return(_a0 error) *mockAuditTrail_Observe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockAuditTrail_Observe_Call) RunAndReturn(run func(context.Context, *v1.DebugMode) error) *mockAuditTrail_Observe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordChanges provides a mock function with given fields: ctx, uid, changes
+func (_m *mockAuditTrail) RecordChanges(ctx context.Context, uid types.UID, changes []audit.Change) error {
+	ret := _m.Called(ctx, uid, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.UID, []audit.Change) error); ok {
+		r0 = rf(ctx, uid, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockAuditTrail_RecordChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordChanges'
+type mockAuditTrail_RecordChanges_Call struct {
+	*mock.Call
+}
+
+// RecordChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid types.UID
+//   - changes []audit.Change
+func (_e *mockAuditTrail_Expecter) RecordChanges(ctx interface{}, uid interface{}, changes interface{}) *mockAuditTrail_RecordChanges_Call {
+	return &mockAuditTrail_RecordChanges_Call{Call: _e.mock.On("RecordChanges", ctx, uid, changes)}
+}
+
+func (_c *mockAuditTrail_RecordChanges_Call) Run(run func(ctx context.Context, uid types.UID, changes []audit.Change)) *mockAuditTrail_RecordChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.UID), args[2].([]audit.Change))
+	})
+	return _c
+}
+
+func (_c *mockAuditTrail_RecordChanges_Call) Return(_a0 error) *mockAuditTrail_RecordChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockAuditTrail_RecordChanges_Call) RunAndReturn(run func(context.Context, types.UID, []audit.Change) error) *mockAuditTrail_RecordChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordEnd provides a mock function with given fields: ctx, uid, result, message
+func (_m *mockAuditTrail) RecordEnd(ctx context.Context, uid types.UID, result audit.Result, message string) error {
+	ret := _m.Called(ctx, uid, result, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordEnd")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.UID, audit.Result, string) error); ok {
+		r0 = rf(ctx, uid, result, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockAuditTrail_RecordEnd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordEnd'
+type mockAuditTrail_RecordEnd_Call struct {
+	*mock.Call
+}
+
+// RecordEnd is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid types.UID
+//   - result audit.Result
+//   - message string
+func (_e *mockAuditTrail_Expecter) RecordEnd(ctx interface{}, uid interface{}, result interface{}, message interface{}) *mockAuditTrail_RecordEnd_Call {
+	return &mockAuditTrail_RecordEnd_Call{Call: _e.mock.On("RecordEnd", ctx, uid, result, message)}
+}
+
+func (_c *mockAuditTrail_RecordEnd_Call) Run(run func(ctx context.Context, uid types.UID, result audit.Result, message string)) *mockAuditTrail_RecordEnd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.UID), args[2].(audit.Result), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *mockAuditTrail_RecordEnd_Call) Return(_a0 error) *mockAuditTrail_RecordEnd_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockAuditTrail_RecordEnd_Call) RunAndReturn(run func(context.Context, types.UID, audit.Result, string) error) *mockAuditTrail_RecordEnd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordFailure provides a mock function with given fields: ctx, uid, failure
+func (_m *mockAuditTrail) RecordFailure(ctx context.Context, uid types.UID, failure error) error {
+	ret := _m.Called(ctx, uid, failure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.UID, error) error); ok {
+		r0 = rf(ctx, uid, failure)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockAuditTrail_RecordFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordFailure'
+type mockAuditTrail_RecordFailure_Call struct {
+	*mock.Call
+}
+
+// RecordFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid types.UID
+//   - failure error
+func (_e *mockAuditTrail_Expecter) RecordFailure(ctx interface{}, uid interface{}, failure interface{}) *mockAuditTrail_RecordFailure_Call {
+	return &mockAuditTrail_RecordFailure_Call{Call: _e.mock.On("RecordFailure", ctx, uid, failure)}
+}
+
+func (_c *mockAuditTrail_RecordFailure_Call) Run(run func(ctx context.Context, uid types.UID, failure error)) *mockAuditTrail_RecordFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.UID), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *mockAuditTrail_RecordFailure_Call) Return(_a0 error) *mockAuditTrail_RecordFailure_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockAuditTrail_RecordFailure_Call) RunAndReturn(run func(context.Context, types.UID, error) error) *mockAuditTrail_RecordFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockAuditTrail creates a new instance of mockAuditTrail. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockAuditTrail(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockAuditTrail {
+	mock := &mockAuditTrail{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,71 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package controller
+
+import (
+	context "context"
+
+	notify "github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockNotifier is an autogenerated mock type for the notifier type
+type mockNotifier struct {
+	mock.Mock
+}
+
+type mockNotifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockNotifier) EXPECT() *mockNotifier_Expecter {
+	return &mockNotifier_Expecter{mock: &_m.Mock}
+}
+
+// Notify provides a mock function with given fields: ctx, notification
+func (_m *mockNotifier) Notify(ctx context.Context, notification notify.Notification) {
+	_m.Called(ctx, notification)
+}
+
+// mockNotifier_Notify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Notify'
+type mockNotifier_Notify_Call struct {
+	*mock.Call
+}
+
+// Notify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notification notify.Notification
+func (_e *mockNotifier_Expecter) Notify(ctx interface{}, notification interface{}) *mockNotifier_Notify_Call {
+	return &mockNotifier_Notify_Call{Call: _e.mock.On("Notify", ctx, notification)}
+}
+
+func (_c *mockNotifier_Notify_Call) Run(run func(ctx context.Context, notification notify.Notification)) *mockNotifier_Notify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(notify.Notification))
+	})
+	return _c
+}
+
+func (_c *mockNotifier_Notify_Call) Return() *mockNotifier_Notify_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockNotifier_Notify_Call) RunAndReturn(run func(context.Context, notify.Notification)) *mockNotifier_Notify_Call {
+	_c.Run(run)
+	return _c
+}
+
+// newMockNotifier creates a new instance of mockNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockNotifier {
+	mock := &mockNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
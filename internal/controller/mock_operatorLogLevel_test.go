@@ -0,0 +1,100 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package controller
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	zapcore "go.uber.org/zap/zapcore"
+)
+
+// mockOperatorLogLevel is an autogenerated mock type for the operatorLogLevel type
+type mockOperatorLogLevel struct {
+	mock.Mock
+}
+
+type mockOperatorLogLevel_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockOperatorLogLevel) EXPECT() *mockOperatorLogLevel_Expecter {
+	return &mockOperatorLogLevel_Expecter{mock: &_m.Mock}
+}
+
+// Raise provides a mock function with given fields: level
+func (_m *mockOperatorLogLevel) Raise(level zapcore.Level) {
+	_m.Called(level)
+}
+
+// mockOperatorLogLevel_Raise_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Raise'
+type mockOperatorLogLevel_Raise_Call struct {
+	*mock.Call
+}
+
+// Raise is a helper method to define mock.On call
+//   - level zapcore.Level
+func (_e *mockOperatorLogLevel_Expecter) Raise(level interface{}) *mockOperatorLogLevel_Raise_Call {
+	return &mockOperatorLogLevel_Raise_Call{Call: _e.mock.On("Raise", level)}
+}
+
+func (_c *mockOperatorLogLevel_Raise_Call) Run(run func(level zapcore.Level)) *mockOperatorLogLevel_Raise_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(zapcore.Level))
+	})
+	return _c
+}
+
+func (_c *mockOperatorLogLevel_Raise_Call) Return() *mockOperatorLogLevel_Raise_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockOperatorLogLevel_Raise_Call) RunAndReturn(run func(zapcore.Level)) *mockOperatorLogLevel_Raise_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Reset provides a mock function with no fields
+func (_m *mockOperatorLogLevel) Reset() {
+	_m.Called()
+}
+
+// mockOperatorLogLevel_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type mockOperatorLogLevel_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+func (_e *mockOperatorLogLevel_Expecter) Reset() *mockOperatorLogLevel_Reset_Call {
+	return &mockOperatorLogLevel_Reset_Call{Call: _e.mock.On("Reset")}
+}
+
+func (_c *mockOperatorLogLevel_Reset_Call) Run(run func()) *mockOperatorLogLevel_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockOperatorLogLevel_Reset_Call) Return() *mockOperatorLogLevel_Reset_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockOperatorLogLevel_Reset_Call) RunAndReturn(run func()) *mockOperatorLogLevel_Reset_Call {
+	_c.Run(run)
+	return _c
+}
+
+// newMockOperatorLogLevel creates a new instance of mockOperatorLogLevel. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockOperatorLogLevel(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockOperatorLogLevel {
+	mock := &mockOperatorLogLevel{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
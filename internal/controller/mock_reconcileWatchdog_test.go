@@ -0,0 +1,105 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package controller
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	reconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// mockReconcileWatchdog is an autogenerated mock type for the reconcileWatchdog type
+type mockReconcileWatchdog struct {
+	mock.Mock
+}
+
+type mockReconcileWatchdog_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockReconcileWatchdog) EXPECT() *mockReconcileWatchdog_Expecter {
+	return &mockReconcileWatchdog_Expecter{mock: &_m.Mock}
+}
+
+// Finished provides a mock function with given fields: key, result, err
+func (_m *mockReconcileWatchdog) Finished(key types.NamespacedName, result reconcile.Result, err error) {
+	_m.Called(key, result, err)
+}
+
+// mockReconcileWatchdog_Finished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Finished'
+type mockReconcileWatchdog_Finished_Call struct {
+	*mock.Call
+}
+
+// Finished is a helper method to define mock.On call
+//   - key types.NamespacedName
+//   - result reconcile.Result
+//   - err error
+func (_e *mockReconcileWatchdog_Expecter) Finished(key interface{}, result interface{}, err interface{}) *mockReconcileWatchdog_Finished_Call {
+	return &mockReconcileWatchdog_Finished_Call{Call: _e.mock.On("Finished", key, result, err)}
+}
+
+func (_c *mockReconcileWatchdog_Finished_Call) Run(run func(key types.NamespacedName, result reconcile.Result, err error)) *mockReconcileWatchdog_Finished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.NamespacedName), args[1].(reconcile.Result), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *mockReconcileWatchdog_Finished_Call) Return() *mockReconcileWatchdog_Finished_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockReconcileWatchdog_Finished_Call) RunAndReturn(run func(types.NamespacedName, reconcile.Result, error)) *mockReconcileWatchdog_Finished_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Started provides a mock function with given fields: key
+func (_m *mockReconcileWatchdog) Started(key types.NamespacedName) {
+	_m.Called(key)
+}
+
+// mockReconcileWatchdog_Started_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Started'
+type mockReconcileWatchdog_Started_Call struct {
+	*mock.Call
+}
+
+// Started is a helper method to define mock.On call
+//   - key types.NamespacedName
+func (_e *mockReconcileWatchdog_Expecter) Started(key interface{}) *mockReconcileWatchdog_Started_Call {
+	return &mockReconcileWatchdog_Started_Call{Call: _e.mock.On("Started", key)}
+}
+
+func (_c *mockReconcileWatchdog_Started_Call) Run(run func(key types.NamespacedName)) *mockReconcileWatchdog_Started_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.NamespacedName))
+	})
+	return _c
+}
+
+func (_c *mockReconcileWatchdog_Started_Call) Return() *mockReconcileWatchdog_Started_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockReconcileWatchdog_Started_Call) RunAndReturn(run func(types.NamespacedName)) *mockReconcileWatchdog_Started_Call {
+	_c.Run(run)
+	return _c
+}
+
+// newMockReconcileWatchdog creates a new instance of mockReconcileWatchdog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockReconcileWatchdog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockReconcileWatchdog {
+	mock := &mockReconcileWatchdog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
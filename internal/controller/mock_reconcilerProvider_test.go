@@ -0,0 +1,90 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package controller
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockReconcilerProvider is an autogenerated mock type for the reconcilerProvider type
+type mockReconcilerProvider struct {
+	mock.Mock
+}
+
+type mockReconcilerProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockReconcilerProvider) EXPECT() *mockReconcilerProvider_Expecter {
+	return &mockReconcilerProvider_Expecter{mock: &_m.Mock}
+}
+
+// ForNamespace provides a mock function with given fields: namespace
+func (_m *mockReconcilerProvider) ForNamespace(namespace string) (*DebugModeReconciler, error) {
+	ret := _m.Called(namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForNamespace")
+	}
+
+	var r0 *DebugModeReconciler
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*DebugModeReconciler, error)); ok {
+		return rf(namespace)
+	}
+	if rf, ok := ret.Get(0).(func(string) *DebugModeReconciler); ok {
+		r0 = rf(namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*DebugModeReconciler)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockReconcilerProvider_ForNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForNamespace'
+type mockReconcilerProvider_ForNamespace_Call struct {
+	*mock.Call
+}
+
+// ForNamespace is a helper method to define mock.On call
+//   - namespace string
+func (_e *mockReconcilerProvider_Expecter) ForNamespace(namespace interface{}) *mockReconcilerProvider_ForNamespace_Call {
+	return &mockReconcilerProvider_ForNamespace_Call{Call: _e.mock.On("ForNamespace", namespace)}
+}
+
+func (_c *mockReconcilerProvider_ForNamespace_Call) Run(run func(namespace string)) *mockReconcilerProvider_ForNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *mockReconcilerProvider_ForNamespace_Call) Return(_a0 *DebugModeReconciler, _a1 error) *mockReconcilerProvider_ForNamespace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockReconcilerProvider_ForNamespace_Call) RunAndReturn(run func(string) (*DebugModeReconciler, error)) *mockReconcilerProvider_ForNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockReconcilerProvider creates a new instance of mockReconcilerProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockReconcilerProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockReconcilerProvider {
+	mock := &mockReconcilerProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
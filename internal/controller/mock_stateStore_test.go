@@ -0,0 +1,146 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package controller
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockStateStore is an autogenerated mock type for the stateStore type
+type mockStateStore struct {
+	mock.Mock
+}
+
+type mockStateStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockStateStore) EXPECT() *mockStateStore_Expecter {
+	return &mockStateStore_Expecter{mock: &_m.Mock}
+}
+
+// getEntry provides a mock function with given fields: key
+func (_m *mockStateStore) getEntry(key string) (StateEntry, bool, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for getEntry")
+	}
+
+	var r0 StateEntry
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (StateEntry, bool, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) StateEntry); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(StateEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// mockStateStore_getEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'getEntry'
+type mockStateStore_getEntry_Call struct {
+	*mock.Call
+}
+
+// getEntry is a helper method to define mock.On call
+//   - key string
+func (_e *mockStateStore_Expecter) getEntry(key interface{}) *mockStateStore_getEntry_Call {
+	return &mockStateStore_getEntry_Call{Call: _e.mock.On("getEntry", key)}
+}
+
+func (_c *mockStateStore_getEntry_Call) Run(run func(key string)) *mockStateStore_getEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *mockStateStore_getEntry_Call) Return(_a0 StateEntry, _a1 bool, _a2 error) *mockStateStore_getEntry_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *mockStateStore_getEntry_Call) RunAndReturn(run func(string) (StateEntry, bool, error)) *mockStateStore_getEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// storeEntries provides a mock function with given fields: ctx, entries
+func (_m *mockStateStore) storeEntries(ctx context.Context, entries map[string]StateEntry) error {
+	ret := _m.Called(ctx, entries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for storeEntries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]StateEntry) error); ok {
+		r0 = rf(ctx, entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockStateStore_storeEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'storeEntries'
+type mockStateStore_storeEntries_Call struct {
+	*mock.Call
+}
+
+// storeEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entries map[string]StateEntry
+func (_e *mockStateStore_Expecter) storeEntries(ctx interface{}, entries interface{}) *mockStateStore_storeEntries_Call {
+	return &mockStateStore_storeEntries_Call{Call: _e.mock.On("storeEntries", ctx, entries)}
+}
+
+func (_c *mockStateStore_storeEntries_Call) Run(run func(ctx context.Context, entries map[string]StateEntry)) *mockStateStore_storeEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[string]StateEntry))
+	})
+	return _c
+}
+
+func (_c *mockStateStore_storeEntries_Call) Return(_a0 error) *mockStateStore_storeEntries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockStateStore_storeEntries_Call) RunAndReturn(run func(context.Context, map[string]StateEntry) error) *mockStateStore_storeEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockStateStore creates a new instance of mockStateStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockStateStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockStateStore {
+	mock := &mockStateStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
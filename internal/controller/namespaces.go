@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ErrNamespaceNotWatched is returned for namespaces whose debug modes are not managed by the operator.
+var ErrNamespaceNotWatched = errors.New("namespace is not watched")
+
+// ErrNamespaceNotFound is returned for namespaces that do not exist.
+var ErrNamespaceNotFound = errors.New("namespace does not exist")
+
+// NamespaceCache creates a value per namespace, e.g. the clients of a Cloudogu EcoSystem, when it is first requested
+// and keeps it afterward.
+type NamespaceCache[T any] struct {
+	// watched contains the namespaces values can be created for. All namespaces are watched if it is empty.
+	watched []string
+	create  func(namespace string) (T, error)
+	mutex   sync.Mutex
+	values  map[string]T
+}
+
+// NewNamespaceCache creates a cache of values for the given namespaces or, if none are given, for all namespaces.
+func NewNamespaceCache[T any](watched []string, create func(namespace string) (T, error)) *NamespaceCache[T] {
+	return &NamespaceCache[T]{watched: watched, create: create, values: map[string]T{}}
+}
+
+// Watches returns true if values can be created for the given namespace.
+func (c *NamespaceCache[T]) Watches(namespace string) bool {
+	if namespace == "" {
+		return false
+	}
+	return len(c.watched) == 0 || slices.Contains(c.watched, namespace)
+}
+
+// Get returns the value of the given namespace and creates it if necessary.
+func (c *NamespaceCache[T]) Get(namespace string) (T, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if value, found := c.values[namespace]; found {
+		return value, nil
+	}
+	if !c.Watches(namespace) {
+		var empty T
+		return empty, fmt.Errorf("%w: %q", ErrNamespaceNotWatched, namespace)
+	}
+	value, err := c.create(namespace)
+	if err != nil {
+		var empty T
+		return empty, fmt.Errorf("failed to create clients for namespace %s: %w", namespace, err)
+	}
+	c.values[namespace] = value
+	return value, nil
+}
+
+// GetExisting returns the value of the given namespace like Get, but only creates it if the given function confirms
+// that the namespace exists. It guards requests naming arbitrary namespaces, because created values are kept forever.
+func (c *NamespaceCache[T]) GetExisting(ctx context.Context, namespace string, exists func(ctx context.Context, namespace string) (bool, error)) (T, error) {
+	var empty T
+	c.mutex.Lock()
+	value, found := c.values[namespace]
+	c.mutex.Unlock()
+	if found {
+		return value, nil
+	}
+	if !c.Watches(namespace) {
+		return empty, fmt.Errorf("%w: %q", ErrNamespaceNotWatched, namespace)
+	}
+
+	existing, err := exists(ctx, namespace)
+	if err != nil {
+		return empty, fmt.Errorf("failed to check namespace %s: %w", namespace, err)
+	}
+	if !existing {
+		return empty, fmt.Errorf("%w: %q", ErrNamespaceNotFound, namespace)
+	}
+	return c.Get(namespace)
+}
+
+// Created returns the values created so far, sorted by namespace.
+func (c *NamespaceCache[T]) Created() []T {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	values := make([]T, 0, len(c.values))
+	for _, namespace := range slices.Sorted(maps.Keys(c.values)) {
+		values = append(values, c.values[namespace])
+	}
+	return values
+}
+
+// NamespacedReconciler reconciles the DebugModes of several namespaces, each with the reconciler bound to the clients
+// of its namespace.
+type NamespacedReconciler struct {
+	reconcilers func(namespace string) (*DebugModeReconciler, error)
+}
+
+// NewNamespacedReconciler creates a reconciler dispatching every DebugMode to the reconciler of its namespace.
+func NewNamespacedReconciler(reconcilers func(namespace string) (*DebugModeReconciler, error)) *NamespacedReconciler {
+	return &NamespacedReconciler{reconcilers: reconcilers}
+}
+
+// ForNamespace returns the reconciler of the given namespace.
+func (n *NamespacedReconciler) ForNamespace(namespace string) (*DebugModeReconciler, error) {
+	return n.reconcilers(namespace)
+}
+
+func (n *NamespacedReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reconciler, err := n.ForNamespace(req.Namespace)
+	if err != nil {
+		if errors.Is(err, ErrNamespaceNotWatched) {
+			// the cache only contains watched namespaces, so this happens only if the configuration has changed
+			logging.FromContext(ctx).Info("Skip DebugMode", "debugMode", req.NamespacedName, "error", err)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	return reconciler.Reconcile(ctx, req)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (n *NamespacedReconciler) SetupWithManager(mgr controllerManager) error {
+	return setupWithManager(mgr, n)
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestNamespaceCache_Get(t *testing.T) {
+	t.Run("should create a value once per namespace", func(t *testing.T) {
+		// given
+		var created []string
+		cache := NewNamespaceCache([]string{"ecosystem-1", "ecosystem-2"}, func(namespace string) (string, error) {
+			created = append(created, namespace)
+			return "clients of " + namespace, nil
+		})
+
+		// when
+		first, err1 := cache.Get("ecosystem-1")
+		again, err2 := cache.Get("ecosystem-1")
+		second, err3 := cache.Get("ecosystem-2")
+
+		// then
+		require.NoError(t, errors.Join(err1, err2, err3))
+		assert.Equal(t, "clients of ecosystem-1", first)
+		assert.Equal(t, "clients of ecosystem-1", again)
+		assert.Equal(t, "clients of ecosystem-2", second)
+		assert.Equal(t, []string{"ecosystem-1", "ecosystem-2"}, created)
+		assert.Equal(t, []string{"clients of ecosystem-1", "clients of ecosystem-2"}, cache.Created())
+	})
+	t.Run("should fail for a namespace that is not watched", func(t *testing.T) {
+		cache := NewNamespaceCache([]string{"ecosystem"}, func(namespace string) (string, error) {
+			t.Fatal("must not create a value")
+			return "", nil
+		})
+
+		_, err := cache.Get("other")
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrNamespaceNotWatched)
+		assert.Empty(t, cache.Created())
+	})
+	t.Run("should watch all namespaces without a list", func(t *testing.T) {
+		cache := NewNamespaceCache(nil, func(namespace string) (string, error) { return namespace, nil })
+
+		value, err := cache.Get("any")
+
+		require.NoError(t, err)
+		assert.Equal(t, "any", value)
+		assert.False(t, cache.Watches(""))
+	})
+	t.Run("should retry a failed creation", func(t *testing.T) {
+		// given
+		fail := true
+		cache := NewNamespaceCache(nil, func(namespace string) (string, error) {
+			if fail {
+				return "", assert.AnError
+			}
+			return namespace, nil
+		})
+
+		// when
+		_, err := cache.Get("ecosystem")
+		fail = false
+		value, retryErr := cache.Get("ecosystem")
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+		require.NoError(t, retryErr)
+		assert.Equal(t, "ecosystem", value)
+	})
+}
+
+func TestNamespaceCache_GetExisting(t *testing.T) {
+	existing := func(_ context.Context, namespace string) (bool, error) {
+		return namespace == "ecosystem", nil
+	}
+
+	t.Run("should create a value for an existing namespace", func(t *testing.T) {
+		cache := NewNamespaceCache(nil, func(namespace string) (string, error) { return namespace, nil })
+
+		value, err := cache.GetExisting(t.Context(), "ecosystem", existing)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ecosystem", value)
+	})
+	t.Run("should not create a value for a namespace that does not exist", func(t *testing.T) {
+		cache := NewNamespaceCache(nil, func(namespace string) (string, error) { return namespace, nil })
+
+		_, err := cache.GetExisting(t.Context(), "typo", existing)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrNamespaceNotFound)
+		assert.Empty(t, cache.Created())
+	})
+	t.Run("should return created values without checking the namespace", func(t *testing.T) {
+		cache := NewNamespaceCache(nil, func(namespace string) (string, error) { return namespace, nil })
+		_, err := cache.Get("deleted")
+		require.NoError(t, err)
+
+		value, err := cache.GetExisting(t.Context(), "deleted", func(context.Context, string) (bool, error) {
+			t.Fatal("must not check the namespace")
+			return false, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "deleted", value)
+	})
+	t.Run("should fail if the namespace cannot be checked", func(t *testing.T) {
+		cache := NewNamespaceCache(nil, func(namespace string) (string, error) { return namespace, nil })
+
+		_, err := cache.GetExisting(t.Context(), "ecosystem", func(context.Context, string) (bool, error) {
+			return false, assert.AnError
+		})
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, cache.Created())
+	})
+	t.Run("should fail for a namespace that is not watched", func(t *testing.T) {
+		cache := NewNamespaceCache([]string{"ecosystem"}, func(namespace string) (string, error) { return namespace, nil })
+
+		_, err := cache.GetExisting(t.Context(), "other", existing)
+
+		require.ErrorIs(t, err, ErrNamespaceNotWatched)
+	})
+}
+
+func TestNamespacedReconciler_Reconcile(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("should reconcile with the reconciler of the namespace", func(t *testing.T) {
+		// given
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem-2", Name: "debug-mode"}}
+		debugModeClient := newMockDebugModeInterface(t)
+		reconciler := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), newMockConfigurationMap(t), NewMockLogLevelHandler(t))
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, Namespace: request.Namespace, UID: testDebugModeUID},
+			Status:     k8sCRLib.DebugModeStatus{Conditions: []metav1.Condition{{Reason: string(k8sCRLib.DebugModeStatusCompleted)}}},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		var requested string
+		sut := NewNamespacedReconciler(func(namespace string) (*DebugModeReconciler, error) {
+			requested = namespace
+			return reconciler, nil
+		})
+
+		// when
+		result, err := sut.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.Equal(t, "ecosystem-2", requested)
+	})
+	t.Run("should skip debug modes of namespaces that are not watched", func(t *testing.T) {
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "other", Name: "debug-mode"}}
+		sut := NewNamespacedReconciler(func(namespace string) (*DebugModeReconciler, error) {
+			return nil, ErrNamespaceNotWatched
+		})
+
+		_, err := sut.Reconcile(ctx, request)
+
+		require.NoError(t, err)
+	})
+	t.Run("should fail if the reconciler cannot be created", func(t *testing.T) {
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: "debug-mode"}}
+		sut := NewNamespacedReconciler(func(namespace string) (*DebugModeReconciler, error) {
+			return nil, assert.AnError
+		})
+
+		_, err := sut.Reconcile(ctx, request)
+
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
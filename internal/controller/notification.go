@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TriggeredByAnnotation names the dogu and the degradation that started an automatic debug mode,
+// e.g. "cas: unhealthy for 2m0s".
+const TriggeredByAnnotation = "debugmode.k8s.cloudogu.com/triggered-by"
+
+// notifications remembers what has been notified about the running sessions, so repeated reconciles do not repeat
+// notifications. It is kept in memory, because it only prevents duplicates.
+type notifications struct {
+	notifier notifier
+	mutex    sync.Mutex
+	// deactivations contains the last notified deactivate timestamp of each running session.
+	deactivations map[types.UID]time.Time
+	// failures contains the last notified error of each running session.
+	failures map[types.UID]string
+}
+
+// SetNotifier sets the notifier informed about the activation, extension, failure and completion of debug modes.
+// Nothing is notified if it is not set.
+func (r *DebugModeReconciler) SetNotifier(n notifier) {
+	r.notifications = &notifications{
+		notifier:      n,
+		deactivations: map[types.UID]time.Time{},
+		failures:      map[types.UID]string{},
+	}
+}
+
+// notifyActivated notifies that all log levels of the debug mode have been set.
+func (r *DebugModeReconciler) notifyActivated(ctx context.Context, cr *k8sCRLib.DebugMode, stateMap *StateMap) {
+	if r.notifications == nil {
+		return
+	}
+	notification := newNotification(notify.EventActivated, cr)
+	notification.Changes = auditChangesOf(stateMap, cr.Spec.TargetLogLevel)
+	r.notifications.notifier.Notify(ctx, notification)
+}
+
+// notifyExtended notifies a changed deactivate timestamp of an active debug mode. The first timestamp seen of a
+// session is only remembered.
+func (r *DebugModeReconciler) notifyExtended(ctx context.Context, cr *k8sCRLib.DebugMode) {
+	if r.notifications == nil || cr == nil || cr.DeletionTimestamp != nil || !r.isActive(cr) {
+		return
+	}
+	n := r.notifications
+	n.mutex.Lock()
+	previous, found := n.deactivations[cr.UID]
+	n.deactivations[cr.UID] = cr.Spec.DeactivateTimestamp.Time
+	n.mutex.Unlock()
+	if !found || previous.Equal(cr.Spec.DeactivateTimestamp.Time) {
+		return
+	}
+
+	notification := newNotification(notify.EventExtended, cr)
+	notification.PreviousDeactivateTimestamp = &previous
+	n.notifier.Notify(ctx, notification)
+}
+
+// notifyFailure notifies a failed reconcile unless the session has already failed with the same error.
+func (r *DebugModeReconciler) notifyFailure(ctx context.Context, cr *k8sCRLib.DebugMode, failure error) {
+	if r.notifications == nil || cr == nil {
+		return
+	}
+	n := r.notifications
+	n.mutex.Lock()
+	repeated := n.failures[cr.UID] == failure.Error()
+	n.failures[cr.UID] = failure.Error()
+	n.mutex.Unlock()
+	if repeated {
+		return
+	}
+
+	notification := newNotification(notify.EventFailed, cr)
+	notification.Message = failure.Error()
+	n.notifier.Notify(ctx, notification)
+}
+
+// notifyCompleted notifies the end of the session with the elements it had changed.
+func (r *DebugModeReconciler) notifyCompleted(ctx context.Context, notification notify.Notification, result audit.Result, message string, changes []audit.Change) {
+	if r.notifications == nil {
+		return
+	}
+	n := r.notifications
+	n.mutex.Lock()
+	delete(n.deactivations, types.UID(notification.UID))
+	delete(n.failures, types.UID(notification.UID))
+	n.mutex.Unlock()
+
+	notification.Event = notify.EventCompleted
+	notification.Result = result
+	notification.Message = message
+	notification.Changes = changes
+	n.notifier.Notify(ctx, notification)
+}
+
+// newNotification describes the event of the given DebugMode.
+func newNotification(event notify.Event, cr *k8sCRLib.DebugMode) notify.Notification {
+	return notify.Notification{
+		Event:               event,
+		Time:                timeNow(),
+		Namespace:           cr.Namespace,
+		Name:                cr.Name,
+		UID:                 string(cr.UID),
+		TargetLogLevel:      cr.Spec.TargetLogLevel,
+		DeactivateTimestamp: cr.Spec.DeactivateTimestamp.Time,
+		RequestedBy:         audit.RequestedBy(cr),
+		TriggeredBy:         cr.Annotations[TriggeredByAnnotation],
+	}
+}
+
+// notifiedChanges returns the changes of the session for its completion notification. They are only collected if
+// notifications are sent, because the state map is read for it.
+func (r *DebugModeReconciler) notifiedChanges(stateMap *StateMap, cr *k8sCRLib.DebugMode) []audit.Change {
+	if r.notifications == nil {
+		return nil
+	}
+	target := ""
+	if cr != nil {
+		target = cr.Spec.TargetLogLevel
+	}
+	return auditChangesOf(stateMap, target)
+}
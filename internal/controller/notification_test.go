@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func Test_DebugModeReconciler_notify(t *testing.T) {
+	fixTimeNow(t)
+	now := timeNow()
+	// the deactivate timestamp must lie in the future, because extensions are only notified for active debug modes
+	deactivate := time.Now().Add(time.Hour).Truncate(time.Second)
+	cr := &k8sCRLib.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "debug-mode",
+			Namespace:   "ecosystem",
+			UID:         testDebugModeUID,
+			Annotations: map[string]string{TriggeredByAnnotation: "cas: unhealthy for 2m0s"},
+		},
+		Spec: k8sCRLib.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(deactivate), TargetLogLevel: "DEBUG"},
+	}
+	expected := func(event notify.Event) notify.Notification {
+		return notify.Notification{
+			Event:               event,
+			Time:                now,
+			Namespace:           "ecosystem",
+			Name:                "debug-mode",
+			UID:                 string(testDebugModeUID),
+			TargetLogLevel:      "DEBUG",
+			DeactivateTimestamp: deactivate,
+			TriggeredBy:         "cas: unhealthy for 2m0s",
+		}
+	}
+	stateMap := func(t *testing.T) *StateMap {
+		return &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{"dogu.cas": testStateEntry(t, "INFO")}}}
+	}
+	changes := []audit.Change{{Kind: "dogu", Name: "cas", Original: "INFO", Target: "DEBUG"}}
+
+	t.Run("should do nothing without notifier", func(t *testing.T) {
+		dmc := &DebugModeReconciler{}
+
+		dmc.notifyActivated(t.Context(), cr, stateMap(t))
+		dmc.notifyExtended(t.Context(), cr)
+		dmc.notifyFailure(t.Context(), cr, assert.AnError)
+		dmc.notifyCompleted(t.Context(), newNotification(notify.EventCompleted, cr), audit.ResultCompleted, "", nil)
+		assert.Nil(t, dmc.notifiedChanges(stateMap(t), cr))
+	})
+	t.Run("should notify activation with the changes", func(t *testing.T) {
+		// given
+		notifier := newMockNotifier(t)
+		dmc := &DebugModeReconciler{}
+		dmc.SetNotifier(notifier)
+		notification := expected(notify.EventActivated)
+		notification.Changes = changes
+		notifier.EXPECT().Notify(t.Context(), notification).Return()
+
+		// when
+		dmc.notifyActivated(t.Context(), cr, stateMap(t))
+	})
+	t.Run("should notify only changed deactivate timestamps", func(t *testing.T) {
+		// given
+		notifier := newMockNotifier(t)
+		dmc := &DebugModeReconciler{}
+		dmc.SetNotifier(notifier)
+		extended := cr.DeepCopy()
+		extended.Spec.DeactivateTimestamp = metav1.NewTime(deactivate.Add(time.Hour))
+		notification := expected(notify.EventExtended)
+		notification.DeactivateTimestamp = deactivate.Add(time.Hour)
+		notification.PreviousDeactivateTimestamp = &deactivate
+		notifier.EXPECT().Notify(t.Context(), notification).Return().Once()
+
+		// when
+		dmc.notifyExtended(t.Context(), cr)
+		dmc.notifyExtended(t.Context(), cr)
+		dmc.notifyExtended(t.Context(), extended)
+		dmc.notifyExtended(t.Context(), extended)
+	})
+	t.Run("should not notify extension of inactive debug mode", func(t *testing.T) {
+		// given
+		dmc := &DebugModeReconciler{}
+		dmc.SetNotifier(newMockNotifier(t))
+		expired := cr.DeepCopy()
+		expired.Spec.DeactivateTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		deleted := cr.DeepCopy()
+		deleted.DeletionTimestamp = &metav1.Time{}
+
+		// when
+		dmc.notifyExtended(t.Context(), expired)
+		dmc.notifyExtended(t.Context(), deleted)
+		dmc.notifyExtended(t.Context(), nil)
+
+		// then
+		assert.Empty(t, dmc.notifications.deactivations)
+	})
+	t.Run("should notify repeated failures once", func(t *testing.T) {
+		// given
+		notifier := newMockNotifier(t)
+		dmc := &DebugModeReconciler{}
+		dmc.SetNotifier(notifier)
+		failure := expected(notify.EventFailed)
+		failure.Message = assert.AnError.Error()
+		notifier.EXPECT().Notify(t.Context(), failure).Return().Once()
+		otherFailure := expected(notify.EventFailed)
+		otherFailure.Message = "other error"
+		notifier.EXPECT().Notify(t.Context(), otherFailure).Return().Once()
+
+		// when
+		dmc.notifyFailure(t.Context(), cr, assert.AnError)
+		dmc.notifyFailure(t.Context(), cr, assert.AnError)
+		dmc.notifyFailure(t.Context(), nil, assert.AnError)
+		dmc.notifyFailure(t.Context(), cr, errors.New("other error"))
+	})
+	t.Run("should notify completion and forget the session", func(t *testing.T) {
+		// given
+		notifier := newMockNotifier(t)
+		dmc := &DebugModeReconciler{}
+		dmc.SetNotifier(notifier)
+		notifier.EXPECT().Notify(t.Context(), mock.MatchedBy(func(n notify.Notification) bool {
+			return n.Event == notify.EventFailed
+		})).Return().Twice()
+		completion := expected(notify.EventCompleted)
+		completion.Result = audit.ResultDeleted
+		completion.Message = "Debug-Mode deactivated"
+		completion.Changes = changes
+		notifier.EXPECT().Notify(t.Context(), completion).Return().Once()
+
+		// when
+		dmc.notifyExtended(t.Context(), cr)
+		dmc.notifyFailure(t.Context(), cr, assert.AnError)
+		dmc.notifyCompleted(t.Context(), newNotification(notify.EventCompleted, cr), audit.ResultDeleted, "Debug-Mode deactivated",
+			dmc.notifiedChanges(stateMap(t), cr))
+		dmc.notifyFailure(t.Context(), cr, assert.AnError)
+
+		// then
+		assert.NotContains(t, dmc.notifications.deactivations, cr.UID)
+	})
+	t.Run("should collect changes without debug mode", func(t *testing.T) {
+		dmc := &DebugModeReconciler{}
+		dmc.SetNotifier(newMockNotifier(t))
+
+		assert.Equal(t, []audit.Change{{Kind: "dogu", Name: "cas", Original: "INFO"}}, dmc.notifiedChanges(stateMap(t), nil))
+	})
+}
+
+func Test_DebugModeReconciler_Reconcile_notify(t *testing.T) {
+	ctx := t.Context()
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ecosystem", Name: "debug-mode"}}
+
+	t.Run("should notify failed reconcile", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		notifier := newMockNotifier(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), configMapClient, NewMockLogLevelHandler(t))
+		dmc.SetNotifier(notifier)
+
+		active := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, UID: testDebugModeUID, Finalizers: []string{debugModeFinalizer}},
+			Spec:       k8sCRLib.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(time.Now().Add(time.Hour)), TargetLogLevel: "debug"},
+		}
+		debugModeClient.EXPECT().Get(mock.Anything, request.Name, metav1.GetOptions{}).Return(active, nil)
+		configMapClient.EXPECT().Get(mock.Anything, testStateMapName, metav1.GetOptions{}).Return(&corev1.ConfigMap{}, nil)
+		debugModeClient.EXPECT().UpdateStatusDebugModeSet(mock.Anything, active).Return(nil, assert.AnError)
+		debugModeClient.EXPECT().UpdateStatusFailed(mock.Anything, active).Return(active, nil)
+		notifier.EXPECT().Notify(mock.Anything, mock.MatchedBy(func(n notify.Notification) bool {
+			return n.Event == notify.EventFailed && n.UID == string(testDebugModeUID) && n.Message != ""
+		})).Return().Once()
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.Error(t, err)
+	})
+	t.Run("should notify completion of force deleted debug mode", func(t *testing.T) {
+		// given
+		debugModeClient := newMockDebugModeInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		notifier := newMockNotifier(t)
+		dmc := NewDebugModeReconciler(debugModeClient, newMockDoguInterface(t), configMapClient, NewMockLogLevelHandler(t))
+		dmc.SetNotifier(notifier)
+
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              request.Name,
+				UID:               testDebugModeUID,
+				Finalizers:        []string{debugModeFinalizer},
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+				Annotations:       map[string]string{forceDeleteAnnotation: "true"},
+			},
+			Spec: k8sCRLib.DebugModeSpec{TargetLogLevel: "DEBUG"},
+		}
+		debugModeClient.EXPECT().Get(ctx, request.Name, metav1.GetOptions{}).Return(cr, nil)
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: testStateMapName, UID: testStateMapUID},
+			Data:       map[string]string{"dogu.doguA": testStateEntry(t, "INFO")},
+		}
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
+		configMapClient.EXPECT().Delete(ctx, testStateMapName, testStateMapDeleteOptions).Return(nil)
+		debugModeClient.EXPECT().RemoveFinalizer(ctx, cr, debugModeFinalizer).Return(cr, nil)
+		notifier.EXPECT().Notify(ctx, mock.MatchedBy(func(n notify.Notification) bool {
+			return n.Event == notify.EventCompleted && n.Result == audit.ResultForceDeleted &&
+				assert.ObjectsAreEqual([]audit.Change{{Kind: "dogu", Name: "doguA", Original: "INFO", Target: "DEBUG"}}, n.Changes)
+		})).Return().Once()
+
+		// when
+		_, err := dmc.Reconcile(ctx, request)
+
+		// then
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"go.uber.org/zap/zapcore"
+)
+
+// zapTraceLevel enables the most verbose output of the operator, e.g. V(2) of the controller-runtime.
+const zapTraceLevel = zapcore.DebugLevel - 1
+
+// SetOperatorLogLevel sets the log level of the operator, which is raised to the target log level while a debug mode is active.
+func (r *DebugModeReconciler) SetOperatorLogLevel(level operatorLogLevel) {
+	r.operatorLogLevel = level
+}
+
+// raiseOperatorLogLevel lets the operator log at least as verbose as the dogus during the debug mode.
+func (r *DebugModeReconciler) raiseOperatorLogLevel(level loglevel.LogLevel) {
+	if r.operatorLogLevel == nil {
+		return
+	}
+	r.operatorLogLevel.Raise(zapLevel(level))
+}
+
+// resetOperatorLogLevel restores the configured log level of the operator.
+func (r *DebugModeReconciler) resetOperatorLogLevel() {
+	if r.operatorLogLevel == nil {
+		return
+	}
+	r.operatorLogLevel.Reset()
+}
+
+// zapLevel returns the level of the operator log matching the given log level.
+func zapLevel(level loglevel.LogLevel) zapcore.Level {
+	switch level {
+	case loglevel.LevelTrace:
+		return zapTraceLevel
+	case loglevel.LevelDebug:
+		return zapcore.DebugLevel
+	case loglevel.LevelWarn:
+		return zapcore.WarnLevel
+	case loglevel.LevelError:
+		return zapcore.ErrorLevel
+	case loglevel.LevelFatal, loglevel.LevelOff:
+		return zapcore.FatalLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
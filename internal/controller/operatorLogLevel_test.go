@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func Test_zapLevel(t *testing.T) {
+	assert.Equal(t, zapcore.Level(-2), zapLevel(loglevel.LevelTrace))
+	assert.Equal(t, zapcore.DebugLevel, zapLevel(loglevel.LevelDebug))
+	assert.Equal(t, zapcore.InfoLevel, zapLevel(loglevel.LevelInfo))
+	assert.Equal(t, zapcore.WarnLevel, zapLevel(loglevel.LevelWarn))
+	assert.Equal(t, zapcore.ErrorLevel, zapLevel(loglevel.LevelError))
+	assert.Equal(t, zapcore.FatalLevel, zapLevel(loglevel.LevelFatal))
+	assert.Equal(t, zapcore.FatalLevel, zapLevel(loglevel.LevelOff))
+}
+
+func Test_DebugModeReconciler_operatorLogLevel(t *testing.T) {
+	t.Run("should raise and reset operator log level", func(t *testing.T) {
+		// given
+		level := newMockOperatorLogLevel(t)
+		level.EXPECT().Raise(zapcore.DebugLevel).Return()
+		level.EXPECT().Reset().Return()
+		dmc := &DebugModeReconciler{}
+		dmc.SetOperatorLogLevel(level)
+
+		// when
+		dmc.raiseOperatorLogLevel(loglevel.LevelDebug)
+		dmc.resetOperatorLogLevel()
+	})
+	t.Run("should ignore unset operator log level", func(t *testing.T) {
+		dmc := &DebugModeReconciler{}
+
+		assert.NotPanics(t, func() {
+			dmc.raiseOperatorLogLevel(loglevel.LevelDebug)
+			dmc.resetOperatorLogLevel()
+		})
+	})
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+)
+
+// AddedDoguPolicy defines how the rollback treats dogus that were installed while the debug mode was active
+// and therefore have no stored original log level.
+type AddedDoguPolicy string
+
+const (
+	// AddedDoguPolicyKeep leaves the log level of added dogus as it is.
+	AddedDoguPolicyKeep AddedDoguPolicy = "keep"
+	// AddedDoguPolicyRestoreDefault removes the log level of added dogus, so they use the default of their descriptor.
+	AddedDoguPolicyRestoreDefault AddedDoguPolicy = "restore-default"
+	// AddedDoguPolicyTargeted treats added dogus as if the debug mode had changed them: a log level equal to the
+	// target log level is removed, any other log level is kept.
+	AddedDoguPolicyTargeted AddedDoguPolicy = "targeted"
+
+	// addedDoguPolicyAnnotation overrides the operator wide policy for a single DebugMode.
+	addedDoguPolicyAnnotation = "debugmode.k8s.cloudogu.com/added-dogu-policy"
+)
+
+// ParseAddedDoguPolicy converts the given string into a policy.
+func ParseAddedDoguPolicy(value string) (AddedDoguPolicy, error) {
+	policy := AddedDoguPolicy(strings.ToLower(strings.TrimSpace(value)))
+	switch policy {
+	case AddedDoguPolicyKeep, AddedDoguPolicyRestoreDefault, AddedDoguPolicyTargeted:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown added dogu policy %q, expected one of %s, %s, %s", value, AddedDoguPolicyKeep, AddedDoguPolicyRestoreDefault, AddedDoguPolicyTargeted)
+	}
+}
+
+// rollback holds the settings of a rollback pass over all dogus and records everything that could not be
+// restored as stored.
+type rollback struct {
+	stateMap        *StateMap
+	sensitiveState  *SensitiveState
+	addedDoguPolicy AddedDoguPolicy
+	targetLogLevel  loglevel.LogLevel
+	logger          logging.Logger
+	// raiseOnly is true if the debug mode left dogus that were more verbose than the target log level unchanged.
+	raiseOnly bool
+	// dogus contains the names of the dogus selected by the debug mode. All dogus are selected if it is empty.
+	dogus []string
+
+	// vanished contains the names of dogus with a stored log level that are not installed anymore.
+	vanished []string
+	// added contains the names of dogus without a stored log level.
+	added []string
+	// fallback contains the names of dogus whose stored log level is not supported by their current version.
+	fallback []string
+	// vanishedComponents contains the names of components with a stored log level whose deployment has been deleted.
+	vanishedComponents []string
+}
+
+// untouched returns true if an element without a stored log level has been left unchanged by a raise-only
+// debug mode. Such an element is more verbose than the target log level, which the debug mode never sets.
+func (rb *rollback) untouched(state loglevel.LogLevelState) bool {
+	return KeepsMoreVerbose(rb.raiseOnly, state.Level, rb.targetLogLevel)
+}
+
+// summary returns a human-readable description of all recorded deviations or an empty string if there are none.
+func (rb *rollback) summary() string {
+	var parts []string
+	if len(rb.vanished) > 0 {
+		parts = append(parts, fmt.Sprintf("skipped uninstalled dogus: %s", strings.Join(rb.vanished, ", ")))
+	}
+	if len(rb.vanishedComponents) > 0 {
+		parts = append(parts, fmt.Sprintf("skipped deleted components: %s", strings.Join(rb.vanishedComponents, ", ")))
+	}
+	if len(rb.added) > 0 {
+		parts = append(parts, fmt.Sprintf("dogus added during debug mode (%s): %s", rb.addedDoguPolicy, strings.Join(rb.added, ", ")))
+	}
+	if len(rb.fallback) > 0 {
+		parts = append(parts, fmt.Sprintf("reset to default because stored level is unsupported: %s", strings.Join(rb.fallback, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// recordVanished records all dogus with a stored log level that are not part of the installed dogus.
+func (rb *rollback) recordVanished(kind string, installed []string) {
+	prefix := kind + "."
+	for key := range rb.stateMap.configMap.Data {
+		name, found := strings.CutPrefix(key, prefix)
+		if found && !slices.Contains(installed, name) {
+			rb.vanished = append(rb.vanished, name)
+		}
+	}
+	slices.Sort(rb.vanished)
+	rb.vanished = slices.Compact(rb.vanished)
+}
+
+// addedDoguPolicyFor returns the policy for dogus added during the given debug mode.
+// An invalid annotation is ignored, so the rollback is never blocked by it.
+func (r *DebugModeReconciler) addedDoguPolicyFor(cr *k8sCRLib.DebugMode, logger logging.Logger) AddedDoguPolicy {
+	if cr == nil {
+		return r.addedDoguPolicy
+	}
+	value, found := cr.Annotations[addedDoguPolicyAnnotation]
+	if !found {
+		return r.addedDoguPolicy
+	}
+	policy, err := ParseAddedDoguPolicy(value)
+	if err != nil {
+		logger.Error("ERROR: ignore annotation", "annotation", addedDoguPolicyAnnotation, "error", err)
+		return r.addedDoguPolicy
+	}
+	return policy
+}
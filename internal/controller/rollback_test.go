@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"testing"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseAddedDoguPolicy(t *testing.T) {
+	t.Run("should parse all policies", func(t *testing.T) {
+		for _, policy := range []AddedDoguPolicy{AddedDoguPolicyKeep, AddedDoguPolicyRestoreDefault, AddedDoguPolicyTargeted} {
+			// when
+			actual, err := ParseAddedDoguPolicy(" " + string(policy))
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, policy, actual)
+		}
+	})
+	t.Run("should ignore case", func(t *testing.T) {
+		// when
+		actual, err := ParseAddedDoguPolicy("Restore-Default")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, AddedDoguPolicyRestoreDefault, actual)
+	})
+	t.Run("should fail on unknown policy", func(t *testing.T) {
+		// when
+		_, err := ParseAddedDoguPolicy("ignore")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown added dogu policy \"ignore\"")
+	})
+}
+
+func Test_rollback_summary(t *testing.T) {
+	t.Run("should be empty without deviations", func(t *testing.T) {
+		assert.Empty(t, (&rollback{}).summary())
+	})
+	t.Run("should describe all deviations", func(t *testing.T) {
+		// given
+		rb := &rollback{
+			addedDoguPolicy: AddedDoguPolicyKeep,
+			vanished:        []string{"jenkins", "nexus"},
+			added:           []string{"redmine"},
+			fallback:        []string{"scm"},
+		}
+
+		// when
+		summary := rb.summary()
+
+		// then
+		assert.Equal(t, "skipped uninstalled dogus: jenkins, nexus; dogus added during debug mode (keep): redmine; reset to default because stored level is unsupported: scm", summary)
+	})
+}
+
+func Test_rollback_recordVanished(t *testing.T) {
+	// given
+	rb := &rollback{stateMap: &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{
+		"dogu.cas":       "WARN",
+		"dogu.nexus":     "INFO",
+		"dogu.jenkins":   "INFO",
+		"component.ldap": "INFO",
+	}}}}
+
+	// when
+	rb.recordVanished("dogu", []string{"cas", "redmine"})
+
+	// then
+	assert.Equal(t, []string{"jenkins", "nexus"}, rb.vanished)
+}
+
+func Test_DebugModeReconciler_addedDoguPolicyFor(t *testing.T) {
+	logger := logging.FromContext(t.Context())
+	dmc := &DebugModeReconciler{addedDoguPolicy: AddedDoguPolicyRestoreDefault}
+
+	t.Run("should use operator policy without cr", func(t *testing.T) {
+		assert.Equal(t, AddedDoguPolicyRestoreDefault, dmc.addedDoguPolicyFor(nil, logger))
+	})
+	t.Run("should use operator policy without annotation", func(t *testing.T) {
+		assert.Equal(t, AddedDoguPolicyRestoreDefault, dmc.addedDoguPolicyFor(&k8sCRLib.DebugMode{}, logger))
+	})
+	t.Run("should use policy of annotation", func(t *testing.T) {
+		// given
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{addedDoguPolicyAnnotation: "targeted"}}}
+
+		// when
+		policy := dmc.addedDoguPolicyFor(cr, logger)
+
+		// then
+		assert.Equal(t, AddedDoguPolicyTargeted, policy)
+	})
+	t.Run("should ignore invalid annotation", func(t *testing.T) {
+		// given
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{addedDoguPolicyAnnotation: "invalid"}}}
+
+		// when
+		policy := dmc.addedDoguPolicyFor(cr, logger)
+
+		// then
+		assert.Equal(t, AddedDoguPolicyRestoreDefault, policy)
+	})
+}
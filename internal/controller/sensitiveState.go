@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// stateStore holds the original state of elements captured before the debug mode changed them.
+type stateStore interface {
+	getEntry(key string) (StateEntry, bool, error)
+	storeEntries(ctx context.Context, entries map[string]StateEntry) error
+}
+
+// SensitiveState stores the original values of sensitive dogu config keys in a Secret with the name of the state map,
+// so they are never written to a ConfigMap in plain text. A nil SensitiveState is empty.
+type SensitiveState struct {
+	debugCR         *k8sCRLib.DebugMode
+	secretInterface secretInterface
+	logger          logging.Logger
+	name            string
+	// secret is nil as long as the state has not been persisted.
+	secret *corev1.Secret
+}
+
+// loadSensitiveState loads the sensitive state that belongs to the given state map.
+// The Secret is only created once the first entry is stored.
+func loadSensitiveState(ctx context.Context, secrets secretInterface, stateMap *StateMap, logger logging.Logger) (*SensitiveState, error) {
+	state := &SensitiveState{
+		debugCR:         stateMap.debugCR,
+		secretInterface: secrets,
+		logger:          logger,
+		name:            stateMap.configMap.Name,
+	}
+
+	secret, err := secrets.Get(ctx, state.name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to get sensitive state %s: %w", state.name, err)
+	}
+	state.secret = secret
+	return state, nil
+}
+
+func (s *SensitiveState) isEmpty() bool {
+	return s == nil || s.secret == nil || len(s.secret.Data) == 0
+}
+
+func (s *SensitiveState) getEntry(key string) (StateEntry, bool, error) {
+	if s.isEmpty() {
+		return StateEntry{}, false, nil
+	}
+
+	raw, found := s.secret.Data[key]
+	if !found {
+		return StateEntry{}, false, nil
+	}
+
+	entry, err := ParseStateEntry(string(raw))
+	if err != nil {
+		return StateEntry{}, true, fmt.Errorf("invalid state entry %s in sensitive state %s: %w", key, s.name, err)
+	}
+	return entry, true, nil
+}
+
+// storeEntries writes all given entries with a single update and creates the Secret if necessary. Like in the state
+// map, an entry another writer has changed since the Secret was read is kept and errStateChangedConcurrently is
+// returned.
+func (s *SensitiveState) storeEntries(ctx context.Context, entries map[string]StateEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	data := make(map[string][]byte, len(entries))
+	for key, entry := range entries {
+		value, err := entry.marshal()
+		if err != nil {
+			return fmt.Errorf("failed to store state entry %s: %w", key, err)
+		}
+		data[key] = []byte(value)
+	}
+
+	if s.secret == nil {
+		return s.create(ctx, data)
+	}
+
+	base := s.secret
+	latest := s.secret
+	var kept []string
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if latest == nil {
+			var getErr error
+			latest, getErr = s.secretInterface.Get(ctx, s.name, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+		}
+
+		updated := latest.DeepCopy()
+		if updated.Data == nil {
+			updated.Data = map[string][]byte{}
+		}
+		kept = nil
+		for key, value := range data {
+			current, exists := updated.Data[key]
+			if exists && !bytes.Equal(current, base.Data[key]) && !bytes.Equal(current, value) {
+				kept = append(kept, key)
+				continue
+			}
+			updated.Data[key] = value
+		}
+
+		secret, updateErr := s.secretInterface.Update(ctx, updated, metav1.UpdateOptions{})
+		if updateErr != nil {
+			// force a re-read of the latest version on the next attempt
+			latest = nil
+			return updateErr
+		}
+		s.secret = secret
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update sensitive state %s: %w", s.name, err)
+	}
+	if len(kept) > 0 {
+		slices.Sort(kept)
+		return fmt.Errorf("%w in sensitive state %s: %s", errStateChangedConcurrently, s.name, strings.Join(kept, ", "))
+	}
+	return nil
+}
+
+func (s *SensitiveState) create(ctx context.Context, data map[string][]byte) error {
+	if s.debugCR == nil {
+		return fmt.Errorf("failed to create sensitive state %s: debug mode is deleted", s.name)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.name,
+			Namespace: s.debugCR.Namespace,
+			Labels: map[string]string{
+				stateMapOwnerLabel:    s.debugCR.Name,
+				stateMapOwnerUIDLabel: string(s.debugCR.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(s.debugCR, k8sCRLib.GroupVersion.WithKind("DebugMode")),
+			},
+			Finalizers: []string{stateMapFinalizer},
+		},
+		Data: data,
+	}
+
+	created, err := s.secretInterface.Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create sensitive state %s: %w", s.name, err)
+	}
+	s.secret = created
+	return nil
+}
+
+// Destroy removes the finalizer from the Secret and deletes it.
+func (s *SensitiveState) Destroy(ctx context.Context) error {
+	if s == nil || s.secret == nil {
+		return nil
+	}
+
+	secret := s.secret.DeepCopy()
+	if controllerutil.RemoveFinalizer(secret, stateMapFinalizer) {
+		var err error
+		secret, err = s.secretInterface.Update(ctx, secret, metav1.UpdateOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to remove finalizer from sensitive state %s: %w", s.name, err)
+		}
+	}
+
+	err := s.secretInterface.Delete(ctx, s.name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &secret.UID}})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete sensitive state %s: %w", s.name, err)
+	}
+	s.secret = nil
+	return nil
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_loadSensitiveState(t *testing.T) {
+	ctx := t.Context()
+	stateMap := &StateMap{configMap: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testStateMapName}}}
+
+	t.Run("should return empty state if secret does not exist", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		secrets.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, testStateMapName))
+
+		// when
+		state, err := loadSensitiveState(ctx, secrets, stateMap, logging.FromContext(ctx))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, state.isEmpty())
+	})
+	t.Run("should load entries of existing secret", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		entry := newConfigStateEntry(map[string]loglevel.ConfigValueState{"sa-postgresql/password": {Value: "secret"}}, "")
+		raw, err := entry.marshal()
+		require.NoError(t, err)
+		secrets := newMockSecretInterface(t)
+		secrets.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(&corev1.Secret{Data: map[string][]byte{"doguconfig.redmine": []byte(raw)}}, nil)
+
+		// when
+		state, err := loadSensitiveState(ctx, secrets, stateMap, logging.FromContext(ctx))
+
+		// then
+		require.NoError(t, err)
+		actual, found, err := state.getEntry("doguconfig.redmine")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, entry, actual)
+	})
+	t.Run("error getting secret", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		secrets.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		// when
+		_, err := loadSensitiveState(ctx, secrets, stateMap, logging.FromContext(ctx))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_SensitiveState_storeEntries(t *testing.T) {
+	ctx := t.Context()
+	cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: "debug-mode", Namespace: "ecosystem", UID: testDebugModeUID}}
+	entries := func(t *testing.T) map[string]StateEntry {
+		fixTimeNow(t)
+		return map[string]StateEntry{"doguconfig.redmine": newConfigStateEntry(map[string]loglevel.ConfigValueState{"sa-postgresql/password": {Value: "secret"}}, "")}
+	}
+
+	t.Run("should create protected secret owned by the debug mode", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		secrets.EXPECT().Create(ctx, mock.MatchedBy(func(secret *corev1.Secret) bool {
+			return secret.Name == testStateMapName &&
+				secret.Labels[stateMapOwnerUIDLabel] == string(testDebugModeUID) &&
+				len(secret.OwnerReferences) == 1 && secret.OwnerReferences[0].UID == testDebugModeUID &&
+				secret.Finalizers[0] == stateMapFinalizer &&
+				len(secret.Data["doguconfig.redmine"]) > 0
+		}), metav1.CreateOptions{}).Return(&corev1.Secret{}, nil)
+		state := &SensitiveState{debugCR: cr, secretInterface: secrets, name: testStateMapName}
+
+		// when
+		err := state.storeEntries(ctx, entries(t))
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, state.secret)
+	})
+	t.Run("should update existing secret", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		existing := &corev1.Secret{Data: map[string][]byte{"doguconfig.cas": []byte("{}")}}
+		secrets.EXPECT().Update(ctx, mock.MatchedBy(func(secret *corev1.Secret) bool {
+			return len(secret.Data) == 2
+		}), metav1.UpdateOptions{}).Return(&corev1.Secret{}, nil)
+		state := &SensitiveState{debugCR: cr, secretInterface: secrets, name: testStateMapName, secret: existing}
+
+		// when
+		err := state.storeEntries(ctx, entries(t))
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, existing.Data, 1, "the loaded secret must not be changed")
+	})
+	t.Run("should keep entries another writer has captured on conflict", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		stale := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "1"}, Data: map[string][]byte{}}
+		conflict := apierrors.NewConflict(schema.GroupResource{Resource: "secrets"}, testStateMapName, assert.AnError)
+		secrets.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, conflict).Once()
+		latest := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "2"}, Data: map[string][]byte{"doguconfig.redmine": []byte("captured")}}
+		secrets.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(latest, nil).Once()
+		secrets.EXPECT().Update(ctx, mock.MatchedBy(func(secret *corev1.Secret) bool {
+			return string(secret.Data["doguconfig.redmine"]) == "captured"
+		}), metav1.UpdateOptions{}).RunAndReturn(func(_ context.Context, secret *corev1.Secret, _ metav1.UpdateOptions) (*corev1.Secret, error) {
+			return secret, nil
+		}).Once()
+		state := &SensitiveState{debugCR: cr, secretInterface: secrets, name: testStateMapName, secret: stale}
+
+		// when
+		err := state.storeEntries(ctx, entries(t))
+
+		// then
+		require.ErrorIs(t, err, errStateChangedConcurrently)
+		assert.ErrorContains(t, err, "doguconfig.redmine")
+	})
+	t.Run("should not create secret for deleted debug mode", func(t *testing.T) {
+		// given
+		state := &SensitiveState{secretInterface: newMockSecretInterface(t), name: testStateMapName}
+
+		// when
+		err := state.storeEntries(ctx, entries(t))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "debug mode is deleted")
+	})
+}
+
+func Test_SensitiveState_Destroy(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("should do nothing for nil or not persisted state", func(t *testing.T) {
+		require.NoError(t, (*SensitiveState)(nil).Destroy(ctx))
+		require.NoError(t, (&SensitiveState{}).Destroy(ctx))
+	})
+	t.Run("should remove finalizer and delete secret", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testStateMapName, UID: "secret-uid", Finalizers: []string{stateMapFinalizer}}}
+		secrets.EXPECT().Update(ctx, mock.MatchedBy(func(secret *corev1.Secret) bool {
+			return len(secret.Finalizers) == 0
+		}), metav1.UpdateOptions{}).Return(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{UID: "secret-uid"}}, nil)
+		uid := secret.UID
+		secrets.EXPECT().Delete(ctx, testStateMapName, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}).Return(nil)
+		state := &SensitiveState{secretInterface: secrets, name: testStateMapName, secret: secret}
+
+		// when
+		err := state.Destroy(ctx)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, state.isEmpty())
+	})
+	t.Run("error deleting secret", func(t *testing.T) {
+		// given
+		secrets := newMockSecretInterface(t)
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testStateMapName, UID: "secret-uid"}}
+		secrets.EXPECT().Delete(ctx, testStateMapName, mock.Anything).Return(assert.AnError)
+		state := &SensitiveState{secretInterface: secrets, name: testStateMapName, secret: secret}
+
+		// when
+		err := state.Destroy(ctx)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	typev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	recoveryAction              = "Recover"
+	recoveryReasonRestored      = "LogLevelsRestored"
+	recoveryReasonRestoreFailed = "LogLevelRestoreFailed"
+)
+
+var defaultRecoveryBackoff = wait.Backoff{
+	Duration: 10 * time.Second,
+	Factor:   2,
+	Steps:    5,
+}
+
+// StartupRecovery restores the log levels stored in state maps that outlived their DebugMode,
+// e.g. because the DebugMode has been deleted while the operator was not running.
+// It runs once after the manager has been started.
+type StartupRecovery struct {
+	reconcilers reconcilerProvider
+	// stateMaps list the state maps to recover, e.g. one client per watched namespace or one for all namespaces.
+	stateMaps     []typev1.ConfigMapInterface
+	eventRecorder eventRecorder
+	backoff       wait.Backoff
+}
+
+// NewStartupRecovery creates a recovery of the state maps in the namespace of the given reconciler.
+func NewStartupRecovery(reconciler *DebugModeReconciler, eventRecorder eventRecorder) *StartupRecovery {
+	return NewNamespacedStartupRecovery(singleReconciler{reconciler}, []typev1.ConfigMapInterface{reconciler.configMapInterface}, eventRecorder)
+}
+
+// NewNamespacedStartupRecovery creates a recovery of the state maps listed by the given clients. Every state map is
+// restored by the reconciler of its namespace.
+func NewNamespacedStartupRecovery(reconcilers reconcilerProvider, stateMaps []typev1.ConfigMapInterface, eventRecorder eventRecorder) *StartupRecovery {
+	return &StartupRecovery{
+		reconcilers:   reconcilers,
+		stateMaps:     stateMaps,
+		eventRecorder: eventRecorder,
+		backoff:       defaultRecoveryBackoff,
+	}
+}
+
+// singleReconciler provides the same reconciler for every namespace.
+type singleReconciler struct {
+	reconciler *DebugModeReconciler
+}
+
+func (s singleReconciler) ForNamespace(string) (*DebugModeReconciler, error) {
+	return s.reconciler, nil
+}
+
+// NeedLeaderElection makes sure only one operator instance restores log levels.
+func (s *StartupRecovery) NeedLeaderElection() bool {
+	return true
+}
+
+// Start restores all orphaned state maps. A failed recovery is reported but does not stop the manager,
+// so the state map is kept for the next start of the operator.
+func (s *StartupRecovery) Start(ctx context.Context) error {
+	logger := logging.FromContext(ctx)
+	logger.Info("Start recovery of orphaned state maps")
+
+	for _, stateMaps := range s.stateMaps {
+		list, err := stateMaps.List(ctx, metav1.ListOptions{LabelSelector: stateMapOwnerLabel})
+		if err != nil {
+			logger.Error("ERROR: failed to list state maps for recovery", "error", err)
+			continue
+		}
+
+		for i := range list.Items {
+			cm := &list.Items[i]
+			err = retry.OnError(s.backoff, func(err error) bool { return ctx.Err() == nil }, func() error {
+				return s.recoverStateMap(ctx, cm)
+			})
+			if err != nil {
+				logger.Error("ERROR: failed to recover state map", "namespace", cm.Namespace, "stateMap", cm.Name, "error", err)
+				s.eventRecorder.Eventf(cm, nil, corev1.EventTypeWarning, recoveryReasonRestoreFailed, recoveryAction,
+					"Failed to restore log levels of orphaned state map %s: %v", cm.Name, err)
+			}
+		}
+	}
+
+	logger.Info("Finished recovery of orphaned state maps")
+	return nil
+}
+
+func (s *StartupRecovery) recoverStateMap(ctx context.Context, cm *corev1.ConfigMap) error {
+	logger := logging.FromContext(ctx)
+
+	reconciler, err := s.reconcilers.ForNamespace(cm.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get reconciler of state map %s: %w", cm.Name, err)
+	}
+
+	orphaned, err := s.isOrphaned(ctx, reconciler, cm)
+	if err != nil {
+		return err
+	}
+	if !orphaned {
+		logger.Debug("State map belongs to an active debug mode - skip recovery", "stateMap", cm.Name)
+		return nil
+	}
+
+	logger.Info("Found orphaned state map", "stateMap", cm.Name, "debugMode", cm.Labels[stateMapOwnerLabel])
+	stateMap := &StateMap{
+		configMapInterface: reconciler.configMapInterface,
+		logger:             logger,
+		configMap:          cm,
+	}
+
+	sensitiveState, err := reconciler.loadSensitiveState(ctx, stateMap, logger)
+	if err != nil {
+		return err
+	}
+
+	restored, err := s.restoreDogus(ctx, reconciler, stateMap, sensitiveState, logger)
+	if err != nil {
+		return err
+	}
+
+	restoredComponents, err := reconciler.rollbackComponents(ctx, &rollback{stateMap: stateMap, targetLogLevel: loglevel.LevelUnknown, logger: logger})
+	if err != nil {
+		return err
+	}
+
+	err = sensitiveState.Destroy(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := reconciler.notifiedChanges(stateMap, nil)
+	_, err = stateMap.Destroy(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete state map %s: %w", cm.Name, err)
+	}
+
+	logger.Info("Restored log levels of orphaned state map", "stateMap", cm.Name, "dogus", restored, "components", restoredComponents)
+	note := "Restored log levels of orphaned state map %s for dogus: [%s]"
+	args := []any{cm.Name, strings.Join(restored, ", ")}
+	if len(restoredComponents) > 0 {
+		note += " and components: [%s]"
+		args = append(args, strings.Join(restoredComponents, ", "))
+	}
+	s.eventRecorder.Eventf(cm, nil, corev1.EventTypeNormal, recoveryReasonRestored, recoveryAction, note, args...)
+	for _, owner := range cm.OwnerReferences {
+		reconciler.auditEnd(ctx, owner.UID, audit.ResultRecovered, fmt.Sprintf(note, args...), logger)
+		reconciler.notifyCompleted(ctx, notify.Notification{Time: timeNow(), Namespace: cm.Namespace, Name: owner.Name, UID: string(owner.UID)},
+			audit.ResultRecovered, fmt.Sprintf(note, args...), changes)
+	}
+	return nil
+}
+
+// isOrphaned returns true if the DebugMode the state map has been created for does not exist anymore,
+// has been replaced by a new one with the same name or has already been completed.
+func (s *StartupRecovery) isOrphaned(ctx context.Context, reconciler *DebugModeReconciler, cm *corev1.ConfigMap) (bool, error) {
+	cr, err := reconciler.debugModeInterface.Get(ctx, cm.Labels[stateMapOwnerLabel], metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get owner of state map %s: %w", cm.Name, err)
+	}
+
+	ownerUID, found := cm.Labels[stateMapOwnerUIDLabel]
+	if found && types.UID(ownerUID) != cr.UID {
+		return true, nil
+	}
+	if !found && cm.CreationTimestamp.Before(&cr.CreationTimestamp) {
+		// legacy state maps carry no UID, so a state map created before the DebugMode belongs to a previous one
+		return true, nil
+	}
+
+	return reconciler.isCompleted(cr), nil
+}
+
+// restoreDogus restores the stored log level and dogu config of every dogu and returns the names of the changed dogus.
+// Dogus without a stored entry have not been changed by the debug mode and are skipped.
+func (s *StartupRecovery) restoreDogus(ctx context.Context, reconciler *DebugModeReconciler, stateMap *StateMap, sensitiveState *SensitiveState, logger logging.Logger) ([]string, error) {
+	doguList, err := reconciler.doguInterface.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dogus: %w", err)
+	}
+
+	handler := reconciler.doguLogLevelHandler
+	// without the DebugMode it is unknown which dogus were added afterward, so dogus without a stored level are kept
+	rb := &rollback{stateMap: stateMap, sensitiveState: sensitiveState, addedDoguPolicy: AddedDoguPolicyKeep, targetLogLevel: loglevel.LevelUnknown, logger: logger}
+	var restored []string
+	var errs []error
+	for _, dogu := range doguList.Items {
+		levelChanged := false
+		if _, found, _ := stateMap.getEntry(stateKey(handler.Kind(), dogu.Name)); found {
+			levelChanged, err = reconciler.deactivateDebugModeForElement(ctx, handler, dogu.Name, dogu, doguVersion(dogu), rb)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		configChanged, err := reconciler.rollbackDoguConfig(ctx, dogu, rb)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if levelChanged || configChanged {
+			restored = append(restored, dogu.Name)
+		}
+	}
+
+	slices.Sort(restored)
+	return restored, errors.Join(errs...)
+}
@@ -0,0 +1,401 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	typev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+func Test_StartupRecovery_NeedLeaderElection(t *testing.T) {
+	// when
+	recovery := NewStartupRecovery(&DebugModeReconciler{}, newMockEventRecorder(t))
+
+	// then
+	assert.True(t, recovery.NeedLeaderElection())
+}
+
+func Test_StartupRecovery_leaderElection(t *testing.T) {
+	// given
+	lease := &testLease{}
+	var recoveries atomic.Int32
+	newManager := func(t *testing.T, identity string) manager.Manager {
+		configMapClient := newMockConfigurationMap(t)
+		configMapClient.EXPECT().List(mock.Anything, mock.Anything).
+			RunAndReturn(func(context.Context, metav1.ListOptions) (*corev1.ConfigMapList, error) {
+				recoveries.Add(1)
+				return &corev1.ConfigMapList{}, nil
+			}).Maybe()
+		reconciler := NewDebugModeReconciler(newMockDebugModeInterface(t), newMockDoguInterface(t), configMapClient, NewMockLogLevelHandler(t))
+
+		leaseDuration, renewDeadline, retryPeriod := 2*time.Second, time.Second, 50*time.Millisecond
+		mgr, err := ctrl.NewManager(&rest.Config{Host: "https://127.0.0.1:1"}, manager.Options{
+			Metrics:                             server.Options{BindAddress: "0"},
+			LeaderElection:                      true,
+			LeaderElectionID:                    "k8s-debug-mode-operator-leader",
+			LeaderElectionResourceLockInterface: &testResourceLock{lease: lease, identity: identity},
+			LeaseDuration:                       &leaseDuration,
+			RenewDeadline:                       &renewDeadline,
+			RetryPeriod:                         &retryPeriod,
+		})
+		require.NoError(t, err)
+		require.NoError(t, mgr.Add(NewStartupRecovery(reconciler, newMockEventRecorder(t))))
+		return mgr
+	}
+	first := newManager(t, "replica-1")
+	second := newManager(t, "replica-2")
+
+	// when
+	ctx, cancel := context.WithCancel(t.Context())
+	var stopped sync.WaitGroup
+	for _, mgr := range []manager.Manager{first, second} {
+		stopped.Go(func() {
+			assert.NoError(t, mgr.Start(ctx))
+		})
+	}
+	defer func() {
+		cancel()
+		stopped.Wait()
+	}()
+
+	// then
+	var follower manager.Manager
+	select {
+	case <-first.Elected():
+		follower = second
+	case <-second.Elected():
+		follower = first
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "no replica was elected")
+	}
+	// the follower retries to acquire the lease many times meanwhile
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, int32(1), recoveries.Load())
+	select {
+	case <-follower.Elected():
+		assert.Fail(t, "both replicas were elected")
+	default:
+	}
+}
+
+// testLease is an in-memory lease shared by the resource locks of several managers.
+type testLease struct {
+	mutex  sync.Mutex
+	record *resourcelock.LeaderElectionRecord
+}
+
+type testResourceLock struct {
+	lease    *testLease
+	identity string
+}
+
+func (l *testResourceLock) Get(context.Context) (*resourcelock.LeaderElectionRecord, []byte, error) {
+	l.lease.mutex.Lock()
+	defer l.lease.mutex.Unlock()
+	if l.lease.record == nil {
+		return nil, nil, apierrors.NewNotFound(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, "k8s-debug-mode-operator-leader")
+	}
+	record := *l.lease.record
+	raw, err := json.Marshal(record)
+	return &record, raw, err
+}
+
+func (l *testResourceLock) Create(_ context.Context, record resourcelock.LeaderElectionRecord) error {
+	l.lease.mutex.Lock()
+	defer l.lease.mutex.Unlock()
+	if l.lease.record != nil {
+		return apierrors.NewAlreadyExists(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, "k8s-debug-mode-operator-leader")
+	}
+	l.lease.record = &record
+	return nil
+}
+
+func (l *testResourceLock) Update(_ context.Context, record resourcelock.LeaderElectionRecord) error {
+	l.lease.mutex.Lock()
+	defer l.lease.mutex.Unlock()
+	l.lease.record = &record
+	return nil
+}
+
+func (l *testResourceLock) RecordEvent(string) {}
+
+func (l *testResourceLock) Identity() string {
+	return l.identity
+}
+
+func (l *testResourceLock) Describe() string {
+	return "test/k8s-debug-mode-operator-leader"
+}
+
+func Test_StartupRecovery_Start(t *testing.T) {
+	ctx := t.Context()
+	creationTime := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	listOptions := metav1.ListOptions{LabelSelector: "debugmode.k8s.cloudogu.com/owner"}
+	notFoundErr := apierrors.NewNotFound(schema.GroupResource{}, "debug-mode")
+	doguA := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "doguA"}}
+	doguB := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "doguB"}}
+	stateMap := func(t *testing.T) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testStateMapName,
+				UID:  testStateMapUID,
+				Labels: map[string]string{
+					stateMapOwnerLabel:    "debug-mode",
+					stateMapOwnerUIDLabel: string(testDebugModeUID),
+				},
+				CreationTimestamp: creationTime,
+			},
+			Data: map[string]string{"dogu.doguA": testStateEntry(t, "INFO")},
+		}
+	}
+	type mocks struct {
+		debugModeClient *mockDebugModeInterface
+		doguClient      *mockDoguInterface
+		configMapClient *mockConfigurationMap
+		doguHandler     *MockLogLevelHandler
+		eventRecorder   *mockEventRecorder
+	}
+	newRecovery := func(t *testing.T) (*StartupRecovery, mocks) {
+		m := mocks{
+			debugModeClient: newMockDebugModeInterface(t),
+			doguClient:      newMockDoguInterface(t),
+			configMapClient: newMockConfigurationMap(t),
+			doguHandler:     NewMockLogLevelHandler(t),
+			eventRecorder:   newMockEventRecorder(t),
+		}
+		reconciler := NewDebugModeReconciler(m.debugModeClient, m.doguClient, m.configMapClient, m.doguHandler)
+		recovery := NewStartupRecovery(reconciler, m.eventRecorder)
+		recovery.backoff = wait.Backoff{Steps: 1}
+		return recovery, m
+	}
+	expectRestore := func(m mocks, cm *corev1.ConfigMap) {
+		m.doguHandler.EXPECT().Kind().Return("dogu")
+		m.doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{doguA, doguB}}, nil)
+		m.doguHandler.EXPECT().GetLogLevelState(ctx, doguA).Return(explicitLevel(loglevel.LevelDebug), nil)
+		m.doguHandler.EXPECT().RestoreLogLevel(ctx, doguA, restoredLevel(loglevel.LevelInfo)).Return(nil)
+		m.configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(cm, nil)
+		m.configMapClient.EXPECT().Delete(ctx, testStateMapName, testStateMapDeleteOptions).Return(nil)
+		m.eventRecorder.EXPECT().Eventf(cm, nil, corev1.EventTypeNormal, "LogLevelsRestored", "Recover",
+			"Restored log levels of orphaned state map %s for dogus: [%s]", testStateMapName, "doguA").Return()
+	}
+
+	t.Run("should restore state map of deleted debug mode", func(t *testing.T) {
+		// given
+		recovery, m := newRecovery(t)
+		cm := stateMap(t)
+		m.configMapClient.EXPECT().List(ctx, listOptions).Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*cm}}, nil)
+		m.debugModeClient.EXPECT().Get(ctx, "debug-mode", metav1.GetOptions{}).Return(nil, notFoundErr)
+		expectRestore(m, cm)
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should restore state map of replaced debug mode", func(t *testing.T) {
+		// given
+		recovery, m := newRecovery(t)
+		cm := stateMap(t)
+		m.configMapClient.EXPECT().List(ctx, listOptions).Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*cm}}, nil)
+		m.debugModeClient.EXPECT().Get(ctx, "debug-mode", metav1.GetOptions{}).
+			Return(&k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{UID: "other-uid"}}, nil)
+		expectRestore(m, cm)
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should restore state map of completed debug mode", func(t *testing.T) {
+		// given
+		recovery, m := newRecovery(t)
+		cm := stateMap(t)
+		m.configMapClient.EXPECT().List(ctx, listOptions).Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*cm}}, nil)
+		m.debugModeClient.EXPECT().Get(ctx, "debug-mode", metav1.GetOptions{}).Return(&k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID},
+			Status: k8sCRLib.DebugModeStatus{Conditions: []metav1.Condition{
+				{Reason: string(k8sCRLib.DebugModeStatusCompleted)},
+			}},
+		}, nil)
+		expectRestore(m, cm)
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should restore legacy state map of previous debug mode", func(t *testing.T) {
+		// given
+		recovery, m := newRecovery(t)
+		cm := stateMap(t)
+		delete(cm.Labels, stateMapOwnerUIDLabel)
+		m.configMapClient.EXPECT().List(ctx, listOptions).Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*cm}}, nil)
+		m.debugModeClient.EXPECT().Get(ctx, "debug-mode", metav1.GetOptions{}).Return(&k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID, CreationTimestamp: metav1.NewTime(creationTime.Add(time.Hour))},
+		}, nil)
+		expectRestore(m, cm)
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should skip state map of active debug mode", func(t *testing.T) {
+		// given
+		recovery, m := newRecovery(t)
+		cm := stateMap(t)
+		m.configMapClient.EXPECT().List(ctx, listOptions).Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*cm}}, nil)
+		m.debugModeClient.EXPECT().Get(ctx, "debug-mode", metav1.GetOptions{}).
+			Return(&k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{UID: testDebugModeUID}}, nil)
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should keep state map and report failed restore", func(t *testing.T) {
+		// given
+		recovery, m := newRecovery(t)
+		cm := stateMap(t)
+		m.configMapClient.EXPECT().List(ctx, listOptions).Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*cm}}, nil)
+		m.debugModeClient.EXPECT().Get(ctx, "debug-mode", metav1.GetOptions{}).Return(nil, notFoundErr)
+		m.doguHandler.EXPECT().Kind().Return("dogu")
+		m.doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{doguA}}, nil)
+		m.doguHandler.EXPECT().GetLogLevelState(ctx, doguA).Return(explicitLevel(loglevel.LevelDebug), nil)
+		m.doguHandler.EXPECT().RestoreLogLevel(ctx, doguA, restoredLevel(loglevel.LevelInfo)).Return(assert.AnError)
+		m.eventRecorder.EXPECT().Eventf(cm, nil, corev1.EventTypeWarning, "LogLevelRestoreFailed", "Recover",
+			"Failed to restore log levels of orphaned state map %s: %v", testStateMapName, mock.Anything).Return()
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should report error getting owner", func(t *testing.T) {
+		// given
+		recovery, m := newRecovery(t)
+		cm := stateMap(t)
+		m.configMapClient.EXPECT().List(ctx, listOptions).Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*cm}}, nil)
+		m.debugModeClient.EXPECT().Get(ctx, "debug-mode", metav1.GetOptions{}).Return(nil, assert.AnError)
+		m.eventRecorder.EXPECT().Eventf(cm, nil, corev1.EventTypeWarning, "LogLevelRestoreFailed", "Recover",
+			"Failed to restore log levels of orphaned state map %s: %v", testStateMapName, mock.Anything).Return()
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not fail on error listing state maps", func(t *testing.T) {
+		// given
+		recovery, m := newRecovery(t)
+		m.configMapClient.EXPECT().List(ctx, listOptions).Return(nil, assert.AnError)
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func Test_StartupRecovery_Start_namespaces(t *testing.T) {
+	ctx := t.Context()
+	listOptions := metav1.ListOptions{LabelSelector: "debugmode.k8s.cloudogu.com/owner"}
+	notFoundErr := apierrors.NewNotFound(schema.GroupResource{}, "debug-mode")
+	stateMap := func(t *testing.T, namespace string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testStateMapName,
+				Namespace: namespace,
+				UID:       testStateMapUID,
+				Labels: map[string]string{
+					stateMapOwnerLabel:    "debug-mode",
+					stateMapOwnerUIDLabel: string(testDebugModeUID),
+				},
+			},
+			Data: map[string]string{"dogu.doguA": testStateEntry(t, "INFO")},
+		}
+	}
+
+	t.Run("should restore every state map with the reconciler of its namespace", func(t *testing.T) {
+		// given
+		stateMaps := newMockConfigurationMap(t)
+		reconcilers := newMockReconcilerProvider(t)
+		eventRecorder := newMockEventRecorder(t)
+		debugModeClient := newMockDebugModeInterface(t)
+		doguClient := newMockDoguInterface(t)
+		configMapClient := newMockConfigurationMap(t)
+		doguHandler := NewMockLogLevelHandler(t)
+		reconciler := NewDebugModeReconciler(debugModeClient, doguClient, configMapClient, doguHandler)
+
+		watched := stateMap(t, "ecosystem-1")
+		unwatched := stateMap(t, "ecosystem-2")
+		stateMaps.EXPECT().List(ctx, listOptions).Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{*watched, *unwatched}}, nil)
+		reconcilers.EXPECT().ForNamespace("ecosystem-1").Return(reconciler, nil)
+		reconcilers.EXPECT().ForNamespace("ecosystem-2").Return(nil, ErrNamespaceNotWatched)
+
+		doguA := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "doguA", Namespace: "ecosystem-1"}}
+		debugModeClient.EXPECT().Get(ctx, "debug-mode", metav1.GetOptions{}).Return(nil, notFoundErr)
+		doguHandler.EXPECT().Kind().Return("dogu")
+		doguClient.EXPECT().List(ctx, metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{doguA}}, nil)
+		doguHandler.EXPECT().GetLogLevelState(ctx, doguA).Return(explicitLevel(loglevel.LevelDebug), nil)
+		doguHandler.EXPECT().RestoreLogLevel(ctx, doguA, restoredLevel(loglevel.LevelInfo)).Return(nil)
+		configMapClient.EXPECT().Get(ctx, testStateMapName, metav1.GetOptions{}).Return(watched, nil)
+		configMapClient.EXPECT().Delete(ctx, testStateMapName, testStateMapDeleteOptions).Return(nil)
+		eventRecorder.EXPECT().Eventf(watched, nil, corev1.EventTypeNormal, "LogLevelsRestored", "Recover",
+			"Restored log levels of orphaned state map %s for dogus: [%s]", testStateMapName, "doguA").Return()
+		eventRecorder.EXPECT().Eventf(unwatched, nil, corev1.EventTypeWarning, "LogLevelRestoreFailed", "Recover",
+			"Failed to restore log levels of orphaned state map %s: %v", testStateMapName, mock.Anything).Return()
+
+		recovery := NewNamespacedStartupRecovery(reconcilers, []typev1.ConfigMapInterface{stateMaps}, eventRecorder)
+		recovery.backoff = wait.Backoff{Steps: 1}
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should list the state maps of every watched namespace", func(t *testing.T) {
+		// given
+		first := newMockConfigurationMap(t)
+		second := newMockConfigurationMap(t)
+		first.EXPECT().List(ctx, listOptions).Return(nil, assert.AnError)
+		second.EXPECT().List(ctx, listOptions).Return(&corev1.ConfigMapList{}, nil)
+
+		recovery := NewNamespacedStartupRecovery(newMockReconcilerProvider(t), []typev1.ConfigMapInterface{first, second}, newMockEventRecorder(t))
+
+		// when
+		err := recovery.Start(ctx)
+
+		// then
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+)
+
+const (
+	// stateEntryVersion is the version of the state entry schema written by this operator version.
+	stateEntryVersion = 1
+	// legacyStateEntryVersion marks entries migrated from the flat "kind.name -> LEVEL" format of older operator versions.
+	legacyStateEntryVersion = 0
+)
+
+var errStateEntryChecksum = errors.New("checksum mismatch")
+
+// timeNow returns the current time and is replaced in tests.
+var timeNow = time.Now
+
+// StateEntry is the state of a single element captured before the debug mode changed it.
+// It is stored as JSON in the state map.
+type StateEntry struct {
+	// Version is the schema version of the entry.
+	Version int `json:"version"`
+	// Level is the original log level of the element.
+	Level string `json:"level"`
+	// Unset is true if the log level was not set explicitly but inherited from a default.
+	Unset bool `json:"unset,omitempty"`
+	// RawValue is the original value as written in the configuration of the element.
+	RawValue string `json:"rawValue,omitempty"`
+	// Values are the original values of further config keys of the element, e.g. loggers of single packages.
+	Values map[string]loglevel.ConfigValueState `json:"values,omitempty"`
+	// DoguVersion is the version of the dogu at the time the state was captured.
+	DoguVersion string `json:"doguVersion,omitempty"`
+	// CapturedAt is the time the state was captured.
+	CapturedAt time.Time `json:"capturedAt,omitzero"`
+	// Checksum is a SHA-256 checksum over all other fields and detects manipulated or corrupted entries.
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// newStateEntry creates a state entry in the current schema version with a valid checksum.
+func newStateEntry(level string, unset bool, rawValue string, doguVersion string) StateEntry {
+	entry := StateEntry{
+		Version:     stateEntryVersion,
+		Level:       level,
+		Unset:       unset,
+		RawValue:    rawValue,
+		DoguVersion: doguVersion,
+		CapturedAt:  timeNow().UTC().Truncate(time.Second),
+	}
+	entry.Checksum = entry.computeChecksum()
+	return entry
+}
+
+func (e StateEntry) computeChecksum() string {
+	e.Checksum = ""
+	// marshalling a struct is deterministic, so the checksum is stable
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// newConfigStateEntry creates a state entry for the original values of config keys with a valid checksum.
+func newConfigStateEntry(values map[string]loglevel.ConfigValueState, doguVersion string) StateEntry {
+	entry := StateEntry{
+		Version:     stateEntryVersion,
+		Values:      values,
+		DoguVersion: doguVersion,
+		CapturedAt:  timeNow().UTC().Truncate(time.Second),
+	}
+	entry.Checksum = entry.computeChecksum()
+	return entry
+}
+
+// marshal converts the entry into its string representation in the state map.
+func (e StateEntry) marshal() (string, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal state entry: %w", err)
+	}
+	return string(data), nil
+}
+
+// ParseStateEntry reads a state entry from its string representation in the state map.
+// Flat level strings written by older operator versions are migrated to an entry with the legacy version.
+func ParseStateEntry(raw string) (StateEntry, error) {
+	trimmed := strings.TrimSpace(raw)
+	if !strings.HasPrefix(trimmed, "{") {
+		return StateEntry{
+			Version:  legacyStateEntryVersion,
+			Level:    trimmed,
+			RawValue: trimmed,
+		}, nil
+	}
+
+	var entry StateEntry
+	err := json.Unmarshal([]byte(trimmed), &entry)
+	if err != nil {
+		return StateEntry{}, fmt.Errorf("failed to unmarshal state entry: %w", err)
+	}
+
+	if entry.Version > stateEntryVersion {
+		return StateEntry{}, fmt.Errorf("unsupported state entry version %d, supported up to %d", entry.Version, stateEntryVersion)
+	}
+
+	if entry.Checksum != entry.computeChecksum() {
+		return StateEntry{}, fmt.Errorf("state entry for level %q: %w", entry.Level, errStateEntryChecksum)
+	}
+
+	return entry, nil
+}
+
+// migrateStateData converts all entries of a state map written by an older operator version into the structured format.
+// The migrated entries keep the legacy version, so it is still visible that no metadata was captured for them.
+func migrateStateData(data map[string]string) (map[string]string, error) {
+	migrated := make(map[string]string, len(data))
+	for key, raw := range data {
+		entry, err := ParseStateEntry(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate state entry %s: %w", key, err)
+		}
+		if entry.Checksum == "" {
+			entry.Checksum = entry.computeChecksum()
+		}
+		migrated[key], err = entry.marshal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate state entry %s: %w", key, err)
+		}
+	}
+	return migrated, nil
+}
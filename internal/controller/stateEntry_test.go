@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newStateEntry(t *testing.T) {
+	t.Run("should create entry in current version with checksum", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+
+		// when
+		entry := newStateEntry("INFO", true, "", "1.2.3-4")
+
+		// then
+		assert.Equal(t, stateEntryVersion, entry.Version)
+		assert.Equal(t, "INFO", entry.Level)
+		assert.True(t, entry.Unset)
+		assert.Equal(t, "1.2.3-4", entry.DoguVersion)
+		assert.Equal(t, time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), entry.CapturedAt)
+		assert.Equal(t, entry.computeChecksum(), entry.Checksum)
+	})
+}
+
+func Test_parseStateEntry(t *testing.T) {
+	t.Run("should round trip current version", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		entry := newStateEntry("WARN", false, "warn", "1.2.3-4")
+		raw, err := entry.marshal()
+		require.NoError(t, err)
+
+		// when
+		actual, err := ParseStateEntry(raw)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, entry, actual)
+	})
+	t.Run("should read stable json of version 1", func(t *testing.T) {
+		// given
+		expected := StateEntry{Version: 1, Level: "WARN", RawValue: "warn", DoguVersion: "1.2.3-4", CapturedAt: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)}
+		expected.Checksum = expected.computeChecksum()
+		raw := `{"version":1,"level":"WARN","rawValue":"warn","doguVersion":"1.2.3-4","capturedAt":"2026-01-01T10:00:00Z","checksum":"` + expected.Checksum + `"}`
+
+		// when
+		actual, err := ParseStateEntry(raw)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("should migrate flat level written by operator v1.0.x", func(t *testing.T) {
+		for _, raw := range []string{"INFO", "debug", " WARN\n"} {
+			// when
+			entry, err := ParseStateEntry(raw)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, legacyStateEntryVersion, entry.Version)
+			assert.Equal(t, entry.Level, entry.RawValue)
+			assert.False(t, entry.Unset)
+			assert.Empty(t, entry.Checksum)
+		}
+	})
+	t.Run("should fail on manipulated entry", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		raw, err := newStateEntry("WARN", false, "WARN", "").marshal()
+		require.NoError(t, err)
+		manipulated := `{"version":1,"level":"DEBUG"` + raw[len(`{"version":1,"level":"WARN"`):]
+
+		// when
+		_, err = ParseStateEntry(manipulated)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errStateEntryChecksum)
+	})
+	t.Run("should fail on newer schema version", func(t *testing.T) {
+		// given
+		entry := StateEntry{Version: stateEntryVersion + 1, Level: "WARN"}
+		entry.Checksum = entry.computeChecksum()
+		raw, err := entry.marshal()
+		require.NoError(t, err)
+
+		// when
+		_, err = ParseStateEntry(raw)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unsupported state entry version 2")
+	})
+	t.Run("should fail on invalid json", func(t *testing.T) {
+		// when
+		_, err := ParseStateEntry(`{"version":`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to unmarshal state entry")
+	})
+}
+
+func Test_migrateStateData(t *testing.T) {
+	t.Run("should migrate flat entries and keep structured ones", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		structured, err := newStateEntry("ERROR", false, "ERROR", "2.0.0-1").marshal()
+		require.NoError(t, err)
+		data := map[string]string{
+			"dogu.cas":  "WARN",
+			"dogu.ldap": structured,
+		}
+
+		// when
+		migrated, err := migrateStateData(data)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, structured, migrated["dogu.ldap"])
+		entry, err := ParseStateEntry(migrated["dogu.cas"])
+		require.NoError(t, err)
+		assert.Equal(t, legacyStateEntryVersion, entry.Version)
+		assert.Equal(t, "WARN", entry.Level)
+		assert.NotEmpty(t, entry.Checksum)
+	})
+	t.Run("should fail on corrupt entry", func(t *testing.T) {
+		// when
+		_, err := migrateStateData(map[string]string{"dogu.cas": "{corrupt"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to migrate state entry dogu.cas")
+	})
+}
@@ -2,16 +2,36 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
 	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-const DEFAULT_CM_NAME = "debugmode-state"
+const (
+	// DEFAULT_CM_NAME is the prefix of all state maps. Operator versions before per-CR state maps
+	// used it as the fixed name of the single state map.
+	DEFAULT_CM_NAME = "debugmode-state"
+
+	stateMapOwnerLabel    = "debugmode.k8s.cloudogu.com/owner"
+	stateMapOwnerUIDLabel = "debugmode.k8s.cloudogu.com/owner-uid"
+	// stateMapFinalizer keeps the garbage collector from deleting the state map together with its DebugMode
+	// before the original log levels were restored.
+	stateMapFinalizer = "debugmode.k8s.cloudogu.com/state-protection"
+)
+
+// errStateChangedConcurrently reports entries another writer has stored in the state map or in the sensitive state
+// concurrently. The entries of the other writer are kept, so nothing has failed and the reconcile is only repeated.
+var errStateChangedConcurrently = errors.New("state entries changed concurrently")
 
 type StateMap struct {
 	debugCR            *k8sCRLib.DebugMode
@@ -20,22 +40,55 @@ type StateMap struct {
 	configMap          *corev1.ConfigMap
 }
 
+// NewStateMap loads the state map of the given DebugMode or creates it.
+// If the DebugMode is already deleted (debugCR is nil), the only existing state map owned by a DebugMode with the
+// given name is loaded instead. No state map is created in this case.
 func NewStateMap(ctx context.Context,
+	debugModeName string,
 	debugCR *k8sCRLib.DebugMode,
 	configMapInterface configurationMap,
-) *StateMap {
+) (*StateMap, error) {
 	stateMap := &StateMap{
 		configMapInterface: configMapInterface,
 		debugCR:            debugCR,
 		logger:             logging.FromContext(ctx),
 	}
-	stateMap.configMap = stateMap.getOrCreateConfigMap(ctx)
-	return stateMap
+
+	var cm *corev1.ConfigMap
+	var err error
+	if debugCR == nil {
+		cm, err = stateMap.findConfigMapOfDeletedOwner(ctx, debugModeName)
+	} else {
+		cm, err = stateMap.getOrCreateConfigMap(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	stateMap.configMap = cm
+
+	return stateMap, nil
+}
+
+// StateMapSelector returns the label selector of all state maps owned by a DebugMode with the given name.
+func StateMapSelector(debugModeName string) string {
+	return metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: map[string]string{stateMapOwnerLabel: debugModeName}})
 }
 
+// stateMapName returns the name of the state map that belongs to the DebugMode with the given UID.
+func stateMapName(ownerUID types.UID) string {
+	return fmt.Sprintf("%s-%s", DEFAULT_CM_NAME, ownerUID)
+}
+
+// Destroy removes the finalizer from the state map and deletes it.
+// Only the state map loaded by this StateMap is deleted, even if a new one with the same name has been created meanwhile.
 func (s *StateMap) Destroy(ctx context.Context) (bool, error) {
-	cmName := DEFAULT_CM_NAME
-	_, err := s.configMapInterface.Get(ctx, cmName, metav1.GetOptions{})
+	if s.configMap.UID == "" {
+		// the state map has never been persisted
+		return false, nil
+	}
+
+	cmName := s.configMap.Name
+	cm, err := s.configMapInterface.Get(ctx, cmName, metav1.GetOptions{})
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			// generic error - not would be ok
@@ -45,43 +98,139 @@ func (s *StateMap) Destroy(ctx context.Context) (bool, error) {
 		return false, nil
 	}
 
-	err = s.configMapInterface.Delete(ctx, cmName, metav1.DeleteOptions{})
+	if cm.UID != s.configMap.UID {
+		s.logger.Info("State map has been replaced, do not delete it", "stateMap", cmName)
+		return false, nil
+	}
+
+	if controllerutil.RemoveFinalizer(cm, stateMapFinalizer) {
+		cm, err = s.configMapInterface.Update(ctx, cm, metav1.UpdateOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to remove finalizer from state map %s: %w", cmName, err)
+		}
+	}
+
+	err = s.configMapInterface.Delete(ctx, cmName, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &cm.UID}})
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// the map was already garbage collected after the finalizer has been removed
+			return true, nil
+		}
 		return false, err
 	}
 	return true, nil
 }
 
-func (s *StateMap) getOrCreateConfigMap(ctx context.Context) *corev1.ConfigMap {
-	cmName := DEFAULT_CM_NAME
+func (s *StateMap) getOrCreateConfigMap(ctx context.Context) (*corev1.ConfigMap, error) {
+	cmName := stateMapName(s.debugCR.UID)
 	cm, err := s.configMapInterface.Get(ctx, cmName, metav1.GetOptions{})
+	if err == nil {
+		return cm, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get state map %s: %w", cmName, err)
+	}
+
+	legacyData, err := s.getLegacyStateData(ctx)
 	if err != nil {
-		if !apierrors.IsNotFound(err) {
-			// generic error - not would be ok
-			return nil
-		}
-		if s.debugCR == nil {
-			// do not create state map when CR is deleted - this should lead to an error
-			return nil
-		}
-		cm = &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cmName,
-				Namespace: s.debugCR.Namespace,
-				Labels: map[string]string{
-					"debugmode.k8s.cloudogu.com/owner": s.debugCR.Name,
-				},
+		return nil, err
+	}
+
+	if len(legacyData) == 0 && s.debugCR.DeletionTimestamp != nil {
+		// a deleted debug mode without state has nothing to restore, so there is no need to persist a map
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: cmName, Namespace: s.debugCR.Namespace},
+			Data:       map[string]string{},
+		}, nil
+	}
+
+	cm = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmName,
+			Namespace: s.debugCR.Namespace,
+			Labels: map[string]string{
+				stateMapOwnerLabel:    s.debugCR.Name,
+				stateMapOwnerUIDLabel: string(s.debugCR.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(s.debugCR, k8sCRLib.GroupVersion.WithKind("DebugMode")),
 			},
-			Data: map[string]string{},
+			Finalizers: []string{stateMapFinalizer},
+		},
+		Data: legacyData,
+	}
+
+	created, err := s.configMapInterface.Create(ctx, cm, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create state map %s: %w", cmName, err)
+	}
+
+	if len(legacyData) > 0 {
+		err = s.configMapInterface.Delete(ctx, DEFAULT_CM_NAME, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete legacy state map %s after migration: %w", DEFAULT_CM_NAME, err)
 		}
+		s.logger.Info("Migrated legacy state map", "from", DEFAULT_CM_NAME, "stateMap", cmName)
+	}
 
-		if cm, err = s.configMapInterface.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
-			s.logger.Error(fmt.Sprintf("ERROR: failed to create configMap: %v", err))
-			return nil
+	return created, nil
+}
+
+// getLegacyStateData returns the data of a state map written by an operator version with a fixed state map name.
+// The data is only adopted if the legacy map has been created for the current DebugMode, that is, it carries the
+// name of the DebugMode as owner and has not been created before the DebugMode itself.
+func (s *StateMap) getLegacyStateData(ctx context.Context) (map[string]string, error) {
+	legacy, err := s.configMapInterface.Get(ctx, DEFAULT_CM_NAME, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string]string{}, nil
 		}
+		return nil, fmt.Errorf("failed to get legacy state map %s: %w", DEFAULT_CM_NAME, err)
 	}
 
-	return cm
+	if legacy.Labels[stateMapOwnerLabel] != s.debugCR.Name || legacy.CreationTimestamp.Before(&s.debugCR.CreationTimestamp) {
+		s.logger.Info("Ignore stale legacy state map", "stateMap", DEFAULT_CM_NAME, "creationTimestamp", legacy.CreationTimestamp)
+		return map[string]string{}, nil
+	}
+
+	data, err := migrateStateData(legacy.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate legacy state map %s: %w", DEFAULT_CM_NAME, err)
+	}
+	return data, nil
+}
+
+// findConfigMapOfDeletedOwner returns the state map owned by a DebugMode with the given name.
+// The UID of the deleted DebugMode is unknown, so several state maps of earlier DebugModes with the same name cannot be
+// told apart. None of them is restored then; the startup recovery restores them by the UID of their owners.
+// If there is no unique state map, an empty and never persisted map is returned, so there is nothing to restore.
+func (s *StateMap) findConfigMapOfDeletedOwner(ctx context.Context, debugModeName string) (*corev1.ConfigMap, error) {
+	list, err := s.configMapInterface.List(ctx, metav1.ListOptions{LabelSelector: StateMapSelector(debugModeName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list state maps of debug mode %s: %w", debugModeName, err)
+	}
+
+	switch len(list.Items) {
+	case 1:
+		return &list.Items[0], nil
+	case 0:
+	default:
+		names := make([]string, 0, len(list.Items))
+		for _, cm := range list.Items {
+			names = append(names, cm.Name)
+		}
+		s.logger.Error("ERROR: several state maps of deleted debug mode - restore them with the startup recovery",
+			"debugMode", debugModeName, "stateMaps", names)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DEFAULT_CM_NAME},
+		Data:       map[string]string{},
+	}, nil
+}
+
+// isEmpty returns true if no original log level is stored in the state map.
+func (s *StateMap) isEmpty() bool {
+	return len(s.configMap.Data) == 0
 }
 
 func (s *StateMap) getValueFromMap(key string) string {
@@ -92,21 +241,94 @@ func (s *StateMap) getValueFromMap(key string) string {
 	return val
 }
 
+// getEntry returns the stored state of the given key. Entries in the flat format of older operator versions are migrated.
+// An error is returned if the entry is corrupt, so it is never used as restore source.
+func (s *StateMap) getEntry(key string) (StateEntry, bool, error) {
+	raw := s.getValueFromMap(key)
+	if raw == "" {
+		return StateEntry{}, false, nil
+	}
+
+	entry, err := ParseStateEntry(raw)
+	if err != nil {
+		return StateEntry{}, true, fmt.Errorf("invalid state entry %s in state map %s: %w", key, s.configMap.Name, err)
+	}
+	return entry, true, nil
+}
+
+// storeEntries writes the given state entries with a single update.
+func (s *StateMap) storeEntries(ctx context.Context, entries map[string]StateEntry) error {
+	data := make(map[string]string, len(entries))
+	for key, entry := range entries {
+		value, err := entry.marshal()
+		if err != nil {
+			return fmt.Errorf("failed to store state entry %s: %w", key, err)
+		}
+		data[key] = value
+	}
+	return s.updateStateMapEntries(ctx, data)
+}
+
 func (s *StateMap) updateStateMap(ctx context.Context, key string, value string) error {
-	s.logger.Debug(fmt.Sprintf("Update state map %s:%s", key, value))
-	if s.configMap.Data == nil {
-		s.logger.Debug("- create new configmap data")
-		s.configMap.Data = map[string]string{}
+	return s.updateStateMapEntries(ctx, map[string]string{key: value})
+}
+
+// updateStateMapEntries writes all given entries to the state map with a single update.
+// On a conflict the latest version of the config map is read again and the entries are merged into it,
+// so keys written by a previous reconcile are never lost. An entry another writer has changed in the meantime is
+// kept, because it may hold an original captured before the log level was raised; errStateChangedConcurrently is returned
+// then, so the reconcile starts over with the latest state. The in-memory map is only replaced on success.
+func (s *StateMap) updateStateMapEntries(ctx context.Context, entries map[string]string) error {
+	if len(entries) == 0 {
+		return nil
 	}
+	s.logger.Debug("Update state map", "entries", entries)
+
+	cmName := s.configMap.Name
+	base := s.configMap
+	latest := s.configMap
+	var kept []string
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if latest == nil {
+			var getErr error
+			latest, getErr = s.configMapInterface.Get(ctx, cmName, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+		}
 
-	s.configMap.Data[key] = value
+		updated := latest.DeepCopy()
+		if updated.Data == nil {
+			updated.Data = map[string]string{}
+		}
+		kept = nil
+		for key, value := range entries {
+			current, exists := updated.Data[key]
+			if exists && current != base.Data[key] && current != value {
+				kept = append(kept, key)
+				continue
+			}
+			updated.Data[key] = value
+		}
 
-	s.logger.Debug("- start update")
-	newMap, err := s.configMapInterface.Update(ctx, s.configMap, metav1.UpdateOptions{})
+		newMap, updateErr := s.configMapInterface.Update(ctx, updated, metav1.UpdateOptions{})
+		if updateErr != nil {
+			// force a re-read of the latest version on the next attempt
+			latest = nil
+			return updateErr
+		}
+
+		s.configMap = newMap
+		return nil
+	})
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to Update configMap: %v", err))
+		return fmt.Errorf("failed to update state map %s: %w", cmName, err)
+	}
+
+	s.logger.Debug("Updated state map", "data", s.configMap.Data)
+	if len(kept) > 0 {
+		slices.Sort(kept)
+		return fmt.Errorf("%w in state map %s: %s", errStateChangedConcurrently, cmName, strings.Join(kept, ", "))
 	}
-	s.logger.Debug(fmt.Sprintf("- updated map %s:%s to %v", key, value, newMap))
-	s.configMap = newMap
-	return err
+	return nil
 }
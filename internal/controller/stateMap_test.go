@@ -1,139 +1,324 @@
 package controller
 
 import (
-	"context"
 	"testing"
+	"time"
 
 	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 )
 
 func Test_StateMap_New(t *testing.T) {
 	ctx := t.Context()
+	creationTime := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	cr := &k8sCRLib.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "mycr",
+			Namespace:         "ecosystem",
+			UID:               "uid-1",
+			CreationTimestamp: creationTime,
+		},
+	}
+	notFoundErr := errors.NewNotFound(schema.GroupResource{}, "CM")
+	migratedWarnEntry := `{"version":0,"level":"WARN","rawValue":"WARN","checksum":"30b3e4c418fe9437eef7590a235c6acaf54ce8d0fa1417402de9256768c8a9d2"}`
+	newStateMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "debugmode-state-uid-1",
+				Namespace: cr.Namespace,
+				Labels: map[string]string{
+					"debugmode.k8s.cloudogu.com/owner":     cr.Name,
+					"debugmode.k8s.cloudogu.com/owner-uid": "uid-1",
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion:         "k8s.cloudogu.com/v1",
+					Kind:               "DebugMode",
+					Name:               cr.Name,
+					UID:                "uid-1",
+					Controller:         ptr.To(true),
+					BlockOwnerDeletion: ptr.To(true),
+				}},
+				Finalizers: []string{"debugmode.k8s.cloudogu.com/state-protection"},
+			},
+			Data: data,
+		}
+	}
+
 	t.Run("success with exists", func(t *testing.T) {
 		//given
-		cr := &k8sCRLib.DebugMode{}
 		cm := &corev1.ConfigMap{}
 		configMapInterface := newMockConfigurationMap(t)
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(cm, nil)
 
 		//when
-		stateMap := NewStateMap(ctx, cr, configMapInterface)
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
 
 		//then
+		require.NoError(t, err)
 		assert.NotEmpty(t, stateMap)
-		assert.NotNil(t, stateMap.configMap)
+		assert.Same(t, cm, stateMap.configMap)
 	})
 	t.Run("success without exists", func(t *testing.T) {
 		//given
-		cr := &k8sCRLib.DebugMode{
+		configMapInterface := newMockConfigurationMap(t)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, notFoundErr)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(nil, notFoundErr)
+		cm := newStateMap(map[string]string{})
+		configMapInterface.EXPECT().Create(ctx, cm, metav1.CreateOptions{}).Return(cm, nil)
+
+		//when
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
+
+		//then
+		require.NoError(t, err)
+		assert.NotEmpty(t, stateMap)
+		assert.NotNil(t, stateMap.configMap)
+	})
+	t.Run("should adopt legacy state map of the same debug mode", func(t *testing.T) {
+		//given
+		configMapInterface := newMockConfigurationMap(t)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, notFoundErr)
+		legacy := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "mycr",
-				Namespace: "ecosystem"},
+				Name:              "debugmode-state",
+				Labels:            map[string]string{"debugmode.k8s.cloudogu.com/owner": cr.Name},
+				CreationTimestamp: metav1.NewTime(creationTime.Add(time.Minute)),
+			},
+			Data: map[string]string{"dogu.cas": "WARN"},
 		}
-		cm := &corev1.ConfigMap{}
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(legacy, nil)
+		cm := newStateMap(map[string]string{"dogu.cas": migratedWarnEntry})
+		configMapInterface.EXPECT().Create(ctx, cm, metav1.CreateOptions{}).Return(cm, nil)
+		configMapInterface.EXPECT().Delete(ctx, "debugmode-state", metav1.DeleteOptions{}).Return(nil)
+
+		//when
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
+
+		//then
+		require.NoError(t, err)
+		entry, found, err := stateMap.getEntry("dogu.cas")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "WARN", entry.Level)
+	})
+	t.Run("should ignore stale legacy state map of a previous debug mode", func(t *testing.T) {
+		//given
 		configMapInterface := newMockConfigurationMap(t)
-		err := errors.NewNotFound(schema.GroupResource{}, "CM")
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, err)
-		cm = &corev1.ConfigMap{
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, notFoundErr)
+		legacy := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "debugmode-state",
-				Namespace: cr.Namespace,
-				Labels: map[string]string{
-					"debugmode.k8s.cloudogu.com/owner": cr.Name,
-				},
+				Name:              "debugmode-state",
+				Labels:            map[string]string{"debugmode.k8s.cloudogu.com/owner": cr.Name},
+				CreationTimestamp: metav1.NewTime(creationTime.Add(-time.Hour)),
 			},
-			Data: map[string]string{},
+			Data: map[string]string{"dogu.cas": "DEBUG"},
 		}
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(legacy, nil)
+		cm := newStateMap(map[string]string{})
 		configMapInterface.EXPECT().Create(ctx, cm, metav1.CreateOptions{}).Return(cm, nil)
 
 		//when
-		stateMap := NewStateMap(ctx, cr, configMapInterface)
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
 
 		//then
-		assert.NotEmpty(t, stateMap)
-		assert.NotNil(t, stateMap.configMap)
+		require.NoError(t, err)
+		assert.Equal(t, "", stateMap.getValueFromMap("dogu.cas"))
 	})
-	t.Run("error without exists", func(t *testing.T) {
+	t.Run("error getting legacy state map", func(t *testing.T) {
 		//given
-		cr := &k8sCRLib.DebugMode{
+		configMapInterface := newMockConfigurationMap(t)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, notFoundErr)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		//when
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
+
+		//then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, stateMap)
+	})
+	t.Run("error deleting migrated legacy state map", func(t *testing.T) {
+		//given
+		configMapInterface := newMockConfigurationMap(t)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, notFoundErr)
+		legacy := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "mycr",
-				Namespace: "ecosystem"},
+				Name:              "debugmode-state",
+				Labels:            map[string]string{"debugmode.k8s.cloudogu.com/owner": cr.Name},
+				CreationTimestamp: creationTime,
+			},
+			Data: map[string]string{"dogu.cas": "WARN"},
 		}
-		cm := &corev1.ConfigMap{}
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(legacy, nil)
+		cm := newStateMap(map[string]string{"dogu.cas": migratedWarnEntry})
+		configMapInterface.EXPECT().Create(ctx, cm, metav1.CreateOptions{}).Return(cm, nil)
+		configMapInterface.EXPECT().Delete(ctx, "debugmode-state", metav1.DeleteOptions{}).Return(assert.AnError)
+
+		//when
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
+
+		//then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, stateMap)
+	})
+	t.Run("error without exists", func(t *testing.T) {
+		//given
 		configMapInterface := newMockConfigurationMap(t)
-		err := assert.AnError
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, err)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, assert.AnError)
 
 		//when
-		stateMap := NewStateMap(ctx, cr, configMapInterface)
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
 
 		//then
-		assert.NotEmpty(t, stateMap)
-		assert.Nil(t, stateMap.configMap)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, stateMap)
 	})
 	t.Run("error creating new configmap", func(t *testing.T) {
 		//given
-		cr := &k8sCRLib.DebugMode{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "mycr",
-				Namespace: "ecosystem"},
-		}
-		cm := &corev1.ConfigMap{}
 		configMapInterface := newMockConfigurationMap(t)
-		err := errors.NewNotFound(schema.GroupResource{}, "CM")
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, err)
-		cm = &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "debugmode-state",
-				Namespace: cr.Namespace,
-				Labels: map[string]string{
-					"debugmode.k8s.cloudogu.com/owner": cr.Name,
-				},
-			},
-			Data: map[string]string{},
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, notFoundErr)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(nil, notFoundErr)
+		configMapInterface.EXPECT().Create(ctx, newStateMap(map[string]string{}), metav1.CreateOptions{}).Return(nil, assert.AnError)
+
+		//when
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
+
+		//then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, stateMap)
+	})
+	t.Run("should load the state map of deleted cr", func(t *testing.T) {
+		//given
+		configMapInterface := newMockConfigurationMap(t)
+		cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", CreationTimestamp: creationTime}}
+		configMapInterface.EXPECT().List(ctx, metav1.ListOptions{LabelSelector: "debugmode.k8s.cloudogu.com/owner=mycr"}).
+			Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{cm}}, nil)
+
+		//when
+		stateMap, err := NewStateMap(ctx, "mycr", nil, configMapInterface)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "debugmode-state-uid-1", stateMap.configMap.Name)
+	})
+	t.Run("should not choose between several state maps of deleted cr", func(t *testing.T) {
+		//given
+		configMapInterface := newMockConfigurationMap(t)
+		older := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-0", UID: "cm-0", CreationTimestamp: metav1.NewTime(creationTime.Add(-time.Hour))},
+			Data:       map[string]string{"dogu.a": "INFO"},
 		}
-		configMapInterface.EXPECT().Create(ctx, cm, metav1.CreateOptions{}).Return(cm, assert.AnError)
+		latest := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", UID: "cm-1", CreationTimestamp: creationTime},
+			Data:       map[string]string{"dogu.a": "DEBUG"},
+		}
+		configMapInterface.EXPECT().List(ctx, metav1.ListOptions{LabelSelector: "debugmode.k8s.cloudogu.com/owner=mycr"}).
+			Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{latest, older}}, nil)
 
 		//when
-		stateMap := NewStateMap(ctx, cr, configMapInterface)
+		stateMap, err := NewStateMap(ctx, "mycr", nil, configMapInterface)
 
 		//then
-		assert.NotEmpty(t, stateMap)
-		assert.Nil(t, stateMap.configMap)
+		require.NoError(t, err)
+		assert.True(t, stateMap.isEmpty())
+		assert.Empty(t, stateMap.configMap.UID, "the empty state map must never be persisted or deleted")
 	})
-	t.Run("error creating new configmap without cr", func(t *testing.T) {
+	t.Run("do not create new configmap without cr", func(t *testing.T) {
 		//given
-		cm := &corev1.ConfigMap{}
 		configMapInterface := newMockConfigurationMap(t)
-		err := errors.NewNotFound(schema.GroupResource{}, "CM")
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, err)
+		configMapInterface.EXPECT().List(ctx, metav1.ListOptions{LabelSelector: "debugmode.k8s.cloudogu.com/owner=mycr"}).
+			Return(&corev1.ConfigMapList{}, nil)
 
 		//when
-		stateMap := NewStateMap(ctx, nil, configMapInterface)
+		stateMap, err := NewStateMap(ctx, "mycr", nil, configMapInterface)
 
 		//then
-		assert.NotEmpty(t, stateMap)
-		assert.Nil(t, stateMap.configMap)
+		require.NoError(t, err)
+		require.NotNil(t, stateMap.configMap)
+		assert.Empty(t, stateMap.configMap.Data)
+		assert.Equal(t, "", stateMap.getValueFromMap("dogu.any"))
+	})
+	t.Run("do not create new configmap for cr marked for deletion", func(t *testing.T) {
+		//given
+		deletedCR := cr.DeepCopy()
+		deletedCR.DeletionTimestamp = &creationTime
+		configMapInterface := newMockConfigurationMap(t)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, notFoundErr)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(nil, notFoundErr)
+
+		//when
+		stateMap, err := NewStateMap(ctx, cr.Name, deletedCR, configMapInterface)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "debugmode-state-uid-1", stateMap.configMap.Name)
+		assert.Empty(t, stateMap.configMap.UID)
+		assert.True(t, stateMap.isEmpty())
+	})
+	t.Run("error listing state maps without cr", func(t *testing.T) {
+		//given
+		configMapInterface := newMockConfigurationMap(t)
+		configMapInterface.EXPECT().List(ctx, metav1.ListOptions{LabelSelector: "debugmode.k8s.cloudogu.com/owner=mycr"}).
+			Return(nil, assert.AnError)
+
+		//when
+		stateMap, err := NewStateMap(ctx, "mycr", nil, configMapInterface)
+
+		//then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, stateMap)
 	})
 }
 
 func Test_StateMap_Destroy(t *testing.T) {
 	ctx := t.Context()
+	loaded := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", UID: "cm-uid"}}
+	deleteOptions := metav1.DeleteOptions{Preconditions: metav1.NewUIDPreconditions("cm-uid")}
+
 	t.Run("success", func(t *testing.T) {
 		// given
-		cm := &corev1.ConfigMap{}
 		configMapInterface := newMockConfigurationMap(t)
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(loaded.DeepCopy(), nil)
+		configMapInterface.EXPECT().Delete(ctx, "debugmode-state-uid-1", deleteOptions).Return(nil)
 		statMap := StateMap{
 			configMapInterface: configMapInterface,
+			configMap:          loaded,
+		}
+
+		// when
+		del, err := statMap.Destroy(ctx)
+
+		// then
+		assert.True(t, del)
+		assert.NoError(t, err)
+	})
+	t.Run("success removing finalizer", func(t *testing.T) {
+		// given
+		protected := loaded.DeepCopy()
+		protected.Finalizers = []string{"debugmode.k8s.cloudogu.com/state-protection"}
+		configMapInterface := newMockConfigurationMap(t)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(protected, nil)
+		released := loaded.DeepCopy()
+		released.Finalizers = []string{}
+		configMapInterface.EXPECT().Update(ctx, released, metav1.UpdateOptions{}).Return(released, nil)
+		configMapInterface.EXPECT().Delete(ctx, "debugmode-state-uid-1", deleteOptions).Return(errors.NewNotFound(schema.GroupResource{}, "CM"))
+		statMap := StateMap{
+			configMapInterface: configMapInterface,
+			configMap:          loaded,
 		}
-		configMapInterface.EXPECT().Delete(ctx, "debugmode-state", metav1.DeleteOptions{}).Return(nil)
 
 		// when
 		del, err := statMap.Destroy(ctx)
@@ -142,13 +327,66 @@ func Test_StateMap_Destroy(t *testing.T) {
 		assert.True(t, del)
 		assert.NoError(t, err)
 	})
+	t.Run("error removing finalizer", func(t *testing.T) {
+		// given
+		protected := loaded.DeepCopy()
+		protected.Finalizers = []string{"debugmode.k8s.cloudogu.com/state-protection"}
+		configMapInterface := newMockConfigurationMap(t)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(protected, nil)
+		configMapInterface.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+		statMap := StateMap{
+			configMapInterface: configMapInterface,
+			configMap:          loaded,
+		}
+
+		// when
+		del, err := statMap.Destroy(ctx)
+
+		// then
+		assert.False(t, del)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should not delete a replaced state map", func(t *testing.T) {
+		// given
+		replaced := loaded.DeepCopy()
+		replaced.UID = "other-uid"
+		configMapInterface := newMockConfigurationMap(t)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(replaced, nil)
+		statMap := StateMap{
+			configMapInterface: configMapInterface,
+			configMap:          loaded,
+			logger:             logging.FromContext(ctx),
+		}
+
+		// when
+		del, err := statMap.Destroy(ctx)
+
+		// then
+		assert.False(t, del)
+		assert.NoError(t, err)
+	})
+	t.Run("should not delete a never persisted state map", func(t *testing.T) {
+		// given
+		configMapInterface := newMockConfigurationMap(t)
+		statMap := StateMap{
+			configMapInterface: configMapInterface,
+			configMap:          &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state"}},
+		}
+
+		// when
+		del, err := statMap.Destroy(ctx)
+
+		// then
+		assert.False(t, del)
+		assert.NoError(t, err)
+	})
 	t.Run("error on get", func(t *testing.T) {
 		// given
-		cm := &corev1.ConfigMap{}
 		configMapInterface := newMockConfigurationMap(t)
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, assert.AnError)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, assert.AnError)
 		statMap := StateMap{
 			configMapInterface: configMapInterface,
+			configMap:          loaded,
 		}
 
 		// when
@@ -160,12 +398,12 @@ func Test_StateMap_Destroy(t *testing.T) {
 	})
 	t.Run("error on get - no not found", func(t *testing.T) {
 		// given
-		cm := &corev1.ConfigMap{}
 		configMapInterface := newMockConfigurationMap(t)
 		notFoundErr := errors.NewNotFound(schema.GroupResource{}, "CM")
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, notFoundErr)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, notFoundErr)
 		statMap := StateMap{
 			configMapInterface: configMapInterface,
+			configMap:          loaded,
 		}
 
 		// when
@@ -177,13 +415,13 @@ func Test_StateMap_Destroy(t *testing.T) {
 	})
 	t.Run("error on delete", func(t *testing.T) {
 		// given
-		cm := &corev1.ConfigMap{}
 		configMapInterface := newMockConfigurationMap(t)
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(loaded.DeepCopy(), nil)
+		configMapInterface.EXPECT().Delete(ctx, "debugmode-state-uid-1", deleteOptions).Return(assert.AnError)
 		statMap := StateMap{
 			configMapInterface: configMapInterface,
+			configMap:          loaded,
 		}
-		configMapInterface.EXPECT().Delete(ctx, "debugmode-state", metav1.DeleteOptions{}).Return(assert.AnError)
 
 		// when
 		del, err := statMap.Destroy(ctx)
@@ -192,21 +430,22 @@ func Test_StateMap_Destroy(t *testing.T) {
 		assert.False(t, del)
 		assert.Error(t, err)
 	})
-
 }
+
 func Test_StateMap_compareWithStateMap(t *testing.T) {
 	ctx := t.Context()
 	t.Run("success", func(t *testing.T) {
 		//given
-		cr := &k8sCRLib.DebugMode{}
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{UID: "uid-1"}}
 		cm := &corev1.ConfigMap{
 			Data: map[string]string{
 				"dogu.keyInfo": "info",
 			},
 		}
 		configMapInterface := newMockConfigurationMap(t)
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
-		stateMap := NewStateMap(ctx, cr, configMapInterface)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(cm, nil)
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
+		require.NoError(t, err)
 
 		//when
 		current := stateMap.getValueFromMap("dogu.keyNotFound")
@@ -221,23 +460,23 @@ func Test_StateMap_updateStateMap(t *testing.T) {
 	ctx := t.Context()
 	t.Run("success", func(t *testing.T) {
 		//given
-		cr := &k8sCRLib.DebugMode{}
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{UID: "uid-1"}}
 		cm := &corev1.ConfigMap{
 			Data: map[string]string{
 				"dogu.key1": "info",
 			},
 		}
 		configMapInterface := newMockConfigurationMap(t)
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
-		stateMap := NewStateMap(ctx, cr, configMapInterface)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(cm, nil)
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
+		require.NoError(t, err)
 
-		configMapInterface.EXPECT().Update(ctx, stateMap.configMap, metav1.UpdateOptions{}).RunAndReturn(
-			func(ctx context.Context, configMap *corev1.ConfigMap, options metav1.UpdateOptions) (*corev1.ConfigMap, error) {
-				return configMap, nil
-			})
+		expected := cm.DeepCopy()
+		expected.Data["dogu.key1"] = "warn"
+		configMapInterface.EXPECT().Update(ctx, expected, metav1.UpdateOptions{}).Return(expected, nil)
 
 		// when
-		err := stateMap.updateStateMap(ctx, "dogu.key1", "warn")
+		err = stateMap.updateStateMap(ctx, "dogu.key1", "warn")
 
 		// then
 		assert.NoError(t, err)
@@ -245,41 +484,246 @@ func Test_StateMap_updateStateMap(t *testing.T) {
 	})
 	t.Run("success no data", func(t *testing.T) {
 		//given
-		cr := &k8sCRLib.DebugMode{}
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{UID: "uid-1"}}
 		cm := &corev1.ConfigMap{}
 		configMapInterface := newMockConfigurationMap(t)
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
-		stateMap := NewStateMap(ctx, cr, configMapInterface)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(cm, nil)
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
+		require.NoError(t, err)
 
-		configMapInterface.EXPECT().Update(ctx, stateMap.configMap, metav1.UpdateOptions{}).RunAndReturn(
-			func(ctx context.Context, configMap *corev1.ConfigMap, options metav1.UpdateOptions) (*corev1.ConfigMap, error) {
-				return configMap, nil
-			})
+		expected := &corev1.ConfigMap{Data: map[string]string{"dogu.key1": "warn"}}
+		configMapInterface.EXPECT().Update(ctx, expected, metav1.UpdateOptions{}).Return(expected, nil)
 
 		// when
-		err := stateMap.updateStateMap(ctx, "dogu.key1", "warn")
+		err = stateMap.updateStateMap(ctx, "dogu.key1", "warn")
 
 		// then
 		assert.NoError(t, err)
 		assert.Equal(t, "warn", stateMap.configMap.Data["dogu.key1"])
 	})
-	t.Run("error on update", func(t *testing.T) {
+	t.Run("error on update keeps previous map", func(t *testing.T) {
 		//given
-		cr := &k8sCRLib.DebugMode{}
-		cm := &corev1.ConfigMap{}
+		cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{UID: "uid-1"}}
+		cm := &corev1.ConfigMap{Data: map[string]string{"dogu.key0": "info"}}
 		configMapInterface := newMockConfigurationMap(t)
-		configMapInterface.EXPECT().Get(ctx, "debugmode-state", metav1.GetOptions{}).Return(cm, nil)
-		stateMap := NewStateMap(ctx, cr, configMapInterface)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(cm, nil)
+		stateMap, err := NewStateMap(ctx, cr.Name, cr, configMapInterface)
+		require.NoError(t, err)
 
-		configMapInterface.EXPECT().Update(ctx, stateMap.configMap, metav1.UpdateOptions{}).RunAndReturn(
-			func(ctx context.Context, configMap *corev1.ConfigMap, options metav1.UpdateOptions) (*corev1.ConfigMap, error) {
-				return nil, assert.AnError
-			})
+		configMapInterface.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
 
 		// when
-		err := stateMap.updateStateMap(ctx, "dogu.key1", "warn")
+		err = stateMap.updateStateMap(ctx, "dogu.key1", "warn")
 
 		// then
-		assert.Error(t, err)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		require.NotNil(t, stateMap.configMap)
+		assert.Equal(t, "info", stateMap.getValueFromMap("dogu.key0"))
+		assert.Equal(t, "", stateMap.getValueFromMap("dogu.key1"))
+	})
+}
+
+func Test_StateMap_updateStateMapEntries(t *testing.T) {
+	ctx := t.Context()
+	conflictErr := errors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "debugmode-state", assert.AnError)
+
+	t.Run("should write all entries with a single update", func(t *testing.T) {
+		//given
+		cm := &corev1.ConfigMap{Data: map[string]string{"dogu.a": "INFO"}}
+		configMapInterface := newMockConfigurationMap(t)
+		stateMap := &StateMap{configMapInterface: configMapInterface, configMap: cm, logger: logging.FromContext(ctx)}
+
+		expected := &corev1.ConfigMap{Data: map[string]string{"dogu.a": "INFO", "dogu.b": "WARN", "dogu.c": "ERROR"}}
+		configMapInterface.EXPECT().Update(ctx, expected, metav1.UpdateOptions{}).Return(expected, nil).Once()
+
+		// when
+		err := stateMap.updateStateMapEntries(ctx, map[string]string{"dogu.b": "WARN", "dogu.c": "ERROR"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, expected, stateMap.configMap)
+		// the cached map must not be modified in place
+		assert.Equal(t, map[string]string{"dogu.a": "INFO"}, cm.Data)
+	})
+	t.Run("should do nothing without entries", func(t *testing.T) {
+		//given
+		configMapInterface := newMockConfigurationMap(t)
+		stateMap := &StateMap{configMapInterface: configMapInterface, configMap: &corev1.ConfigMap{}, logger: logging.FromContext(ctx)}
+
+		// when
+		err := stateMap.updateStateMapEntries(ctx, map[string]string{})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should re-read latest version on conflict and keep keys of previous reconciles", func(t *testing.T) {
+		//given
+		stale := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "1"}, Data: map[string]string{"dogu.a": "INFO"}}
+		configMapInterface := newMockConfigurationMap(t)
+		stateMap := &StateMap{configMapInterface: configMapInterface, configMap: stale, logger: logging.FromContext(ctx)}
+
+		staleUpdate := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "1"}, Data: map[string]string{"dogu.a": "INFO", "dogu.c": "DEBUG"}}
+		configMapInterface.EXPECT().Update(ctx, staleUpdate, metav1.UpdateOptions{}).Return(nil, conflictErr).Once()
+
+		latest := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "2"}, Data: map[string]string{"dogu.a": "INFO", "dogu.b": "WARN"}}
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(latest, nil).Once()
+
+		merged := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "2"}, Data: map[string]string{"dogu.a": "INFO", "dogu.b": "WARN", "dogu.c": "DEBUG"}}
+		configMapInterface.EXPECT().Update(ctx, merged, metav1.UpdateOptions{}).Return(merged, nil).Once()
+
+		// when
+		err := stateMap.updateStateMapEntries(ctx, map[string]string{"dogu.c": "DEBUG"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "WARN", stateMap.getValueFromMap("dogu.b"))
+		assert.Equal(t, "DEBUG", stateMap.getValueFromMap("dogu.c"))
+	})
+	t.Run("should keep entries another writer has captured on conflict", func(t *testing.T) {
+		//given
+		stale := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "1"}, Data: map[string]string{"dogu.a": "INFO"}}
+		configMapInterface := newMockConfigurationMap(t)
+		stateMap := &StateMap{configMapInterface: configMapInterface, configMap: stale, logger: logging.FromContext(ctx)}
+
+		configMapInterface.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, conflictErr).Once()
+
+		// the other writer captured the original before the log level was raised to the debug level
+		latest := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "2"}, Data: map[string]string{"dogu.a": "INFO", "dogu.b": "WARN"}}
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(latest, nil).Once()
+
+		merged := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "2"}, Data: map[string]string{"dogu.a": "INFO", "dogu.b": "WARN", "dogu.c": "ERROR"}}
+		configMapInterface.EXPECT().Update(ctx, merged, metav1.UpdateOptions{}).Return(merged, nil).Once()
+
+		// when
+		err := stateMap.updateStateMapEntries(ctx, map[string]string{"dogu.b": "DEBUG", "dogu.c": "ERROR"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errStateChangedConcurrently)
+		assert.ErrorContains(t, err, "dogu.b")
+		assert.Equal(t, "WARN", stateMap.getValueFromMap("dogu.b"))
+		assert.Equal(t, "ERROR", stateMap.getValueFromMap("dogu.c"))
+	})
+	t.Run("should overwrite entries unchanged by another writer on conflict", func(t *testing.T) {
+		//given
+		stale := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "1"}, Data: map[string]string{"doguconfig.a": "v1"}}
+		configMapInterface := newMockConfigurationMap(t)
+		stateMap := &StateMap{configMapInterface: configMapInterface, configMap: stale, logger: logging.FromContext(ctx)}
+
+		configMapInterface.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, conflictErr).Once()
+		latest := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "2"}, Data: map[string]string{"doguconfig.a": "v1", "dogu.b": "WARN"}}
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(latest, nil).Once()
+		merged := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1", ResourceVersion: "2"}, Data: map[string]string{"doguconfig.a": "v2", "dogu.b": "WARN"}}
+		configMapInterface.EXPECT().Update(ctx, merged, metav1.UpdateOptions{}).Return(merged, nil).Once()
+
+		// when
+		err := stateMap.updateStateMapEntries(ctx, map[string]string{"doguconfig.a": "v2"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "v2", stateMap.getValueFromMap("doguconfig.a"))
+	})
+	t.Run("should fail on error re-reading the latest version", func(t *testing.T) {
+		//given
+		stale := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1"}, Data: map[string]string{"dogu.a": "INFO"}}
+		configMapInterface := newMockConfigurationMap(t)
+		stateMap := &StateMap{configMapInterface: configMapInterface, configMap: stale, logger: logging.FromContext(ctx)}
+
+		configMapInterface.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, conflictErr).Once()
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(nil, assert.AnError).Once()
+
+		// when
+		err := stateMap.updateStateMapEntries(ctx, map[string]string{"dogu.c": "DEBUG"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Same(t, stale, stateMap.configMap)
+	})
+	t.Run("should give up after repeated conflicts", func(t *testing.T) {
+		//given
+		stale := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "debugmode-state-uid-1"}, Data: map[string]string{"dogu.a": "INFO"}}
+		configMapInterface := newMockConfigurationMap(t)
+		stateMap := &StateMap{configMapInterface: configMapInterface, configMap: stale, logger: logging.FromContext(ctx)}
+
+		configMapInterface.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, conflictErr)
+		configMapInterface.EXPECT().Get(ctx, "debugmode-state-uid-1", metav1.GetOptions{}).Return(stale.DeepCopy(), nil)
+
+		// when
+		err := stateMap.updateStateMapEntries(ctx, map[string]string{"dogu.c": "DEBUG"})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, errors.IsConflict(err))
+		assert.Same(t, stale, stateMap.configMap)
+	})
+}
+
+func Test_StateMap_getEntry(t *testing.T) {
+	t.Run("should read entries written by different operator versions", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		structured, err := newStateEntry("ERROR", false, "ERROR", "2.0.0-1").marshal()
+		require.NoError(t, err)
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{
+			"dogu.cas":  "WARN",
+			"dogu.ldap": structured,
+		}}}
+
+		// when
+		legacyEntry, legacyFound, legacyErr := stateMap.getEntry("dogu.cas")
+		entry, found, err := stateMap.getEntry("dogu.ldap")
+		_, missingFound, missingErr := stateMap.getEntry("dogu.redmine")
+
+		// then
+		require.NoError(t, legacyErr)
+		assert.True(t, legacyFound)
+		assert.Equal(t, "WARN", legacyEntry.Level)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "ERROR", entry.Level)
+		assert.Equal(t, "2.0.0-1", entry.DoguVersion)
+		require.NoError(t, missingErr)
+		assert.False(t, missingFound)
+	})
+	t.Run("should fail on corrupt entry", func(t *testing.T) {
+		// given
+		stateMap := &StateMap{configMap: &corev1.ConfigMap{Data: map[string]string{
+			"dogu.cas": `{"version":1,"level":"WARN","checksum":"invalid"}`,
+		}}}
+
+		// when
+		_, found, err := stateMap.getEntry("dogu.cas")
+
+		// then
+		assert.True(t, found)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errStateEntryChecksum)
+	})
+}
+
+func Test_StateMap_storeEntries(t *testing.T) {
+	ctx := t.Context()
+	t.Run("should store serialized entries", func(t *testing.T) {
+		// given
+		fixTimeNow(t)
+		entry := newStateEntry("INFO", false, "INFO", "1.0.0-1")
+		serialized, err := entry.marshal()
+		require.NoError(t, err)
+		configMapInterface := newMockConfigurationMap(t)
+		stateMap := &StateMap{configMapInterface: configMapInterface, configMap: &corev1.ConfigMap{}, logger: logging.FromContext(ctx)}
+		expected := &corev1.ConfigMap{Data: map[string]string{"dogu.cas": serialized}}
+		configMapInterface.EXPECT().Update(ctx, expected, metav1.UpdateOptions{}).Return(expected, nil)
+
+		// when
+		err = stateMap.storeEntries(ctx, map[string]StateEntry{"dogu.cas": entry})
+
+		// then
+		require.NoError(t, err)
+		stored, found, err := stateMap.getEntry("dogu.cas")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, entry, stored)
 	})
 }
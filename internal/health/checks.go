@@ -0,0 +1,41 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// registryTimeout limits the time a readiness probe waits for the dogu version registry.
+const registryTimeout = 5 * time.Second
+
+// CacheSynced returns a readiness check that fails until the informers of all given objects are synced.
+// Missing informers are created, so the check also fails if the CRD of an object is not installed.
+func CacheSynced(informers informerCache, objects ...client.Object) healthz.Checker {
+	return func(req *http.Request) error {
+		for _, obj := range objects {
+			informer, err := informers.GetInformer(req.Context(), obj, cache.BlockUntilSynced(false))
+			if err != nil {
+				return fmt.Errorf("failed to get informer for %T: %w", obj, err)
+			}
+			if !informer.HasSynced() {
+				return fmt.Errorf("informer for %T is not synced", obj)
+			}
+		}
+		return nil
+	}
+}
+
+// RegistryAccessible returns a readiness check that fails if the dogu version registry cannot be read.
+func RegistryAccessible(registry registryChecker) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), registryTimeout)
+		defer cancel()
+		return registry.CheckAccess(ctx)
+	}
+}
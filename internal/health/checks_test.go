@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheSynced(t *testing.T) {
+	t.Run("should succeed if all informers are synced", func(t *testing.T) {
+		// given
+		informers := newMockInformerCache(t)
+		debugModes := newMockInformer(t)
+		dogus := newMockInformer(t)
+		informers.EXPECT().GetInformer(mock.Anything, &k8sCRLib.DebugMode{}, mock.Anything).Return(debugModes, nil)
+		informers.EXPECT().GetInformer(mock.Anything, &v2.Dogu{}, mock.Anything).Return(dogus, nil)
+		debugModes.EXPECT().HasSynced().Return(true)
+		dogus.EXPECT().HasSynced().Return(true)
+
+		// when
+		err := CacheSynced(informers, &k8sCRLib.DebugMode{}, &v2.Dogu{})(httptest.NewRequest("GET", "/readyz", nil))
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail if an informer is not synced", func(t *testing.T) {
+		// given
+		informers := newMockInformerCache(t)
+		dogus := newMockInformer(t)
+		informers.EXPECT().GetInformer(mock.Anything, &v2.Dogu{}, mock.Anything).Return(dogus, nil)
+		dogus.EXPECT().HasSynced().Return(false)
+
+		// when
+		err := CacheSynced(informers, &v2.Dogu{})(httptest.NewRequest("GET", "/readyz", nil))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "informer for *v2.Dogu is not synced")
+	})
+	t.Run("should fail if the informer cannot be created, e.g. because the CRD is missing", func(t *testing.T) {
+		// given
+		informers := newMockInformerCache(t)
+		informers.EXPECT().GetInformer(mock.Anything, &k8sCRLib.DebugMode{}, mock.Anything).Return(nil, assert.AnError)
+
+		// when
+		err := CacheSynced(informers, &k8sCRLib.DebugMode{})(httptest.NewRequest("GET", "/readyz", nil))
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestRegistryAccessible(t *testing.T) {
+	t.Run("should succeed if the registry is accessible", func(t *testing.T) {
+		// given
+		registry := newMockRegistryChecker(t)
+		registry.EXPECT().CheckAccess(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+			deadline, found := ctx.Deadline()
+			assert.True(t, found)
+			assert.WithinDuration(t, time.Now().Add(registryTimeout), deadline, time.Second)
+			return nil
+		})
+
+		// when
+		err := RegistryAccessible(registry)(httptest.NewRequest("GET", "/readyz", nil))
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should fail if the registry is not accessible", func(t *testing.T) {
+		registry := newMockRegistryChecker(t)
+		registry.EXPECT().CheckAccess(mock.Anything).Return(assert.AnError)
+
+		err := RegistryAccessible(registry)(httptest.NewRequest("GET", "/readyz", nil))
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,24 @@
+package health
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// informerCache provides the informers of the manager cache.
+type informerCache interface {
+	GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error)
+}
+
+//nolint:unused
+//goland:noinspection GoUnusedType
+type informer interface {
+	cache.Informer
+}
+
+// registryChecker checks the access to the dogu version registry.
+type registryChecker interface {
+	CheckAccess(ctx context.Context) error
+}
@@ -0,0 +1,113 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package health
+
+import (
+	cache "sigs.k8s.io/controller-runtime/pkg/cache"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockInformerCache is an autogenerated mock type for the informerCache type
+type mockInformerCache struct {
+	mock.Mock
+}
+
+type mockInformerCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockInformerCache) EXPECT() *mockInformerCache_Expecter {
+	return &mockInformerCache_Expecter{mock: &_m.Mock}
+}
+
+// GetInformer provides a mock function with given fields: ctx, obj, opts
+func (_m *mockInformerCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, obj)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInformer")
+	}
+
+	var r0 cache.Informer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.Object, ...cache.InformerGetOption) (cache.Informer, error)); ok {
+		return rf(ctx, obj, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.Object, ...cache.InformerGetOption) cache.Informer); ok {
+		r0 = rf(ctx, obj, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cache.Informer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.Object, ...cache.InformerGetOption) error); ok {
+		r1 = rf(ctx, obj, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockInformerCache_GetInformer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInformer'
+type mockInformerCache_GetInformer_Call struct {
+	*mock.Call
+}
+
+// GetInformer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - obj client.Object
+//   - opts ...cache.InformerGetOption
+func (_e *mockInformerCache_Expecter) GetInformer(ctx interface{}, obj interface{}, opts ...interface{}) *mockInformerCache_GetInformer_Call {
+	return &mockInformerCache_GetInformer_Call{Call: _e.mock.On("GetInformer",
+		append([]interface{}{ctx, obj}, opts...)...)}
+}
+
+func (_c *mockInformerCache_GetInformer_Call) Run(run func(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption)) *mockInformerCache_GetInformer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]cache.InformerGetOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(cache.InformerGetOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(client.Object), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockInformerCache_GetInformer_Call) Return(_a0 cache.Informer, _a1 error) *mockInformerCache_GetInformer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockInformerCache_GetInformer_Call) RunAndReturn(run func(context.Context, client.Object, ...cache.InformerGetOption) (cache.Informer, error)) *mockInformerCache_GetInformer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockInformerCache creates a new instance of mockInformerCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockInformerCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockInformerCache {
+	mock := &mockInformerCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,395 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package health
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	cache "k8s.io/client-go/tools/cache"
+
+	time "time"
+)
+
+// mockInformer is an autogenerated mock type for the informer type
+type mockInformer struct {
+	mock.Mock
+}
+
+type mockInformer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockInformer) EXPECT() *mockInformer_Expecter {
+	return &mockInformer_Expecter{mock: &_m.Mock}
+}
+
+// AddEventHandler provides a mock function with given fields: handler
+func (_m *mockInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	ret := _m.Called(handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEventHandler")
+	}
+
+	var r0 cache.ResourceEventHandlerRegistration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error)); ok {
+		return rf(handler)
+	}
+	if rf, ok := ret.Get(0).(func(cache.ResourceEventHandler) cache.ResourceEventHandlerRegistration); ok {
+		r0 = rf(handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cache.ResourceEventHandlerRegistration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(cache.ResourceEventHandler) error); ok {
+		r1 = rf(handler)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockInformer_AddEventHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddEventHandler'
+type mockInformer_AddEventHandler_Call struct {
+	*mock.Call
+}
+
+// AddEventHandler is a helper method to define mock.On call
+//   - handler cache.ResourceEventHandler
+func (_e *mockInformer_Expecter) AddEventHandler(handler interface{}) *mockInformer_AddEventHandler_Call {
+	return &mockInformer_AddEventHandler_Call{Call: _e.mock.On("AddEventHandler", handler)}
+}
+
+func (_c *mockInformer_AddEventHandler_Call) Run(run func(handler cache.ResourceEventHandler)) *mockInformer_AddEventHandler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(cache.ResourceEventHandler))
+	})
+	return _c
+}
+
+func (_c *mockInformer_AddEventHandler_Call) Return(_a0 cache.ResourceEventHandlerRegistration, _a1 error) *mockInformer_AddEventHandler_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockInformer_AddEventHandler_Call) RunAndReturn(run func(cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error)) *mockInformer_AddEventHandler_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddEventHandlerWithOptions provides a mock function with given fields: handler, options
+func (_m *mockInformer) AddEventHandlerWithOptions(handler cache.ResourceEventHandler, options cache.HandlerOptions) (cache.ResourceEventHandlerRegistration, error) {
+	ret := _m.Called(handler, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEventHandlerWithOptions")
+	}
+
+	var r0 cache.ResourceEventHandlerRegistration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(cache.ResourceEventHandler, cache.HandlerOptions) (cache.ResourceEventHandlerRegistration, error)); ok {
+		return rf(handler, options)
+	}
+	if rf, ok := ret.Get(0).(func(cache.ResourceEventHandler, cache.HandlerOptions) cache.ResourceEventHandlerRegistration); ok {
+		r0 = rf(handler, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cache.ResourceEventHandlerRegistration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(cache.ResourceEventHandler, cache.HandlerOptions) error); ok {
+		r1 = rf(handler, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockInformer_AddEventHandlerWithOptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddEventHandlerWithOptions'
+type mockInformer_AddEventHandlerWithOptions_Call struct {
+	*mock.Call
+}
+
+// AddEventHandlerWithOptions is a helper method to define mock.On call
+//   - handler cache.ResourceEventHandler
+//   - options cache.HandlerOptions
+func (_e *mockInformer_Expecter) AddEventHandlerWithOptions(handler interface{}, options interface{}) *mockInformer_AddEventHandlerWithOptions_Call {
+	return &mockInformer_AddEventHandlerWithOptions_Call{Call: _e.mock.On("AddEventHandlerWithOptions", handler, options)}
+}
+
+func (_c *mockInformer_AddEventHandlerWithOptions_Call) Run(run func(handler cache.ResourceEventHandler, options cache.HandlerOptions)) *mockInformer_AddEventHandlerWithOptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(cache.ResourceEventHandler), args[1].(cache.HandlerOptions))
+	})
+	return _c
+}
+
+func (_c *mockInformer_AddEventHandlerWithOptions_Call) Return(_a0 cache.ResourceEventHandlerRegistration, _a1 error) *mockInformer_AddEventHandlerWithOptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockInformer_AddEventHandlerWithOptions_Call) RunAndReturn(run func(cache.ResourceEventHandler, cache.HandlerOptions) (cache.ResourceEventHandlerRegistration, error)) *mockInformer_AddEventHandlerWithOptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddEventHandlerWithResyncPeriod provides a mock function with given fields: handler, resyncPeriod
+func (_m *mockInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) (cache.ResourceEventHandlerRegistration, error) {
+	ret := _m.Called(handler, resyncPeriod)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEventHandlerWithResyncPeriod")
+	}
+
+	var r0 cache.ResourceEventHandlerRegistration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(cache.ResourceEventHandler, time.Duration) (cache.ResourceEventHandlerRegistration, error)); ok {
+		return rf(handler, resyncPeriod)
+	}
+	if rf, ok := ret.Get(0).(func(cache.ResourceEventHandler, time.Duration) cache.ResourceEventHandlerRegistration); ok {
+		r0 = rf(handler, resyncPeriod)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cache.ResourceEventHandlerRegistration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(cache.ResourceEventHandler, time.Duration) error); ok {
+		r1 = rf(handler, resyncPeriod)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockInformer_AddEventHandlerWithResyncPeriod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddEventHandlerWithResyncPeriod'
+type mockInformer_AddEventHandlerWithResyncPeriod_Call struct {
+	*mock.Call
+}
+
+// AddEventHandlerWithResyncPeriod is a helper method to define mock.On call
+//   - handler cache.ResourceEventHandler
+//   - resyncPeriod time.Duration
+func (_e *mockInformer_Expecter) AddEventHandlerWithResyncPeriod(handler interface{}, resyncPeriod interface{}) *mockInformer_AddEventHandlerWithResyncPeriod_Call {
+	return &mockInformer_AddEventHandlerWithResyncPeriod_Call{Call: _e.mock.On("AddEventHandlerWithResyncPeriod", handler, resyncPeriod)}
+}
+
+func (_c *mockInformer_AddEventHandlerWithResyncPeriod_Call) Run(run func(handler cache.ResourceEventHandler, resyncPeriod time.Duration)) *mockInformer_AddEventHandlerWithResyncPeriod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(cache.ResourceEventHandler), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *mockInformer_AddEventHandlerWithResyncPeriod_Call) Return(_a0 cache.ResourceEventHandlerRegistration, _a1 error) *mockInformer_AddEventHandlerWithResyncPeriod_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockInformer_AddEventHandlerWithResyncPeriod_Call) RunAndReturn(run func(cache.ResourceEventHandler, time.Duration) (cache.ResourceEventHandlerRegistration, error)) *mockInformer_AddEventHandlerWithResyncPeriod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddIndexers provides a mock function with given fields: indexers
+func (_m *mockInformer) AddIndexers(indexers cache.Indexers) error {
+	ret := _m.Called(indexers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddIndexers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(cache.Indexers) error); ok {
+		r0 = rf(indexers)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockInformer_AddIndexers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddIndexers'
+type mockInformer_AddIndexers_Call struct {
+	*mock.Call
+}
+
+// AddIndexers is a helper method to define mock.On call
+//   - indexers cache.Indexers
+func (_e *mockInformer_Expecter) AddIndexers(indexers interface{}) *mockInformer_AddIndexers_Call {
+	return &mockInformer_AddIndexers_Call{Call: _e.mock.On("AddIndexers", indexers)}
+}
+
+func (_c *mockInformer_AddIndexers_Call) Run(run func(indexers cache.Indexers)) *mockInformer_AddIndexers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(cache.Indexers))
+	})
+	return _c
+}
+
+func (_c *mockInformer_AddIndexers_Call) Return(_a0 error) *mockInformer_AddIndexers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockInformer_AddIndexers_Call) RunAndReturn(run func(cache.Indexers) error) *mockInformer_AddIndexers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasSynced provides a mock function with no fields
+func (_m *mockInformer) HasSynced() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasSynced")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// mockInformer_HasSynced_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasSynced'
+type mockInformer_HasSynced_Call struct {
+	*mock.Call
+}
+
+// HasSynced is a helper method to define mock.On call
+func (_e *mockInformer_Expecter) HasSynced() *mockInformer_HasSynced_Call {
+	return &mockInformer_HasSynced_Call{Call: _e.mock.On("HasSynced")}
+}
+
+func (_c *mockInformer_HasSynced_Call) Run(run func()) *mockInformer_HasSynced_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockInformer_HasSynced_Call) Return(_a0 bool) *mockInformer_HasSynced_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockInformer_HasSynced_Call) RunAndReturn(run func() bool) *mockInformer_HasSynced_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsStopped provides a mock function with no fields
+func (_m *mockInformer) IsStopped() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsStopped")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// mockInformer_IsStopped_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsStopped'
+type mockInformer_IsStopped_Call struct {
+	*mock.Call
+}
+
+// IsStopped is a helper method to define mock.On call
+func (_e *mockInformer_Expecter) IsStopped() *mockInformer_IsStopped_Call {
+	return &mockInformer_IsStopped_Call{Call: _e.mock.On("IsStopped")}
+}
+
+func (_c *mockInformer_IsStopped_Call) Run(run func()) *mockInformer_IsStopped_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockInformer_IsStopped_Call) Return(_a0 bool) *mockInformer_IsStopped_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockInformer_IsStopped_Call) RunAndReturn(run func() bool) *mockInformer_IsStopped_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveEventHandler provides a mock function with given fields: handle
+func (_m *mockInformer) RemoveEventHandler(handle cache.ResourceEventHandlerRegistration) error {
+	ret := _m.Called(handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveEventHandler")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(cache.ResourceEventHandlerRegistration) error); ok {
+		r0 = rf(handle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockInformer_RemoveEventHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveEventHandler'
+type mockInformer_RemoveEventHandler_Call struct {
+	*mock.Call
+}
+
+// RemoveEventHandler is a helper method to define mock.On call
+//   - handle cache.ResourceEventHandlerRegistration
+func (_e *mockInformer_Expecter) RemoveEventHandler(handle interface{}) *mockInformer_RemoveEventHandler_Call {
+	return &mockInformer_RemoveEventHandler_Call{Call: _e.mock.On("RemoveEventHandler", handle)}
+}
+
+func (_c *mockInformer_RemoveEventHandler_Call) Run(run func(handle cache.ResourceEventHandlerRegistration)) *mockInformer_RemoveEventHandler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(cache.ResourceEventHandlerRegistration))
+	})
+	return _c
+}
+
+func (_c *mockInformer_RemoveEventHandler_Call) Return(_a0 error) *mockInformer_RemoveEventHandler_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockInformer_RemoveEventHandler_Call) RunAndReturn(run func(cache.ResourceEventHandlerRegistration) error) *mockInformer_RemoveEventHandler_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockInformer creates a new instance of mockInformer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockInformer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockInformer {
+	mock := &mockInformer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package health
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockRegistryChecker is an autogenerated mock type for the registryChecker type
+type mockRegistryChecker struct {
+	mock.Mock
+}
+
+type mockRegistryChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockRegistryChecker) EXPECT() *mockRegistryChecker_Expecter {
+	return &mockRegistryChecker_Expecter{mock: &_m.Mock}
+}
+
+// CheckAccess provides a mock function with given fields: ctx
+func (_m *mockRegistryChecker) CheckAccess(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockRegistryChecker_CheckAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckAccess'
+type mockRegistryChecker_CheckAccess_Call struct {
+	*mock.Call
+}
+
+// CheckAccess is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockRegistryChecker_Expecter) CheckAccess(ctx interface{}) *mockRegistryChecker_CheckAccess_Call {
+	return &mockRegistryChecker_CheckAccess_Call{Call: _e.mock.On("CheckAccess", ctx)}
+}
+
+func (_c *mockRegistryChecker_CheckAccess_Call) Run(run func(ctx context.Context)) *mockRegistryChecker_CheckAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockRegistryChecker_CheckAccess_Call) Return(_a0 error) *mockRegistryChecker_CheckAccess_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockRegistryChecker_CheckAccess_Call) RunAndReturn(run func(context.Context) error) *mockRegistryChecker_CheckAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockRegistryChecker creates a new instance of mockRegistryChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockRegistryChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockRegistryChecker {
+	mock := &mockRegistryChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
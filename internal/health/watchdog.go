@@ -0,0 +1,91 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultReconcileDeadline is the default time a reconcile may be late. It must be longer than the maximum delay of
+// 1000 seconds between the retries of a failing reconcile.
+const DefaultReconcileDeadline = 20 * time.Minute
+
+// Watchdog is a liveness check detecting a stuck reconciler: a reconcile running longer than the deadline or a
+// requeued reconcile that has not started within the deadline after it was due. Every DebugMode is tracked on its own,
+// so the reconciles of DebugModes in different namespaces do not hide each other. Without a pending requeue, e.g. if
+// no debug mode is active or the replica is not the leader, the check always succeeds.
+type Watchdog struct {
+	mutex    sync.Mutex
+	deadline time.Duration
+	now      func() time.Time
+	// runningSince contains the start of the running reconcile of each DebugMode.
+	runningSince map[types.NamespacedName]time.Time
+	// dueAt contains the time the next reconcile of each DebugMode is expected at.
+	dueAt map[types.NamespacedName]time.Time
+}
+
+// NewWatchdog creates a watchdog allowing reconciles to be late for the given deadline.
+func NewWatchdog(deadline time.Duration) *Watchdog {
+	return &Watchdog{
+		deadline:     deadline,
+		now:          time.Now,
+		runningSince: map[types.NamespacedName]time.Time{},
+		dueAt:        map[types.NamespacedName]time.Time{},
+	}
+}
+
+// Started records the start of a reconcile of the given DebugMode.
+func (w *Watchdog) Started(key types.NamespacedName) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.runningSince[key] = w.now()
+}
+
+// Finished records the end of a reconcile of the given DebugMode and when the next one is expected.
+func (w *Watchdog) Finished(key types.NamespacedName, result ctrl.Result, err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	now := w.now()
+	delete(w.runningSince, key)
+	switch {
+	case err != nil:
+		// failed reconciles are retried with a backoff, which is covered by the deadline
+		w.dueAt[key] = now
+	case result.RequeueAfter > 0:
+		w.dueAt[key] = now.Add(result.RequeueAfter)
+	default:
+		delete(w.dueAt, key)
+	}
+}
+
+// Check returns an error if the reconciler is stuck.
+func (w *Watchdog) Check(_ *http.Request) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	now := w.now()
+	var errs []error
+	for _, key := range slices.SortedFunc(maps.Keys(w.runningSince), compareKeys) {
+		if since := w.runningSince[key]; now.Sub(since) > w.deadline {
+			errs = append(errs, fmt.Errorf("reconcile of %s running since %s", key, since.Format(time.RFC3339)))
+		}
+	}
+	for _, key := range slices.SortedFunc(maps.Keys(w.dueAt), compareKeys) {
+		_, running := w.runningSince[key]
+		if due := w.dueAt[key]; !running && now.Sub(due) > w.deadline {
+			errs = append(errs, fmt.Errorf("reconcile of %s due at %s has not started", key, due.Format(time.RFC3339)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func compareKeys(a, b types.NamespacedName) int {
+	return strings.Compare(a.String(), b.String())
+}
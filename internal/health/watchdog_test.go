@@ -0,0 +1,113 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var testKey = types.NamespacedName{Namespace: "ecosystem", Name: "debug-mode"}
+
+func newTestWatchdog() (*Watchdog, *time.Time) {
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	watchdog := NewWatchdog(time.Minute)
+	watchdog.now = func() time.Time { return now }
+	return watchdog, &now
+}
+
+func TestWatchdog_Check(t *testing.T) {
+	t.Run("should succeed without reconcile", func(t *testing.T) {
+		watchdog, _ := newTestWatchdog()
+
+		require.NoError(t, watchdog.Check(nil))
+	})
+	t.Run("should succeed without pending requeue", func(t *testing.T) {
+		// given
+		watchdog, now := newTestWatchdog()
+		watchdog.Started(testKey)
+		watchdog.Finished(testKey, ctrl.Result{}, nil)
+
+		// when
+		*now = now.Add(time.Hour)
+
+		// then
+		require.NoError(t, watchdog.Check(nil))
+	})
+	t.Run("should fail if a reconcile runs longer than the deadline", func(t *testing.T) {
+		// given
+		watchdog, now := newTestWatchdog()
+		watchdog.Started(testKey)
+
+		// when
+		*now = now.Add(30 * time.Second)
+		require.NoError(t, watchdog.Check(nil))
+		*now = now.Add(time.Minute)
+		err := watchdog.Check(nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "reconcile of ecosystem/debug-mode running since 2026-01-01T10:00:00Z")
+	})
+	t.Run("should fail if a requeued reconcile is overdue", func(t *testing.T) {
+		// given
+		watchdog, now := newTestWatchdog()
+		watchdog.Started(testKey)
+		watchdog.Finished(testKey, ctrl.Result{RequeueAfter: time.Hour}, nil)
+
+		// when
+		*now = now.Add(time.Hour + 30*time.Second)
+		require.NoError(t, watchdog.Check(nil))
+		*now = now.Add(time.Minute)
+		err := watchdog.Check(nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "reconcile of ecosystem/debug-mode due at 2026-01-01T11:00:00Z has not started")
+	})
+	t.Run("should expect a retry after a failed reconcile", func(t *testing.T) {
+		// given
+		watchdog, now := newTestWatchdog()
+		watchdog.Started(testKey)
+		watchdog.Finished(testKey, ctrl.Result{}, assert.AnError)
+
+		// when
+		*now = now.Add(2 * time.Minute)
+
+		// then
+		require.Error(t, watchdog.Check(nil))
+	})
+	t.Run("should succeed while the due reconcile runs", func(t *testing.T) {
+		// given
+		watchdog, now := newTestWatchdog()
+		watchdog.Finished(testKey, ctrl.Result{RequeueAfter: time.Minute}, nil)
+
+		// when
+		*now = now.Add(2*time.Minute + 30*time.Second)
+		watchdog.Started(testKey)
+
+		// then
+		require.NoError(t, watchdog.Check(nil))
+	})
+	t.Run("should track the debug modes of different namespaces separately", func(t *testing.T) {
+		// given
+		watchdog, now := newTestWatchdog()
+		otherKey := types.NamespacedName{Namespace: "ecosystem-2", Name: "debug-mode"}
+		watchdog.Started(testKey)
+		watchdog.Finished(testKey, ctrl.Result{RequeueAfter: time.Minute}, nil)
+		watchdog.Started(otherKey)
+		watchdog.Finished(otherKey, ctrl.Result{}, nil)
+
+		// when
+		*now = now.Add(3 * time.Minute)
+		err := watchdog.Check(nil)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "reconcile of ecosystem/debug-mode due at")
+		assert.NotContains(t, err.Error(), "ecosystem-2")
+	})
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"github.com/go-logr/logr"
 	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"os"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"strings"
 )
 
 type Logger struct {
@@ -21,6 +23,21 @@ func FromContext(ctx context.Context) Logger {
 	}
 }
 
+// IntoContext returns a context carrying the logger, so all functions called with it log with the same values.
+func IntoContext(ctx context.Context, l Logger) context.Context {
+	return logf.IntoContext(ctx, l.logger)
+}
+
+// WithValues returns a logger adding the given key/value pairs to every message, e.g. "dogu", "nexus".
+func (l Logger) WithValues(keysAndValues ...any) Logger {
+	return Logger{logger: l.logger.WithValues(keysAndValues...)}
+}
+
+// WithName returns a logger appending the given name to the name of the logger.
+func (l Logger) WithName(name string) Logger {
+	return Logger{logger: l.logger.WithName(name)}
+}
+
 func (l Logger) Info(msg string, keysAndValues ...any) {
 	l.logger.Info(msg, keysAndValues...)
 }
@@ -33,35 +50,49 @@ func (l Logger) Debug(msg string, keysAndValues ...any) {
 	l.logger.V(-1).GetSink().Info(1, msg, keysAndValues...)
 }
 
-func ConfigureLogger() {
+const stageDevelopment = "development"
+
+// operatorLevel is shared by all loggers configured by ConfigureLogger.
+var operatorLevel = NewOperatorLevel(uberzap.InfoLevel)
+
+// ConfigureLogger configures the logger of the operator and returns its runtime adjustable log level.
+func ConfigureLogger() *OperatorLevel {
 	zapOpts := getZapOptions()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&zapOpts)))
+	return operatorLevel
 }
 
 func getZapOptions() zap.Options {
-	var logLevel uberzap.AtomicLevel
+	logLevel := uberzap.InfoLevel
 	envLogLevel, err := GetLogLevel()
 	if err != nil {
 		fmt.Printf("unable to get configured log level. using info level instead.\n  %s\n", err.Error())
-		logLevel = uberzap.NewAtomicLevelAt(uberzap.InfoLevel)
 	} else {
-		logLevel, err = uberzap.ParseAtomicLevel(envLogLevel)
+		logLevel, err = zapcore.ParseLevel(envLogLevel)
 		if err != nil {
 			fmt.Printf("error parsing configured log level. using info level instead.\n  %s\n", err.Error())
-			logLevel = uberzap.NewAtomicLevelAt(uberzap.InfoLevel)
+			logLevel = uberzap.InfoLevel
 		}
 	}
+	operatorLevel.SetBase(logLevel)
 
 	zapOpts := zap.Options{
 		Development: IsStageDevelopment(),
-		Level:       logLevel,
+		Level:       operatorLevel.atomic,
+	}
+	if zapOpts.Development {
+		zap.ConsoleEncoder()(&zapOpts)
+	} else {
+		zap.JSONEncoder()(&zapOpts)
 	}
 	return zapOpts
 }
 
+// IsStageDevelopment returns true if the environment variable STAGE is set to development.
 func IsStageDevelopment() bool {
-	return true
+	stage, _ := os.LookupEnv("STAGE")
+	return strings.EqualFold(strings.TrimSpace(stage), stageDevelopment)
 }
 
 func GetLogLevel() (string, error) {
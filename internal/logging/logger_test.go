@@ -2,6 +2,7 @@ package logging
 
 import (
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
 	"os"
 	"testing"
 )
@@ -33,3 +34,53 @@ func Test_Logger_Log(t *testing.T) {
 		logger.Info("This is invalid")
 	})
 }
+
+func Test_IsStageDevelopment(t *testing.T) {
+	t.Run("should be development", func(t *testing.T) {
+		t.Setenv("STAGE", "development")
+		assert.True(t, IsStageDevelopment())
+	})
+	t.Run("should be production", func(t *testing.T) {
+		t.Setenv("STAGE", "production")
+		assert.False(t, IsStageDevelopment())
+	})
+}
+
+func Test_ConfigureLogger(t *testing.T) {
+	t.Run("should configure level from env", func(t *testing.T) {
+		// given
+		t.Setenv("LOG_LEVEL", "warn")
+		t.Setenv("STAGE", "production")
+
+		// when
+		level := ConfigureLogger()
+
+		// then
+		assert.Equal(t, zapcore.WarnLevel, level.Level())
+	})
+	t.Run("should use info level on invalid env", func(t *testing.T) {
+		// given
+		t.Setenv("LOG_LEVEL", "invalid")
+
+		// when
+		level := ConfigureLogger()
+
+		// then
+		assert.Equal(t, zapcore.InfoLevel, level.Level())
+	})
+}
+
+func Test_Logger_WithValues(t *testing.T) {
+	t.Run("should keep values in context", func(t *testing.T) {
+		// given
+		ConfigureLogger()
+		logger := FromContext(t.Context()).WithName("test").WithValues("dogu", "nexus")
+
+		// when
+		ctx := IntoContext(t.Context(), logger)
+
+		// then
+		assert.Equal(t, logger, FromContext(ctx))
+		FromContext(ctx).Info("This is Info with values", "level", "DEBUG")
+	})
+}
@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// OperatorLevel is the log level of the operator itself. It can be changed at runtime without restarting the operator.
+// The effective level is the most verbose one of the configured base level and the levels raised temporarily,
+// e.g. while debug modes are active.
+type OperatorLevel struct {
+	mu     sync.Mutex
+	atomic uberzap.AtomicLevel
+	base   zapcore.Level
+	// raised contains the temporarily raised level of each source.
+	raised map[string]zapcore.Level
+}
+
+func NewOperatorLevel(base zapcore.Level) *OperatorLevel {
+	return &OperatorLevel{
+		atomic: uberzap.NewAtomicLevelAt(base),
+		base:   base,
+		raised: map[string]zapcore.Level{},
+	}
+}
+
+// Level returns the effective log level.
+func (l *OperatorLevel) Level() zapcore.Level {
+	return l.atomic.Level()
+}
+
+// SetBase sets the configured log level that applies if no level is raised.
+func (l *OperatorLevel) SetBase(level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.base = level
+	l.apply()
+}
+
+// Raise temporarily sets the given level if it is more verbose than the base level. It never lowers the verbosity.
+func (l *OperatorLevel) Raise(level zapcore.Level) {
+	l.raise("", level)
+}
+
+// Reset removes a raised level, so the base level applies again.
+func (l *OperatorLevel) Reset() {
+	l.reset("")
+}
+
+// Source returns a handle raising the level independently of other sources, e.g. for the debug modes of one of
+// several namespaces. A source resetting its level does not lower a level raised by another source.
+func (l *OperatorLevel) Source(name string) *OperatorLevelSource {
+	return &OperatorLevelSource{level: l, name: name}
+}
+
+func (l *OperatorLevel) raise(source string, level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.raised[source] = level
+	l.apply()
+}
+
+func (l *OperatorLevel) reset(source string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.raised, source)
+	l.apply()
+}
+
+func (l *OperatorLevel) apply() {
+	level := l.base
+	for _, raised := range l.raised {
+		level = min(level, raised)
+	}
+	l.atomic.SetLevel(level)
+}
+
+// OperatorLevelSource raises the log level of the operator on behalf of a single source.
+type OperatorLevelSource struct {
+	level *OperatorLevel
+	name  string
+}
+
+// Raise temporarily sets the given level for this source if it is more verbose than the base level.
+func (s *OperatorLevelSource) Raise(level zapcore.Level) {
+	s.level.raise(s.name, level)
+}
+
+// Reset removes the level raised by this source.
+func (s *OperatorLevelSource) Reset() {
+	s.level.reset(s.name)
+}
+
+type levelPayload struct {
+	Level zapcore.Level `json:"level"`
+	Base  zapcore.Level `json:"base"`
+}
+
+// ServeHTTP returns the effective and the base level on GET and sets the base level on PUT,
+// e.g. with the body {"level":"debug"}.
+func (l *OperatorLevel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var request struct {
+			Level *zapcore.Level `json:"level"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil || request.Level == nil {
+			http.Error(w, fmt.Sprintf("invalid log level: %v", err), http.StatusBadRequest)
+			return
+		}
+		l.SetBase(*request.Level)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "only GET and PUT are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	l.mu.Lock()
+	payload := levelPayload{Level: l.Level(), Base: l.base}
+	l.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+// ReadOnly returns a handler returning the levels like ServeHTTP, but refusing to change them. It is served instead
+// if requests are not authenticated.
+func (l *OperatorLevel) ReadOnly() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "changing the log level requires authenticated requests", http.StatusMethodNotAllowed)
+			return
+		}
+		l.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func Test_OperatorLevel(t *testing.T) {
+	t.Run("should raise and reset level", func(t *testing.T) {
+		// given
+		level := NewOperatorLevel(zapcore.InfoLevel)
+
+		// when
+		level.Raise(zapcore.DebugLevel)
+
+		// then
+		assert.Equal(t, zapcore.DebugLevel, level.Level())
+		level.Reset()
+		assert.Equal(t, zapcore.InfoLevel, level.Level())
+	})
+	t.Run("should never lower the base level", func(t *testing.T) {
+		// given
+		level := NewOperatorLevel(zapcore.DebugLevel)
+
+		// when
+		level.Raise(zapcore.ErrorLevel)
+
+		// then
+		assert.Equal(t, zapcore.DebugLevel, level.Level())
+	})
+	t.Run("should keep raised level when base changes", func(t *testing.T) {
+		// given
+		level := NewOperatorLevel(zapcore.InfoLevel)
+		level.Raise(zapcore.DebugLevel)
+
+		// when
+		level.SetBase(zapcore.WarnLevel)
+
+		// then
+		assert.Equal(t, zapcore.DebugLevel, level.Level())
+		level.Reset()
+		assert.Equal(t, zapcore.WarnLevel, level.Level())
+	})
+	t.Run("should apply the most verbose level of all sources", func(t *testing.T) {
+		// given
+		level := NewOperatorLevel(zapcore.InfoLevel)
+		first := level.Source("ecosystem")
+		second := level.Source("ecosystem-2")
+
+		// when
+		first.Raise(zapcore.DebugLevel)
+		second.Raise(zapcore.DebugLevel - 1)
+
+		// then
+		assert.Equal(t, zapcore.DebugLevel-1, level.Level())
+		second.Reset()
+		assert.Equal(t, zapcore.DebugLevel, level.Level())
+		first.Reset()
+		assert.Equal(t, zapcore.InfoLevel, level.Level())
+	})
+}
+
+func Test_OperatorLevel_ServeHTTP(t *testing.T) {
+	t.Run("should return levels", func(t *testing.T) {
+		// given
+		level := NewOperatorLevel(zapcore.InfoLevel)
+		level.Raise(zapcore.DebugLevel)
+		recorder := httptest.NewRecorder()
+
+		// when
+		level.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"level":"debug","base":"info"}`, recorder.Body.String())
+	})
+	t.Run("should set base level", func(t *testing.T) {
+		// given
+		level := NewOperatorLevel(zapcore.InfoLevel)
+		recorder := httptest.NewRecorder()
+
+		// when
+		level.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug"}`)))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, zapcore.DebugLevel, level.Level())
+	})
+	t.Run("should refuse invalid level", func(t *testing.T) {
+		// given
+		level := NewOperatorLevel(zapcore.InfoLevel)
+		recorder := httptest.NewRecorder()
+
+		// when
+		level.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"verbose"}`)))
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, zapcore.InfoLevel, level.Level())
+	})
+	t.Run("should refuse other methods", func(t *testing.T) {
+		// given
+		recorder := httptest.NewRecorder()
+
+		// when
+		NewOperatorLevel(zapcore.InfoLevel).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/loglevel", nil))
+
+		// then
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func Test_OperatorLevel_ReadOnly(t *testing.T) {
+	t.Run("should return levels", func(t *testing.T) {
+		// given
+		recorder := httptest.NewRecorder()
+
+		// when
+		NewOperatorLevel(zapcore.InfoLevel).ReadOnly().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"level":"info","base":"info"}`, recorder.Body.String())
+	})
+	t.Run("should refuse to change the level", func(t *testing.T) {
+		// given
+		level := NewOperatorLevel(zapcore.InfoLevel)
+		recorder := httptest.NewRecorder()
+
+		// when
+		level.ReadOnly().ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug"}`)))
+
+		// then
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, http.MethodGet, recorder.Header().Get("Allow"))
+		assert.Equal(t, zapcore.InfoLevel, level.Level())
+	})
+}
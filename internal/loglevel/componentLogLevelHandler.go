@@ -0,0 +1,179 @@
+package loglevel
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	componentLogLevelHandlerType = "component"
+	componentLogLevelEnv         = "LOG_LEVEL"
+)
+
+// componentLevels are the log levels the platform operators accept in LOG_LEVEL.
+var componentLevels = []LogLevel{LevelFatal, LevelError, LevelWarn, LevelInfo, LevelDebug}
+
+type DeploymentRepository interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.Deployment, error)
+	Update(ctx context.Context, deployment *appsv1.Deployment, opts metav1.UpdateOptions) (*appsv1.Deployment, error)
+}
+
+// ComponentLogLevelHandler changes the log level of platform components by the environment variable LOG_LEVEL of their
+// Deployment. Changing the pod template restarts the component.
+type ComponentLogLevelHandler struct {
+	deploymentRepository DeploymentRepository
+}
+
+func NewComponentLogLevelHandler(deploymentRepository DeploymentRepository) *ComponentLogLevelHandler {
+	return &ComponentLogLevelHandler{deploymentRepository: deploymentRepository}
+}
+
+func (h *ComponentLogLevelHandler) Kind() string {
+	return componentLogLevelHandlerType
+}
+
+func (h *ComponentLogLevelHandler) GetLogLevel(ctx context.Context, element any) (LogLevel, error) {
+	state, err := h.GetLogLevelState(ctx, element)
+	return state.Level, err
+}
+
+// GetLogLevelState returns the log level of the first container declaring LOG_LEVEL. A LOG_LEVEL set from a
+// reference cannot be restored and results in ErrUnsupportedLogLevel.
+func (h *ComponentLogLevelHandler) GetLogLevelState(ctx context.Context, element any) (LogLevelState, error) {
+	deployment, ok := element.(appsv1.Deployment)
+	if !ok {
+		return LogLevelState{Level: LevelUnknown}, fmt.Errorf("unexpected type of element: %v", element)
+	}
+
+	env, found := findLogLevelEnv(&deployment)
+	if !found {
+		// the platform operators log with info if LOG_LEVEL is not set
+		return LogLevelState{Level: LevelInfo, Unset: true}, nil
+	}
+	if env.ValueFrom != nil {
+		return LogLevelState{Level: LevelUnknown}, fmt.Errorf("%w: %s of deployment %s is set from a reference", ErrUnsupportedLogLevel, componentLogLevelEnv, deployment.Name)
+	}
+
+	level, err := CreateLogLevelFromString(env.Value)
+	if err != nil {
+		logging.FromContext(ctx).Info("WARNING: invalid log level set", "component", deployment.Name, "level", env.Value)
+		level = LevelUnknown
+	}
+	return LogLevelState{Level: level, RawValue: env.Value}, nil
+}
+
+func (h *ComponentLogLevelHandler) SetLogLevel(ctx context.Context, element any, logLevel LogLevel) error {
+	if !slices.Contains(componentLevels, logLevel) {
+		return fmt.Errorf("%w: components do not accept %s", ErrUnsupportedLogLevel, logLevel)
+	}
+	return h.writeLogLevel(ctx, element, strings.ToLower(logLevel.String()))
+}
+
+func (h *ComponentLogLevelHandler) ResetLogLevel(ctx context.Context, element any) error {
+	return h.updateDeployment(ctx, element, func(deployment *appsv1.Deployment) bool {
+		changed := false
+		for i := range deployment.Spec.Template.Spec.Containers {
+			container := &deployment.Spec.Template.Spec.Containers[i]
+			length := len(container.Env)
+			container.Env = slices.DeleteFunc(container.Env, isLogLevelEnv)
+			changed = changed || len(container.Env) != length
+		}
+		return changed
+	})
+}
+
+// RestoreLogLevel restores a previously read log level state. The raw value is written unchanged if it still
+// describes the log level or if it is no known log level at all.
+func (h *ComponentLogLevelHandler) RestoreLogLevel(ctx context.Context, element any, state LogLevelState) error {
+	if state.Unset {
+		return h.ResetLogLevel(ctx, element)
+	}
+	level, err := CreateLogLevelFromString(state.RawValue)
+	if err != nil {
+		// a value that is no known log level is restored as it was read
+		level = LevelUnknown
+	}
+	if state.RawValue == "" || level != state.Level {
+		return h.SetLogLevel(ctx, element, state.Level)
+	}
+	return h.writeLogLevel(ctx, element, state.RawValue)
+}
+
+func (h *ComponentLogLevelHandler) IsLogLevelSupported(_ context.Context, _ any, logLevel LogLevel) (bool, error) {
+	return slices.Contains(componentLevels, logLevel), nil
+}
+
+// writeLogLevel sets LOG_LEVEL in all containers declaring it or in the first container if none does.
+func (h *ComponentLogLevelHandler) writeLogLevel(ctx context.Context, element any, value string) error {
+	return h.updateDeployment(ctx, element, func(deployment *appsv1.Deployment) bool {
+		containers := deployment.Spec.Template.Spec.Containers
+		if len(containers) == 0 {
+			return false
+		}
+
+		changed := false
+		declared := false
+		for i := range containers {
+			index := slices.IndexFunc(containers[i].Env, isLogLevelEnv)
+			if index < 0 {
+				continue
+			}
+			declared = true
+			if containers[i].Env[index].Value != value || containers[i].Env[index].ValueFrom != nil {
+				containers[i].Env[index] = corev1.EnvVar{Name: componentLogLevelEnv, Value: value}
+				changed = true
+			}
+		}
+		if !declared {
+			containers[0].Env = append(containers[0].Env, corev1.EnvVar{Name: componentLogLevelEnv, Value: value})
+			changed = true
+		}
+		return changed
+	})
+}
+
+// updateDeployment applies the change to the latest version of the deployment and retries on conflicts.
+func (h *ComponentLogLevelHandler) updateDeployment(ctx context.Context, element any, change func(*appsv1.Deployment) bool) error {
+	deployment, ok := element.(appsv1.Deployment)
+	if !ok {
+		return fmt.Errorf("unexpected type of element: %v", element)
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := h.deploymentRepository.Get(ctx, deployment.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !change(latest) {
+			return nil
+		}
+		_, err = h.deploymentRepository.Update(ctx, latest, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not update %s of deployment %s: %w", componentLogLevelEnv, deployment.Name, err)
+	}
+	logging.FromContext(ctx).Debug("updated log level", "component", deployment.Name, "env", componentLogLevelEnv)
+	return nil
+}
+
+func findLogLevelEnv(deployment *appsv1.Deployment) (corev1.EnvVar, bool) {
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if index := slices.IndexFunc(container.Env, isLogLevelEnv); index >= 0 {
+			return container.Env[index], true
+		}
+	}
+	return corev1.EnvVar{}, false
+}
+
+func isLogLevelEnv(env corev1.EnvVar) bool {
+	return env.Name == componentLogLevelEnv
+}
@@ -0,0 +1,310 @@
+package loglevel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func createDeployment(containers ...corev1.Container) appsv1.Deployment {
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-dogu-operator"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: containers,
+		}}},
+	}
+}
+
+func createContainer(name string, env ...corev1.EnvVar) corev1.Container {
+	return corev1.Container{Name: name, Env: env}
+}
+
+func Test_ComponentLogLevelHandler_Kind(t *testing.T) {
+	assert.Equal(t, "component", NewComponentLogLevelHandler(nil).Kind())
+}
+
+func Test_ComponentLogLevelHandler_GetLogLevelState(t *testing.T) {
+	ctx := t.Context()
+	handler := NewComponentLogLevelHandler(nil)
+
+	t.Run("should return set log level", func(t *testing.T) {
+		// given
+		deployment := createDeployment(
+			createContainer("proxy"),
+			createContainer("manager", corev1.EnvVar{Name: "STAGE", Value: "production"}, corev1.EnvVar{Name: "LOG_LEVEL", Value: "warn"}),
+		)
+
+		// when
+		state, err := handler.GetLogLevelState(ctx, deployment)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, LogLevelState{Level: LevelWarn, RawValue: "warn"}, state)
+	})
+	t.Run("should return unset info level without env", func(t *testing.T) {
+		// when
+		state, err := handler.GetLogLevelState(ctx, createDeployment(createContainer("manager")))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, LogLevelState{Level: LevelInfo, Unset: true}, state)
+	})
+	t.Run("should return unknown for invalid value", func(t *testing.T) {
+		// when
+		state, err := handler.GetLogLevelState(ctx, createDeployment(createContainer("manager", corev1.EnvVar{Name: "LOG_LEVEL", Value: "verbose"})))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, LogLevelState{Level: LevelUnknown, RawValue: "verbose"}, state)
+	})
+	t.Run("should refuse log level set from a reference", func(t *testing.T) {
+		// given
+		deployment := createDeployment(createContainer("manager", corev1.EnvVar{Name: "LOG_LEVEL", ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{Key: "logLevel"},
+		}}))
+
+		// when
+		_, err := handler.GetLogLevelState(ctx, deployment)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrUnsupportedLogLevel)
+		assert.ErrorContains(t, err, "LOG_LEVEL of deployment k8s-dogu-operator is set from a reference")
+	})
+	t.Run("error on wrong element type", func(t *testing.T) {
+		// when
+		_, err := handler.GetLogLevel(ctx, "k8s-dogu-operator")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unexpected type of element")
+	})
+}
+
+func Test_ComponentLogLevelHandler_SetLogLevel(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("should update all containers declaring the env", func(t *testing.T) {
+		// given
+		deployment := createDeployment(
+			createContainer("proxy"),
+			createContainer("manager", corev1.EnvVar{Name: "LOG_LEVEL", Value: "info"}),
+		)
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(
+			func(_ context.Context, actual *appsv1.Deployment, _ metav1.UpdateOptions) (*appsv1.Deployment, error) {
+				assert.Empty(t, actual.Spec.Template.Spec.Containers[0].Env)
+				assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}, actual.Spec.Template.Spec.Containers[1].Env)
+				return actual, nil
+			})
+
+		// when
+		err := NewComponentLogLevelHandler(repository).SetLogLevel(ctx, deployment, LevelDebug)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should add env to first container if no container declares it", func(t *testing.T) {
+		// given
+		deployment := createDeployment(createContainer("manager"), createContainer("proxy"))
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(
+			func(_ context.Context, actual *appsv1.Deployment, _ metav1.UpdateOptions) (*appsv1.Deployment, error) {
+				assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}, actual.Spec.Template.Spec.Containers[0].Env)
+				assert.Empty(t, actual.Spec.Template.Spec.Containers[1].Env)
+				return actual, nil
+			})
+
+		// when
+		err := NewComponentLogLevelHandler(repository).SetLogLevel(ctx, deployment, LevelDebug)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not update deployment with target log level", func(t *testing.T) {
+		// given
+		deployment := createDeployment(createContainer("manager", corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"}))
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+
+		// when
+		err := NewComponentLogLevelHandler(repository).SetLogLevel(ctx, deployment, LevelDebug)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should retry on conflict with latest deployment", func(t *testing.T) {
+		// given
+		deployment := createDeployment(createContainer("manager", corev1.EnvVar{Name: "LOG_LEVEL", Value: "info"}))
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).RunAndReturn(
+			func(context.Context, string, metav1.GetOptions) (*appsv1.Deployment, error) {
+				return deployment.DeepCopy(), nil
+			}).Twice()
+		conflict := apierrors.NewConflict(schema.GroupResource{Resource: "deployments"}, deployment.Name, assert.AnError)
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, conflict).Once()
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(&deployment, nil).Once()
+
+		// when
+		err := NewComponentLogLevelHandler(repository).SetLogLevel(ctx, deployment, LevelDebug)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should refuse log level components do not accept", func(t *testing.T) {
+		// when
+		err := NewComponentLogLevelHandler(NewMockDeploymentRepository(t)).SetLogLevel(ctx, createDeployment(), LevelTrace)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrUnsupportedLogLevel)
+	})
+	t.Run("error updating deployment", func(t *testing.T) {
+		// given
+		deployment := createDeployment(createContainer("manager"))
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).Return(nil, assert.AnError)
+
+		// when
+		err := NewComponentLogLevelHandler(repository).SetLogLevel(ctx, deployment, LevelDebug)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not update LOG_LEVEL of deployment k8s-dogu-operator")
+	})
+}
+
+func Test_ComponentLogLevelHandler_ResetLogLevel(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("should remove env from all containers", func(t *testing.T) {
+		// given
+		deployment := createDeployment(
+			createContainer("manager", corev1.EnvVar{Name: "STAGE", Value: "production"}, corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"}),
+			createContainer("proxy", corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"}),
+		)
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(
+			func(_ context.Context, actual *appsv1.Deployment, _ metav1.UpdateOptions) (*appsv1.Deployment, error) {
+				assert.Equal(t, []corev1.EnvVar{{Name: "STAGE", Value: "production"}}, actual.Spec.Template.Spec.Containers[0].Env)
+				assert.Empty(t, actual.Spec.Template.Spec.Containers[1].Env)
+				return actual, nil
+			})
+
+		// when
+		err := NewComponentLogLevelHandler(repository).ResetLogLevel(ctx, deployment)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not update deployment without env", func(t *testing.T) {
+		// given
+		deployment := createDeployment(createContainer("manager"))
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+
+		// when
+		err := NewComponentLogLevelHandler(repository).ResetLogLevel(ctx, deployment)
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func Test_ComponentLogLevelHandler_RestoreLogLevel(t *testing.T) {
+	ctx := t.Context()
+	deployment := createDeployment(createContainer("manager", corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"}))
+
+	t.Run("should write raw value verbatim", func(t *testing.T) {
+		// given
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(
+			func(_ context.Context, actual *appsv1.Deployment, _ metav1.UpdateOptions) (*appsv1.Deployment, error) {
+				assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "WARNING"}}, actual.Spec.Template.Spec.Containers[0].Env)
+				return actual, nil
+			})
+
+		// when
+		err := NewComponentLogLevelHandler(repository).RestoreLogLevel(ctx, deployment, LogLevelState{Level: LevelWarn, RawValue: "WARNING"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should write unknown raw value verbatim", func(t *testing.T) {
+		// given
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(
+			func(_ context.Context, actual *appsv1.Deployment, _ metav1.UpdateOptions) (*appsv1.Deployment, error) {
+				assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "verbose"}}, actual.Spec.Template.Spec.Containers[0].Env)
+				return actual, nil
+			})
+
+		// when
+		err := NewComponentLogLevelHandler(repository).RestoreLogLevel(ctx, deployment, LogLevelState{Level: LevelUnknown, RawValue: "verbose"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should write log level if raw value does not match", func(t *testing.T) {
+		// given
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(
+			func(_ context.Context, actual *appsv1.Deployment, _ metav1.UpdateOptions) (*appsv1.Deployment, error) {
+				assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "error"}}, actual.Spec.Template.Spec.Containers[0].Env)
+				return actual, nil
+			})
+
+		// when
+		err := NewComponentLogLevelHandler(repository).RestoreLogLevel(ctx, deployment, LogLevelState{Level: LevelError})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should remove env if it was unset", func(t *testing.T) {
+		// given
+		repository := NewMockDeploymentRepository(t)
+		repository.EXPECT().Get(ctx, deployment.Name, metav1.GetOptions{}).Return(deployment.DeepCopy(), nil)
+		repository.EXPECT().Update(ctx, mock.Anything, metav1.UpdateOptions{}).RunAndReturn(
+			func(_ context.Context, actual *appsv1.Deployment, _ metav1.UpdateOptions) (*appsv1.Deployment, error) {
+				assert.Empty(t, actual.Spec.Template.Spec.Containers[0].Env)
+				return actual, nil
+			})
+
+		// when
+		err := NewComponentLogLevelHandler(repository).RestoreLogLevel(ctx, deployment, LogLevelState{Level: LevelInfo, Unset: true})
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func Test_ComponentLogLevelHandler_IsLogLevelSupported(t *testing.T) {
+	handler := NewComponentLogLevelHandler(nil)
+
+	for _, level := range []LogLevel{LevelFatal, LevelError, LevelWarn, LevelInfo, LevelDebug} {
+		supported, err := handler.IsLogLevelSupported(t.Context(), createDeployment(), level)
+		require.NoError(t, err)
+		assert.True(t, supported, level.String())
+	}
+	for _, level := range []LogLevel{LevelOff, LevelTrace, LevelUnknown} {
+		supported, err := handler.IsLogLevelSupported(t.Context(), createDeployment(), level)
+		require.NoError(t, err)
+		assert.False(t, supported, level.String())
+	}
+}
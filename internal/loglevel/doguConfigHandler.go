@@ -0,0 +1,104 @@
+package loglevel
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-registry-lib/config"
+)
+
+// ConfigValueState is the value of a single dogu config key together with the way it is configured.
+type ConfigValueState struct {
+	// Value is the value of the key. It is empty if the key is unset.
+	Value string `json:"value,omitempty"`
+	// Unset is true if the key does not exist in the dogu config.
+	Unset bool `json:"unset,omitempty"`
+}
+
+// DoguConfigHandler reads and changes arbitrary keys of the dogu config, e.g. loggers of single packages.
+type DoguConfigHandler struct {
+	doguConfigRepository DoguConfigRepository
+}
+
+func NewDoguConfigHandler(doguConfigRepository DoguConfigRepository) *DoguConfigHandler {
+	return &DoguConfigHandler{doguConfigRepository: doguConfigRepository}
+}
+
+// GetConfigValues returns the state of the given keys in the config of the dogu.
+func (h *DoguConfigHandler) GetConfigValues(ctx context.Context, element any, keys []string) (map[string]ConfigValueState, error) {
+	d, ok := element.(v2.Dogu)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type of element: %v", element)
+	}
+
+	doguConfig, err := h.doguConfigRepository.Get(ctx, dogu.SimpleName(d.Name))
+	if err != nil {
+		return nil, fmt.Errorf("ERROR: Failed to get dogu config: %w", err)
+	}
+
+	states := make(map[string]ConfigValueState, len(keys))
+	for _, key := range keys {
+		value, found := doguConfig.Get(config.Key(key))
+		states[key] = ConfigValueState{Value: string(value), Unset: !found}
+	}
+	return states, nil
+}
+
+// SetConfigValues writes the given values to the config of the dogu with a single update.
+// It returns false if all keys already have the given values.
+func (h *DoguConfigHandler) SetConfigValues(ctx context.Context, element any, values map[string]string) (bool, error) {
+	states := make(map[string]ConfigValueState, len(values))
+	for key, value := range values {
+		states[key] = ConfigValueState{Value: value}
+	}
+	return h.RestoreConfigValues(ctx, element, states)
+}
+
+// RestoreConfigValues restores previously read states of keys in the config of the dogu with a single update.
+// Unset keys are removed. It returns false if all keys already have the given state.
+func (h *DoguConfigHandler) RestoreConfigValues(ctx context.Context, element any, states map[string]ConfigValueState) (bool, error) {
+	d, ok := element.(v2.Dogu)
+	if !ok {
+		return false, fmt.Errorf("unexpected type of element: %v", element)
+	}
+
+	doguConfig, err := h.doguConfigRepository.Get(ctx, dogu.SimpleName(d.Name))
+	if err != nil {
+		return false, fmt.Errorf("ERROR: Failed to get dogu config: %w", err)
+	}
+
+	cfg := doguConfig.Config
+	changed := false
+	for _, key := range slices.Sorted(maps.Keys(states)) {
+		state := states[key]
+		current, found := cfg.Get(config.Key(key))
+		switch {
+		case state.Unset && found:
+			cfg = cfg.Delete(config.Key(key))
+		case !state.Unset && (!found || string(current) != state.Value):
+			cfg, err = cfg.Set(config.Key(key), config.Value(state.Value))
+			if err != nil {
+				return false, fmt.Errorf("could not write key %s to dogu config: %w", key, err)
+			}
+		default:
+			continue
+		}
+		changed = true
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	_, err = h.doguConfigRepository.Update(ctx, config.DoguConfig{DoguName: doguConfig.DoguName, Config: cfg})
+	if err != nil {
+		return false, fmt.Errorf("could not update dogu config for dogu %q: %w", d.Name, err)
+	}
+	logging.FromContext(ctx).Debug("updated config keys", "dogu", d.Name, "keys", slices.Sorted(maps.Keys(states)))
+	return true, nil
+}
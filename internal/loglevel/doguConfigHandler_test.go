@@ -0,0 +1,169 @@
+package loglevel
+
+import (
+	"testing"
+
+	dogulib "github.com/cloudogu/ces-commons-lib/dogu"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/cloudogu/k8s-registry-lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_DoguConfigHandler_GetConfigValues(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "nexus"}}
+
+	t.Run("should return set and unset keys", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{"logging/org.sonatype": "WARN"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+
+		// when
+		handler := NewDoguConfigHandler(doguConfigRepository)
+		states, err := handler.GetConfigValues(ctx, dogu, []string{"logging/org.sonatype", "logging/sql"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]ConfigValueState{
+			"logging/org.sonatype": {Value: "WARN"},
+			"logging/sql":          {Unset: true},
+		}, states)
+	})
+	t.Run("error getting dogu config", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfigRepository.EXPECT().Get(ctx, dogulib.SimpleName(dogu.Name)).Return(config.DoguConfig{}, assert.AnError)
+
+		// when
+		handler := NewDoguConfigHandler(doguConfigRepository)
+		_, err := handler.GetConfigValues(ctx, dogu, []string{"logging/sql"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("error on wrong element type", func(t *testing.T) {
+		// when
+		handler := NewDoguConfigHandler(NewMockDoguConfigRepository(t))
+		_, err := handler.GetConfigValues(ctx, "nexus", []string{"logging/sql"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unexpected type of element")
+	})
+}
+
+func Test_DoguConfigHandler_SetConfigValues(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "nexus"}}
+	doguConfig := config.DoguConfig{
+		DoguName: dogulib.SimpleName(dogu.Name),
+		Config:   config.CreateConfig(config.Entries{"logging/org.sonatype": "WARN", "logging/sql": "true"}),
+	}
+
+	t.Run("should write changed values with a single update", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, mock.MatchedBy(func(actual config.DoguConfig) bool {
+			sonatype, _ := actual.Get("logging/org.sonatype")
+			sql, _ := actual.Get("logging/sql")
+			cache, _ := actual.Get("cache/ttl")
+			return sonatype == "DEBUG" && sql == "true" && cache == "0"
+		})).Return(doguConfig, nil).Once()
+
+		// when
+		handler := NewDoguConfigHandler(doguConfigRepository)
+		changed, err := handler.SetConfigValues(ctx, dogu, map[string]string{"logging/org.sonatype": "DEBUG", "logging/sql": "true", "cache/ttl": "0"})
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+	t.Run("should not update unchanged values", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+
+		// when
+		handler := NewDoguConfigHandler(doguConfigRepository)
+		changed, err := handler.SetConfigValues(ctx, dogu, map[string]string{"logging/sql": "true"})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+	t.Run("error on update", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, mock.Anything).Return(config.DoguConfig{}, assert.AnError)
+
+		// when
+		handler := NewDoguConfigHandler(doguConfigRepository)
+		_, err := handler.SetConfigValues(ctx, dogu, map[string]string{"logging/sql": "false"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, `could not update dogu config for dogu "nexus"`)
+	})
+}
+
+func Test_DoguConfigHandler_RestoreConfigValues(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "nexus"}}
+
+	t.Run("should restore values and remove unset keys", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{"logging/org.sonatype": "DEBUG", "logging/sql": "true"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, mock.MatchedBy(func(actual config.DoguConfig) bool {
+			sonatype, _ := actual.Get("logging/org.sonatype")
+			_, sqlFound := actual.Get("logging/sql")
+			return sonatype == "warn" && !sqlFound
+		})).Return(doguConfig, nil)
+
+		// when
+		handler := NewDoguConfigHandler(doguConfigRepository)
+		changed, err := handler.RestoreConfigValues(ctx, dogu, map[string]ConfigValueState{
+			"logging/org.sonatype": {Value: "warn"},
+			"logging/sql":          {Unset: true},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+	t.Run("should not update already restored values", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{"logging/org.sonatype": "warn"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+
+		// when
+		handler := NewDoguConfigHandler(doguConfigRepository)
+		changed, err := handler.RestoreConfigValues(ctx, dogu, map[string]ConfigValueState{
+			"logging/org.sonatype": {Value: "warn"},
+			"logging/sql":          {Unset: true},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+}
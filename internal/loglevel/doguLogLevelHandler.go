@@ -3,21 +3,26 @@ package loglevel
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/cloudogu/ces-commons-lib/dogu"
+	"github.com/cloudogu/cesapp-lib/core"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/cloudogu/k8s-registry-lib/config"
-	"github.com/sirupsen/logrus"
 )
 
 const (
 	loggingKey              = "logging/root"
 	doguLogLevelHandlerType = "dogu"
+	validationTypeOneOf     = "ONE_OF"
 )
 
 type DoguLogLevelHandler struct {
 	doguConfigRepository DoguConfigRepository
 	doguDescriptorGetter DoguDescriptorGetter
+	vocabularies         map[string]Vocabulary
 }
 
 func NewDoguLogLevelHandler(
@@ -29,6 +34,12 @@ func NewDoguLogLevelHandler(
 	}
 }
 
+// SetVocabularies sets the spellings of log levels for single dogus. They take precedence over the
+// values of the dogu descriptor and the canonical names of the log levels.
+func (r *DoguLogLevelHandler) SetVocabularies(vocabularies map[string]Vocabulary) {
+	r.vocabularies = vocabularies
+}
+
 func (r *DoguLogLevelHandler) Kind() string {
 	return doguLogLevelHandlerType
 }
@@ -47,6 +58,30 @@ func (r *DoguLogLevelHandler) GetLogLevel(ctx context.Context, element any) (Log
 	return r.getLogLevel(ctx, d.Name, doguConfig)
 }
 
+func (r *DoguLogLevelHandler) GetLogLevelState(ctx context.Context, element any) (LogLevelState, error) {
+	d, ok := element.(v2.Dogu)
+	if !ok {
+		return LogLevelState{Level: LevelUnknown}, fmt.Errorf("unexpected type of element: %v", element)
+	}
+
+	doguConfig, err := r.doguConfigRepository.Get(ctx, dogu.SimpleName(d.Name))
+	if err != nil {
+		return LogLevelState{Level: LevelUnknown}, fmt.Errorf("ERROR: Failed to get LogLevel: %w", err)
+	}
+
+	level, err := r.getLogLevel(ctx, d.Name, doguConfig)
+	if err != nil {
+		return LogLevelState{Level: LevelUnknown}, err
+	}
+
+	rawValue := r.getConfigLogLevel(ctx, doguConfig)
+	return LogLevelState{
+		Level:    level,
+		Unset:    rawValue == "",
+		RawValue: rawValue,
+	}, nil
+}
+
 func (r *DoguLogLevelHandler) SetLogLevel(ctx context.Context, element any, logLevel LogLevel) error {
 	d, ok := element.(v2.Dogu)
 	if !ok {
@@ -63,11 +98,107 @@ func (r *DoguLogLevelHandler) SetLogLevel(ctx context.Context, element any, logL
 	return err
 }
 
+func (r *DoguLogLevelHandler) ResetLogLevel(ctx context.Context, element any) error {
+	d, ok := element.(v2.Dogu)
+	if !ok {
+		return fmt.Errorf("unexpected type of element: %v", element)
+	}
+
+	doguConfig, err := r.doguConfigRepository.Get(ctx, dogu.SimpleName(d.Name))
+	if err != nil {
+		return fmt.Errorf("ERROR: Failed to get LogLevel: %w", err)
+	}
+
+	if r.getConfigLogLevel(ctx, doguConfig) == "" {
+		return nil
+	}
+
+	_, err = r.doguConfigRepository.Update(ctx, config.DoguConfig{DoguName: doguConfig.DoguName, Config: doguConfig.Delete(loggingKey)})
+	if err != nil {
+		return fmt.Errorf("could not remove log level from dogu config for dogu %q: %w", d.Name, err)
+	}
+	logging.FromContext(ctx).Debug("removed log level", "dogu", d.Name)
+	return nil
+}
+
+// RestoreLogLevel restores a previously read log level state. An unset log level is removed and the raw value is
+// written unchanged if it still describes the log level, so the spelling of the dogu config is preserved.
+func (r *DoguLogLevelHandler) RestoreLogLevel(ctx context.Context, element any, state LogLevelState) error {
+	if state.Unset {
+		return r.ResetLogLevel(ctx, element)
+	}
+
+	d, ok := element.(v2.Dogu)
+	if !ok {
+		return fmt.Errorf("unexpected type of element: %v", element)
+	}
+
+	if state.RawValue == "" || r.parseLevel(d.Name, state.RawValue) != state.Level {
+		return r.SetLogLevel(ctx, element, state.Level)
+	}
+
+	doguConfig, err := r.doguConfigRepository.Get(ctx, dogu.SimpleName(d.Name))
+	if err != nil {
+		return fmt.Errorf("ERROR: Failed to get LogLevel: %w", err)
+	}
+
+	if r.getConfigLogLevel(ctx, doguConfig) == state.RawValue {
+		return nil
+	}
+
+	if err = r.writeLogLevel(ctx, doguConfig, state.RawValue); err != nil {
+		return fmt.Errorf("could not restore log level %s for dogu %s: %w", state.RawValue, d.Name, err)
+	}
+	logging.FromContext(ctx).Debug("restored log level", "dogu", d.Name, "level", state.RawValue)
+	return nil
+}
+
+func (r *DoguLogLevelHandler) IsLogLevelSupported(ctx context.Context, element any, logLevel LogLevel) (bool, error) {
+	d, ok := element.(v2.Dogu)
+	if !ok {
+		return false, fmt.Errorf("unexpected type of element: %v", element)
+	}
+
+	field, err := r.getLoggingField(ctx, d.Name)
+	if err != nil {
+		return false, fmt.Errorf("could not get dogu description: %w", err)
+	}
+
+	_, supported := r.valueFor(d.Name, field, logLevel)
+	return supported, nil
+}
+
+// valueFor returns the value of the log level in the spelling the dogu expects. The vocabulary of the dogu takes
+// precedence over the ONE_OF validation of its log level field. The second return value is false if the dogu does
+// not accept the level.
+func (r *DoguLogLevelHandler) valueFor(doguName string, field core.ConfigurationField, logLevel LogLevel) (string, bool) {
+	if value, found := r.vocabularies[doguName].spell(logLevel); found {
+		if field.Validation.Type == validationTypeOneOf && !slices.ContainsFunc(field.Validation.Values, func(allowed string) bool {
+			return strings.EqualFold(allowed, value)
+		}) {
+			return "", false
+		}
+		return value, true
+	}
+
+	if field.Validation.Type != validationTypeOneOf {
+		// without a list of allowed values every level is accepted
+		return logLevel.String(), true
+	}
+
+	index := slices.IndexFunc(field.Validation.Values, logLevel.Matches)
+	if index < 0 {
+		return "", false
+	}
+	return field.Validation.Values[index], true
+}
+
 func (r *DoguLogLevelHandler) getLogLevel(ctx context.Context, doguName string, doguConfig config.DoguConfig) (LogLevel, error) {
+	logger := logging.FromContext(ctx).WithValues("dogu", doguName)
 	currentLogLevelStr := r.getConfigLogLevel(ctx, doguConfig)
 
 	if currentLogLevelStr == "" {
-		logrus.Debugf("config log level is empty, try to get default log level from dogu descrption")
+		logger.Debug("config log level is empty, try to get default log level from dogu description")
 		var err error
 		currentLogLevelStr, err = r.getDefaultLogLevel(ctx, doguName)
 		if err != nil {
@@ -76,15 +207,15 @@ func (r *DoguLogLevelHandler) getLogLevel(ctx context.Context, doguName string,
 	}
 
 	if currentLogLevelStr == "" {
-		logrus.Warnf("log level for dogu %s is neither set in config nor description", doguName)
+		logger.Info("WARNING: log level is neither set in config nor description")
 		return LevelUnknown, nil
 	}
 
-	logrus.Debugf("current log level from dogu %s is %s", doguName, currentLogLevelStr)
+	logger.Debug("read current log level", "level", currentLogLevelStr)
 
-	currentLogLevel, err := CreateLogLevelFromString(currentLogLevelStr)
-	if err != nil {
-		logrus.Warnf("invalid log level set for dogu %s: %s", doguName, currentLogLevelStr)
+	currentLogLevel := r.parseLevel(doguName, currentLogLevelStr)
+	if currentLogLevel == LevelUnknown {
+		logger.Info("WARNING: invalid log level set", "level", currentLogLevelStr)
 
 		return LevelUnknown, nil
 	}
@@ -92,6 +223,18 @@ func (r *DoguLogLevelHandler) getLogLevel(ctx context.Context, doguName string,
 	return currentLogLevel, nil
 }
 
+// parseLevel returns the log level of a value using the vocabulary of the dogu and the known spellings of log levels.
+func (r *DoguLogLevelHandler) parseLevel(doguName string, value string) LogLevel {
+	if level, found := r.vocabularies[doguName].parse(value); found {
+		return level
+	}
+	level, err := CreateLogLevelFromString(value)
+	if err != nil {
+		return LevelUnknown
+	}
+	return level
+}
+
 func (r *DoguLogLevelHandler) getConfigLogLevel(_ context.Context, dConfig config.DoguConfig) string {
 	configLevelStr, _ := dConfig.Get(loggingKey)
 
@@ -99,21 +242,29 @@ func (r *DoguLogLevelHandler) getConfigLogLevel(_ context.Context, dConfig confi
 }
 
 func (r *DoguLogLevelHandler) getDefaultLogLevel(ctx context.Context, doguName string) (string, error) {
-	doguDescription, err := r.doguDescriptorGetter.GetCurrent(ctx, doguName)
+	field, err := r.getLoggingField(ctx, doguName)
 	if err != nil {
-		return "", fmt.Errorf("could not get dogu description for dogu %s: %w", doguName, err)
+		return "", err
 	}
 
-	var defaultLevelStr string
+	return field.Default, nil
+}
+
+// getLoggingField returns the log level configuration field of the current dogu description.
+// An empty field is returned if the dogu does not declare one.
+func (r *DoguLogLevelHandler) getLoggingField(ctx context.Context, doguName string) (core.ConfigurationField, error) {
+	doguDescription, err := r.doguDescriptorGetter.GetCurrent(ctx, doguName)
+	if err != nil {
+		return core.ConfigurationField{}, fmt.Errorf("could not get dogu description for dogu %s: %w", doguName, err)
+	}
 
 	for _, cfgValue := range doguDescription.Configuration {
 		if cfgValue.Name == loggingKey {
-			defaultLevelStr = cfgValue.Default
-			break
+			return cfgValue, nil
 		}
 	}
 
-	return defaultLevelStr, nil
+	return core.ConfigurationField{}, nil
 }
 
 func (r *DoguLogLevelHandler) setLogLevel(ctx context.Context, doguName string, doguConfig config.DoguConfig, l LogLevel) (bool, error) {
@@ -127,17 +278,28 @@ func (r *DoguLogLevelHandler) setLogLevel(ctx context.Context, doguName string,
 		return false, nil
 	}
 
-	if lErr := r.writeLogLevel(ctx, doguConfig, l); lErr != nil {
-		return false, fmt.Errorf("could not change log level from %s to %s: %w", currentLogLevel, l.String(), err)
+	field, err := r.getLoggingField(ctx, doguName)
+	if err != nil {
+		return false, fmt.Errorf("could not get dogu description: %w", err)
+	}
+
+	// never write a value the dogu rejects at startup
+	value, supported := r.valueFor(doguName, field, l)
+	if !supported {
+		return false, fmt.Errorf("%w: dogu %s does not accept %s, allowed values: %v", ErrUnsupportedLogLevel, doguName, l.String(), field.Validation.Values)
+	}
+
+	if lErr := r.writeLogLevel(ctx, doguConfig, value); lErr != nil {
+		return false, fmt.Errorf("could not change log level from %s to %s: %w", currentLogLevel, l.String(), lErr)
 	}
 
-	logrus.Debugf("written new log level %s for dogu %s", l.String(), doguName)
+	logging.FromContext(ctx).Debug("written new log level", "dogu", doguName, "level", value)
 
 	return true, nil
 }
 
-func (r *DoguLogLevelHandler) writeLogLevel(ctx context.Context, dConfig config.DoguConfig, l LogLevel) error {
-	doguConfig, err := dConfig.Set(loggingKey, config.Value(l.String()))
+func (r *DoguLogLevelHandler) writeLogLevel(ctx context.Context, dConfig config.DoguConfig, value string) error {
+	doguConfig, err := dConfig.Set(loggingKey, config.Value(value))
 	if err != nil {
 		return fmt.Errorf("could not write to dogu config: %w", err)
 	}
@@ -10,7 +10,8 @@ import (
 	dogulib "github.com/cloudogu/ces-commons-lib/dogu"
 	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	"github.com/stretchr/testify/assert"
-	_ "github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_DoguLogLevelHandler_NewComponentLogLevelHandler(t *testing.T) {
@@ -256,6 +257,7 @@ func Test_DoguLogLevelHandler_SetLogLevel(t *testing.T) {
 		expectedConfig.Config, err = expectedConfig.Config.Set(loggingKey, "WARN")
 
 		doguConfigRepository.EXPECT().Get(ctx, dogucConfig.DoguName).Return(dogucConfig, nil)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&core.Dogu{}, nil)
 
 		doguConfigRepository.EXPECT().Update(ctx, config.DoguConfig{DoguName: dogucConfig.DoguName, Config: expectedConfig.Config}).Return(dogucConfig, nil)
 
@@ -369,6 +371,7 @@ func Test_DoguLogLevelHandler_SetLogLevel(t *testing.T) {
 		}
 
 		doguConfigRepository.EXPECT().Get(ctx, dogucConfig.DoguName).Return(dogucConfig, nil)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&core.Dogu{}, nil)
 
 		// when
 		dllh := NewDoguLogLevelHandler(doguConfigRepository, doguDescriptorGetter)
@@ -402,6 +405,7 @@ func Test_DoguLogLevelHandler_SetLogLevel(t *testing.T) {
 		expectedConfig.Config, err = expectedConfig.Config.Set(loggingKey, "WARN")
 
 		doguConfigRepository.EXPECT().Get(ctx, dogucConfig.DoguName).Return(dogucConfig, nil)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&core.Dogu{}, nil)
 
 		doguConfigRepository.EXPECT().Update(ctx, config.DoguConfig{DoguName: dogucConfig.DoguName, Config: expectedConfig.Config}).Return(dogucConfig, assert.AnError)
 
@@ -412,4 +416,483 @@ func Test_DoguLogLevelHandler_SetLogLevel(t *testing.T) {
 		assert.Error(t, err)
 
 	})
+	t.Run("should write level in spelling of the dogu", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mydogu"}}
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{loggingKey: "INFO"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&core.Dogu{Configuration: []core.ConfigurationField{{
+			Name:       loggingKey,
+			Validation: core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"ERROR", "WARNING", "INFO", "debug"}},
+		}}}, nil)
+		doguConfigRepository.EXPECT().Update(ctx, mock.MatchedBy(func(actual config.DoguConfig) bool {
+			value, _ := actual.Get(loggingKey)
+			return value == "WARNING"
+		})).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, doguDescriptorGetter)
+		err := dllh.SetLogLevel(ctx, dogu, LevelWarn)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should refuse level not supported by the dogu", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mydogu"}}
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{loggingKey: "INFO"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&core.Dogu{Configuration: []core.ConfigurationField{{
+			Name:       loggingKey,
+			Validation: core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"ERROR", "WARN", "INFO"}},
+		}}}, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, doguDescriptorGetter)
+		err := dllh.SetLogLevel(ctx, dogu, LevelDebug)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrUnsupportedLogLevel)
+		assert.ErrorContains(t, err, "dogu mydogu does not accept DEBUG, allowed values: [ERROR WARN INFO]")
+	})
+	t.Run("error getting descriptor before write", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mydogu"}}
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{loggingKey: "INFO"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(nil, assert.AnError)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, doguDescriptorGetter)
+		err := dllh.SetLogLevel(ctx, dogu, LevelDebug)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_DoguLogLevelHandler_GetLogLevelState(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mydogu"}}
+
+	t.Run("success with explicit loglevel", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{loggingKey: "warn"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, doguDescriptorGetter)
+		state, err := dllh.GetLogLevelState(ctx, dogu)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, LogLevelState{Level: LevelWarn, RawValue: "warn"}, state)
+	})
+	t.Run("success with unset loglevel", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{}),
+		}
+		coreDogu := core.Dogu{Configuration: []core.ConfigurationField{{Name: loggingKey, Default: "info"}}}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&coreDogu, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, doguDescriptorGetter)
+		state, err := dllh.GetLogLevelState(ctx, dogu)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, LogLevelState{Level: LevelInfo, Unset: true}, state)
+	})
+	t.Run("error getting config", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguConfigRepository.EXPECT().Get(ctx, dogulib.SimpleName(dogu.Name)).Return(config.DoguConfig{}, assert.AnError)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, doguDescriptorGetter)
+		state, err := dllh.GetLogLevelState(ctx, dogu)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, LevelUnknown, state.Level)
+	})
+	t.Run("error with unexpected element", func(t *testing.T) {
+		// when
+		dllh := NewDoguLogLevelHandler(NewMockDoguConfigRepository(t), NewMockDoguDescriptorGetter(t))
+		_, err := dllh.GetLogLevelState(ctx, "no dogu")
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unexpected type of element")
+	})
+}
+
+func Test_DoguLogLevelHandler_ResetLogLevel(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mydogu"}}
+	withoutLoggingKey := mock.MatchedBy(func(doguConfig config.DoguConfig) bool {
+		_, found := doguConfig.Get(loggingKey)
+		return doguConfig.DoguName == dogulib.SimpleName(dogu.Name) && !found
+	})
+
+	t.Run("should remove loglevel key", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{loggingKey: "DEBUG"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, withoutLoggingKey).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		err := dllh.ResetLogLevel(ctx, dogu)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should do nothing if loglevel is not set", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		err := dllh.ResetLogLevel(ctx, dogu)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("error getting config", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfigRepository.EXPECT().Get(ctx, dogulib.SimpleName(dogu.Name)).Return(config.DoguConfig{}, assert.AnError)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		err := dllh.ResetLogLevel(ctx, dogu)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("error on update", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{loggingKey: "DEBUG"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, withoutLoggingKey).Return(config.DoguConfig{}, assert.AnError)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		err := dllh.ResetLogLevel(ctx, dogu)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not remove log level")
+	})
+}
+
+func Test_DoguLogLevelHandler_IsLogLevelSupported(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mydogu"}}
+	descriptor := func(validation core.ValidationDescriptor) *core.Dogu {
+		return &core.Dogu{Configuration: []core.ConfigurationField{{Name: loggingKey, Validation: validation}}}
+	}
+
+	t.Run("should support level listed in ONE_OF validation", func(t *testing.T) {
+		// given
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).
+			Return(descriptor(core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"warn", "info", "debug"}}), nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(NewMockDoguConfigRepository(t), doguDescriptorGetter)
+		supported, err := dllh.IsLogLevelSupported(ctx, dogu, LevelDebug)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, supported)
+	})
+	t.Run("should not support level missing in ONE_OF validation", func(t *testing.T) {
+		// given
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).
+			Return(descriptor(core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"WARN", "INFO"}}), nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(NewMockDoguConfigRepository(t), doguDescriptorGetter)
+		supported, err := dllh.IsLogLevelSupported(ctx, dogu, LevelDebug)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, supported)
+	})
+	t.Run("should support every level without validation", func(t *testing.T) {
+		// given
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&core.Dogu{}, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(NewMockDoguConfigRepository(t), doguDescriptorGetter)
+		supported, err := dllh.IsLogLevelSupported(ctx, dogu, LevelDebug)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, supported)
+	})
+	t.Run("error getting descriptor", func(t *testing.T) {
+		// given
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(nil, assert.AnError)
+
+		// when
+		dllh := NewDoguLogLevelHandler(NewMockDoguConfigRepository(t), doguDescriptorGetter)
+		_, err := dllh.IsLogLevelSupported(ctx, dogu, LevelDebug)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("error with unexpected element", func(t *testing.T) {
+		// when
+		dllh := NewDoguLogLevelHandler(NewMockDoguConfigRepository(t), NewMockDoguDescriptorGetter(t))
+		_, err := dllh.IsLogLevelSupported(ctx, "no dogu", LevelDebug)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unexpected type of element")
+	})
+}
+
+func Test_DoguLogLevelHandler_RestoreLogLevel(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mydogu"}}
+	newDoguConfig := func(entries config.Entries) config.DoguConfig {
+		return config.DoguConfig{DoguName: dogulib.SimpleName(dogu.Name), Config: config.CreateConfig(entries)}
+	}
+	withLogLevel := func(value string) any {
+		return mock.MatchedBy(func(actual config.DoguConfig) bool {
+			actualValue, _ := actual.Get(loggingKey)
+			return string(actualValue) == value
+		})
+	}
+
+	t.Run("should write raw value unchanged", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := newDoguConfig(config.Entries{loggingKey: "DEBUG"})
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, withLogLevel("warning")).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		err := dllh.RestoreLogLevel(ctx, dogu, LogLevelState{Level: LevelWarn, RawValue: "warning"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not write raw value already set", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := newDoguConfig(config.Entries{loggingKey: "warning"})
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		err := dllh.RestoreLogLevel(ctx, dogu, LogLevelState{Level: LevelWarn, RawValue: "warning"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should write raw value of dogu vocabulary", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := newDoguConfig(config.Entries{loggingKey: "DEBUG"})
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, withLogLevel("FINEST")).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		dllh.SetVocabularies(map[string]Vocabulary{"mydogu": {LevelTrace: "FINEST"}})
+		err := dllh.RestoreLogLevel(ctx, dogu, LogLevelState{Level: LevelTrace, RawValue: "FINEST"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should write unknown raw value verbatim", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := newDoguConfig(config.Entries{loggingKey: "DEBUG"})
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, withLogLevel("verbose")).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		err := dllh.RestoreLogLevel(ctx, dogu, LogLevelState{Level: LevelUnknown, RawValue: "verbose"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should set log level if raw value does not describe it", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguConfig := newDoguConfig(config.Entries{loggingKey: "DEBUG"})
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&core.Dogu{}, nil)
+		doguConfigRepository.EXPECT().Update(ctx, withLogLevel("INFO")).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, doguDescriptorGetter)
+		err := dllh.RestoreLogLevel(ctx, dogu, LogLevelState{Level: LevelInfo, RawValue: "verbose"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should remove unset log level", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := newDoguConfig(config.Entries{loggingKey: "DEBUG"})
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, mock.MatchedBy(func(actual config.DoguConfig) bool {
+			_, found := actual.Get(loggingKey)
+			return !found
+		})).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		err := dllh.RestoreLogLevel(ctx, dogu, LogLevelState{Level: LevelInfo, Unset: true})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("error on update", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := newDoguConfig(config.Entries{loggingKey: "DEBUG"})
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguConfigRepository.EXPECT().Update(ctx, withLogLevel("info")).Return(config.DoguConfig{}, assert.AnError)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		err := dllh.RestoreLogLevel(ctx, dogu, LogLevelState{Level: LevelInfo, RawValue: "info"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not restore log level info for dogu mydogu")
+	})
+	t.Run("error on wrong element type", func(t *testing.T) {
+		// when
+		dllh := NewDoguLogLevelHandler(NewMockDoguConfigRepository(t), NewMockDoguDescriptorGetter(t))
+		err := dllh.RestoreLogLevel(ctx, "mydogu", LogLevelState{Level: LevelInfo, RawValue: "info"})
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unexpected type of element")
+	})
+}
+
+func Test_DoguLogLevelHandler_Vocabularies(t *testing.T) {
+	ctx := t.Context()
+	dogu := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "mydogu"}}
+	vocabularies := map[string]Vocabulary{"mydogu": {LevelTrace: "finest", LevelDebug: "fine"}}
+
+	t.Run("should read log level in spelling of the vocabulary", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{loggingKey: "finest"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, NewMockDoguDescriptorGetter(t))
+		dllh.SetVocabularies(vocabularies)
+		level, err := dllh.GetLogLevel(ctx, dogu)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, LevelTrace, level)
+	})
+	t.Run("should write log level in spelling of the vocabulary", func(t *testing.T) {
+		// given
+		doguConfigRepository := NewMockDoguConfigRepository(t)
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguConfig := config.DoguConfig{
+			DoguName: dogulib.SimpleName(dogu.Name),
+			Config:   config.CreateConfig(config.Entries{loggingKey: "INFO"}),
+		}
+		doguConfigRepository.EXPECT().Get(ctx, doguConfig.DoguName).Return(doguConfig, nil)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&core.Dogu{}, nil)
+		doguConfigRepository.EXPECT().Update(ctx, mock.MatchedBy(func(actual config.DoguConfig) bool {
+			value, _ := actual.Get(loggingKey)
+			return value == "fine"
+		})).Return(doguConfig, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(doguConfigRepository, doguDescriptorGetter)
+		dllh.SetVocabularies(vocabularies)
+		err := dllh.SetLogLevel(ctx, dogu, LevelDebug)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should refuse vocabulary value not allowed by the descriptor", func(t *testing.T) {
+		// given
+		doguDescriptorGetter := NewMockDoguDescriptorGetter(t)
+		doguDescriptorGetter.EXPECT().GetCurrent(ctx, dogu.Name).Return(&core.Dogu{Configuration: []core.ConfigurationField{{
+			Name:       loggingKey,
+			Validation: core.ValidationDescriptor{Type: "ONE_OF", Values: []string{"INFO", "DEBUG"}},
+		}}}, nil)
+
+		// when
+		dllh := NewDoguLogLevelHandler(NewMockDoguConfigRepository(t), doguDescriptorGetter)
+		dllh.SetVocabularies(vocabularies)
+		supported, err := dllh.IsLogLevelSupported(ctx, dogu, LevelDebug)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, supported)
+	})
 }
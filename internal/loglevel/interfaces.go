@@ -19,6 +19,24 @@ type DoguDescriptorGetter interface {
 
 type LogLevelHandler interface {
 	GetLogLevel(ctx context.Context, element any) (LogLevel, error)
+	// GetLogLevelState returns the log level and whether it is configured explicitly or inherited from a default.
+	GetLogLevelState(ctx context.Context, element any) (LogLevelState, error)
 	SetLogLevel(ctx context.Context, element any, targetLogLevel LogLevel) error
+	// ResetLogLevel removes an explicitly configured log level, so the element falls back to its default.
+	ResetLogLevel(ctx context.Context, element any) error
+	// RestoreLogLevel restores a previously read log level state, preserving the original spelling of the value.
+	RestoreLogLevel(ctx context.Context, element any, state LogLevelState) error
+	// IsLogLevelSupported returns whether the current version of the element accepts the given log level.
+	IsLogLevelSupported(ctx context.Context, element any, logLevel LogLevel) (bool, error)
 	Kind() string
 }
+
+// ConfigHandler reads and changes arbitrary config keys of an element.
+type ConfigHandler interface {
+	// GetConfigValues returns the state of the given keys.
+	GetConfigValues(ctx context.Context, element any, keys []string) (map[string]ConfigValueState, error)
+	// SetConfigValues writes the given values and returns whether anything changed.
+	SetConfigValues(ctx context.Context, element any, values map[string]string) (bool, error)
+	// RestoreConfigValues restores previously read states and returns whether anything changed.
+	RestoreConfigValues(ctx context.Context, element any, states map[string]ConfigValueState) (bool, error)
+}
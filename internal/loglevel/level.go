@@ -2,52 +2,107 @@ package loglevel
 
 import (
 	"errors"
+	"slices"
 	"strings"
 )
 
+// ErrUnsupportedLogLevel is returned if an element does not accept a log level.
+var ErrUnsupportedLogLevel = errors.New("log level not supported")
+
 // LogLevel is the log level that can be defined for a dogu or component.
+// Known levels are ordered by verbosity, from LevelOff (no output) to LevelTrace (most output).
 type LogLevel int
 
 const (
 	LevelUnknown LogLevel = iota
+	LevelOff
+	LevelFatal
 	LevelError
 	LevelWarn
 	LevelInfo
 	LevelDebug
+	LevelTrace
 )
 
+// knownLevels contains all known log levels ordered by verbosity.
+var knownLevels = []LogLevel{LevelOff, LevelFatal, LevelError, LevelWarn, LevelInfo, LevelDebug, LevelTrace}
+
+// LogLevelState describes the log level of an element together with the way it is configured.
+type LogLevelState struct {
+	// Level is the effective log level.
+	Level LogLevel
+	// Unset is true if no log level is configured explicitly and Level is the default.
+	Unset bool
+	// RawValue is the value as written in the configuration. It is empty if the log level is unset.
+	RawValue string
+}
+
 // String converts LogLevel type to a string
 func (l LogLevel) String() string {
 	switch l {
-	case LevelUnknown:
-		return "UNKNOWN"
-	case LevelDebug:
-		return "DEBUG"
+	case LevelOff:
+		return "OFF"
+	case LevelFatal:
+		return "FATAL"
+	case LevelError:
+		return "ERROR"
+	case LevelWarn:
+		return "WARN"
 	case LevelInfo:
 		return "INFO"
+	case LevelDebug:
+		return "DEBUG"
+	case LevelTrace:
+		return "TRACE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// IsKnown returns true if the log level is one of the defined levels.
+func (l LogLevel) IsKnown() bool {
+	return slices.Contains(knownLevels, l)
+}
+
+// Compare returns -1 if the log level is less verbose than the other, 1 if it is more verbose and 0 if both are
+// equally verbose. Unknown log levels are less verbose than all known levels.
+func (l LogLevel) Compare(other LogLevel) int {
+	return slices.Index(knownLevels, l) - slices.Index(knownLevels, other)
+}
+
+// IsMoreVerboseThan returns true if both log levels are known and the log level produces more output than the other.
+func (l LogLevel) IsMoreVerboseThan(other LogLevel) bool {
+	return l.IsKnown() && other.IsKnown() && l.Compare(other) > 0
+}
+
+// IsLessVerboseThan returns true if both log levels are known and the log level produces less output than the other.
+func (l LogLevel) IsLessVerboseThan(other LogLevel) bool {
+	return l.IsKnown() && other.IsKnown() && l.Compare(other) < 0
+}
+
+// aliases returns all spellings of the log level in upper case, starting with the canonical one.
+func (l LogLevel) aliases() []string {
+	switch l {
 	case LevelWarn:
-		return "WARN"
-	case LevelError:
-		return "ERROR"
+		return []string{l.String(), "WARNING"}
+	case LevelFatal:
+		return []string{l.String(), "CRITICAL"}
 	default:
-		return "WARN"
+		return []string{l.String()}
 	}
 }
 
+// Matches returns true if the given value is a spelling of the log level, ignoring the case.
+func (l LogLevel) Matches(value string) bool {
+	return l.IsKnown() && slices.Contains(l.aliases(), strings.ToUpper(strings.TrimSpace(value)))
+}
+
 // CreateLogLevelFromString maps a string to an internal log level used in application
 func CreateLogLevelFromString(sLevel string) (LogLevel, error) {
-	sLevelUpper := strings.ToUpper(sLevel)
-
-	switch sLevelUpper {
-	case LevelError.String():
-		return LevelError, nil
-	case LevelWarn.String():
-		return LevelWarn, nil
-	case LevelInfo.String():
-		return LevelInfo, nil
-	case LevelDebug.String():
-		return LevelDebug, nil
-	default:
-		return LevelUnknown, errors.New("unknown log level")
+	for _, level := range knownLevels {
+		if level.Matches(sLevel) {
+			return level, nil
+		}
 	}
+	return LevelUnknown, errors.New("unknown log level")
 }
@@ -15,6 +15,9 @@ func Test_Level_CreateLogLevelFromString(t *testing.T) {
 			"warn":  LevelWarn,
 			"error": LevelError,
 			"debug": LevelDebug,
+			"trace": LevelTrace,
+			"fatal": LevelFatal,
+			"off":   LevelOff,
 		}
 
 		for key, value := range levelMap {
@@ -27,9 +30,50 @@ func Test_Level_CreateLogLevelFromString(t *testing.T) {
 		require.Error(t, err)
 		assert.Equal(t, LevelUnknown.String(), level.String())
 
-		var invalidLogLevel LogLevel = 8
+		var invalidLogLevel LogLevel = 42
 
-		assert.Equal(t, LevelWarn.String(), invalidLogLevel.String())
+		assert.Equal(t, LevelUnknown.String(), invalidLogLevel.String())
 
 	})
 }
+
+func Test_Level_Matches(t *testing.T) {
+	t.Run("should match all spellings ignoring case", func(t *testing.T) {
+		assert.True(t, LevelWarn.Matches("warn"))
+		assert.True(t, LevelWarn.Matches("WARNING"))
+		assert.True(t, LevelWarn.Matches(" Warning "))
+		assert.True(t, LevelDebug.Matches("debug"))
+		assert.True(t, LevelFatal.Matches("critical"))
+	})
+	t.Run("should not match other levels", func(t *testing.T) {
+		assert.False(t, LevelWarn.Matches("INFO"))
+		assert.False(t, LevelDebug.Matches("TRACE"))
+		assert.False(t, LevelUnknown.Matches("UNKNOWN"))
+	})
+	t.Run("should create level from alias", func(t *testing.T) {
+		level, err := CreateLogLevelFromString("warning")
+		require.NoError(t, err)
+		assert.Equal(t, LevelWarn, level)
+	})
+}
+
+func Test_Level_Compare(t *testing.T) {
+	t.Run("should order levels by verbosity", func(t *testing.T) {
+		assert.True(t, LevelTrace.IsMoreVerboseThan(LevelDebug))
+		assert.True(t, LevelDebug.IsMoreVerboseThan(LevelInfo))
+		assert.True(t, LevelInfo.IsMoreVerboseThan(LevelWarn))
+		assert.True(t, LevelError.IsMoreVerboseThan(LevelFatal))
+		assert.True(t, LevelFatal.IsMoreVerboseThan(LevelOff))
+		assert.True(t, LevelOff.IsLessVerboseThan(LevelError))
+		assert.False(t, LevelInfo.IsMoreVerboseThan(LevelInfo))
+		assert.False(t, LevelInfo.IsLessVerboseThan(LevelInfo))
+		assert.Equal(t, 0, LevelWarn.Compare(LevelWarn))
+	})
+	t.Run("should not compare unknown levels", func(t *testing.T) {
+		assert.False(t, LevelUnknown.IsMoreVerboseThan(LevelOff))
+		assert.False(t, LevelUnknown.IsLessVerboseThan(LevelTrace))
+		assert.False(t, LevelTrace.IsMoreVerboseThan(LevelUnknown))
+		assert.False(t, LevelUnknown.IsKnown())
+		assert.Negative(t, LevelUnknown.Compare(LevelOff))
+	})
+}
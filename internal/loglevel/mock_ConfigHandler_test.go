@@ -0,0 +1,212 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package loglevel
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockConfigHandler is an autogenerated mock type for the ConfigHandler type
+type MockConfigHandler struct {
+	mock.Mock
+}
+
+type MockConfigHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockConfigHandler) EXPECT() *MockConfigHandler_Expecter {
+	return &MockConfigHandler_Expecter{mock: &_m.Mock}
+}
+
+// GetConfigValues provides a mock function with given fields: ctx, element, keys
+func (_m *MockConfigHandler) GetConfigValues(ctx context.Context, element interface{}, keys []string) (map[string]ConfigValueState, error) {
+	ret := _m.Called(ctx, element, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigValues")
+	}
+
+	var r0 map[string]ConfigValueState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, []string) (map[string]ConfigValueState, error)); ok {
+		return rf(ctx, element, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, []string) map[string]ConfigValueState); ok {
+		r0 = rf(ctx, element, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]ConfigValueState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, []string) error); ok {
+		r1 = rf(ctx, element, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConfigHandler_GetConfigValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfigValues'
+type MockConfigHandler_GetConfigValues_Call struct {
+	*mock.Call
+}
+
+// GetConfigValues is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+//   - keys []string
+func (_e *MockConfigHandler_Expecter) GetConfigValues(ctx interface{}, element interface{}, keys interface{}) *MockConfigHandler_GetConfigValues_Call {
+	return &MockConfigHandler_GetConfigValues_Call{Call: _e.mock.On("GetConfigValues", ctx, element, keys)}
+}
+
+func (_c *MockConfigHandler_GetConfigValues_Call) Run(run func(ctx context.Context, element interface{}, keys []string)) *MockConfigHandler_GetConfigValues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockConfigHandler_GetConfigValues_Call) Return(_a0 map[string]ConfigValueState, _a1 error) *MockConfigHandler_GetConfigValues_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConfigHandler_GetConfigValues_Call) RunAndReturn(run func(context.Context, interface{}, []string) (map[string]ConfigValueState, error)) *MockConfigHandler_GetConfigValues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreConfigValues provides a mock function with given fields: ctx, element, states
+func (_m *MockConfigHandler) RestoreConfigValues(ctx context.Context, element interface{}, states map[string]ConfigValueState) (bool, error) {
+	ret := _m.Called(ctx, element, states)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreConfigValues")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, map[string]ConfigValueState) (bool, error)); ok {
+		return rf(ctx, element, states)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, map[string]ConfigValueState) bool); ok {
+		r0 = rf(ctx, element, states)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, map[string]ConfigValueState) error); ok {
+		r1 = rf(ctx, element, states)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConfigHandler_RestoreConfigValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreConfigValues'
+type MockConfigHandler_RestoreConfigValues_Call struct {
+	*mock.Call
+}
+
+// RestoreConfigValues is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+//   - states map[string]ConfigValueState
+func (_e *MockConfigHandler_Expecter) RestoreConfigValues(ctx interface{}, element interface{}, states interface{}) *MockConfigHandler_RestoreConfigValues_Call {
+	return &MockConfigHandler_RestoreConfigValues_Call{Call: _e.mock.On("RestoreConfigValues", ctx, element, states)}
+}
+
+func (_c *MockConfigHandler_RestoreConfigValues_Call) Run(run func(ctx context.Context, element interface{}, states map[string]ConfigValueState)) *MockConfigHandler_RestoreConfigValues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}), args[2].(map[string]ConfigValueState))
+	})
+	return _c
+}
+
+func (_c *MockConfigHandler_RestoreConfigValues_Call) Return(_a0 bool, _a1 error) *MockConfigHandler_RestoreConfigValues_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConfigHandler_RestoreConfigValues_Call) RunAndReturn(run func(context.Context, interface{}, map[string]ConfigValueState) (bool, error)) *MockConfigHandler_RestoreConfigValues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetConfigValues provides a mock function with given fields: ctx, element, values
+func (_m *MockConfigHandler) SetConfigValues(ctx context.Context, element interface{}, values map[string]string) (bool, error) {
+	ret := _m.Called(ctx, element, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetConfigValues")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, map[string]string) (bool, error)); ok {
+		return rf(ctx, element, values)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, map[string]string) bool); ok {
+		r0 = rf(ctx, element, values)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, map[string]string) error); ok {
+		r1 = rf(ctx, element, values)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConfigHandler_SetConfigValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetConfigValues'
+type MockConfigHandler_SetConfigValues_Call struct {
+	*mock.Call
+}
+
+// SetConfigValues is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+//   - values map[string]string
+func (_e *MockConfigHandler_Expecter) SetConfigValues(ctx interface{}, element interface{}, values interface{}) *MockConfigHandler_SetConfigValues_Call {
+	return &MockConfigHandler_SetConfigValues_Call{Call: _e.mock.On("SetConfigValues", ctx, element, values)}
+}
+
+func (_c *MockConfigHandler_SetConfigValues_Call) Run(run func(ctx context.Context, element interface{}, values map[string]string)) *MockConfigHandler_SetConfigValues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}), args[2].(map[string]string))
+	})
+	return _c
+}
+
+func (_c *MockConfigHandler_SetConfigValues_Call) Return(_a0 bool, _a1 error) *MockConfigHandler_SetConfigValues_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConfigHandler_SetConfigValues_Call) RunAndReturn(run func(context.Context, interface{}, map[string]string) (bool, error)) *MockConfigHandler_SetConfigValues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockConfigHandler creates a new instance of MockConfigHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockConfigHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockConfigHandler {
+	mock := &MockConfigHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
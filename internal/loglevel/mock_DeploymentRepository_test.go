@@ -0,0 +1,160 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package loglevel
+
+import (
+	context "context"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	mock "github.com/stretchr/testify/mock"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MockDeploymentRepository is an autogenerated mock type for the DeploymentRepository type
+type MockDeploymentRepository struct {
+	mock.Mock
+}
+
+type MockDeploymentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeploymentRepository) EXPECT() *MockDeploymentRepository_Expecter {
+	return &MockDeploymentRepository_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, name, opts
+func (_m *MockDeploymentRepository) Get(ctx context.Context, name string, opts v1.GetOptions) (*appsv1.Deployment, error) {
+	ret := _m.Called(ctx, name, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *appsv1.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, v1.GetOptions) (*appsv1.Deployment, error)); ok {
+		return rf(ctx, name, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, v1.GetOptions) *appsv1.Deployment); ok {
+		r0 = rf(ctx, name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*appsv1.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, v1.GetOptions) error); ok {
+		r1 = rf(ctx, name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeploymentRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDeploymentRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - opts v1.GetOptions
+func (_e *MockDeploymentRepository_Expecter) Get(ctx interface{}, name interface{}, opts interface{}) *MockDeploymentRepository_Get_Call {
+	return &MockDeploymentRepository_Get_Call{Call: _e.mock.On("Get", ctx, name, opts)}
+}
+
+func (_c *MockDeploymentRepository_Get_Call) Run(run func(ctx context.Context, name string, opts v1.GetOptions)) *MockDeploymentRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(v1.GetOptions))
+	})
+	return _c
+}
+
+func (_c *MockDeploymentRepository_Get_Call) Return(_a0 *appsv1.Deployment, _a1 error) *MockDeploymentRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeploymentRepository_Get_Call) RunAndReturn(run func(context.Context, string, v1.GetOptions) (*appsv1.Deployment, error)) *MockDeploymentRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, deployment, opts
+func (_m *MockDeploymentRepository) Update(ctx context.Context, deployment *appsv1.Deployment, opts v1.UpdateOptions) (*appsv1.Deployment, error) {
+	ret := _m.Called(ctx, deployment, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *appsv1.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *appsv1.Deployment, v1.UpdateOptions) (*appsv1.Deployment, error)); ok {
+		return rf(ctx, deployment, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *appsv1.Deployment, v1.UpdateOptions) *appsv1.Deployment); ok {
+		r0 = rf(ctx, deployment, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*appsv1.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *appsv1.Deployment, v1.UpdateOptions) error); ok {
+		r1 = rf(ctx, deployment, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeploymentRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockDeploymentRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deployment *appsv1.Deployment
+//   - opts v1.UpdateOptions
+func (_e *MockDeploymentRepository_Expecter) Update(ctx interface{}, deployment interface{}, opts interface{}) *MockDeploymentRepository_Update_Call {
+	return &MockDeploymentRepository_Update_Call{Call: _e.mock.On("Update", ctx, deployment, opts)}
+}
+
+func (_c *MockDeploymentRepository_Update_Call) Run(run func(ctx context.Context, deployment *appsv1.Deployment, opts v1.UpdateOptions)) *MockDeploymentRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*appsv1.Deployment), args[2].(v1.UpdateOptions))
+	})
+	return _c
+}
+
+func (_c *MockDeploymentRepository_Update_Call) Return(_a0 *appsv1.Deployment, _a1 error) *MockDeploymentRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeploymentRepository_Update_Call) RunAndReturn(run func(context.Context, *appsv1.Deployment, v1.UpdateOptions) (*appsv1.Deployment, error)) *MockDeploymentRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeploymentRepository creates a new instance of MockDeploymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeploymentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeploymentRepository {
+	mock := &MockDeploymentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
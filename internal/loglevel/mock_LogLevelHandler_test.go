@@ -78,6 +78,121 @@ func (_c *MockLogLevelHandler_GetLogLevel_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// GetLogLevelState provides a mock function with given fields: ctx, element
+func (_m *MockLogLevelHandler) GetLogLevelState(ctx context.Context, element interface{}) (LogLevelState, error) {
+	ret := _m.Called(ctx, element)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogLevelState")
+	}
+
+	var r0 LogLevelState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) (LogLevelState, error)); ok {
+		return rf(ctx, element)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) LogLevelState); ok {
+		r0 = rf(ctx, element)
+	} else {
+		r0 = ret.Get(0).(LogLevelState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, element)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLogLevelHandler_GetLogLevelState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogLevelState'
+type MockLogLevelHandler_GetLogLevelState_Call struct {
+	*mock.Call
+}
+
+// GetLogLevelState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+func (_e *MockLogLevelHandler_Expecter) GetLogLevelState(ctx interface{}, element interface{}) *MockLogLevelHandler_GetLogLevelState_Call {
+	return &MockLogLevelHandler_GetLogLevelState_Call{Call: _e.mock.On("GetLogLevelState", ctx, element)}
+}
+
+func (_c *MockLogLevelHandler_GetLogLevelState_Call) Run(run func(ctx context.Context, element interface{})) *MockLogLevelHandler_GetLogLevelState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockLogLevelHandler_GetLogLevelState_Call) Return(_a0 LogLevelState, _a1 error) *MockLogLevelHandler_GetLogLevelState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLogLevelHandler_GetLogLevelState_Call) RunAndReturn(run func(context.Context, interface{}) (LogLevelState, error)) *MockLogLevelHandler_GetLogLevelState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsLogLevelSupported provides a mock function with given fields: ctx, element, logLevel
+func (_m *MockLogLevelHandler) IsLogLevelSupported(ctx context.Context, element interface{}, logLevel LogLevel) (bool, error) {
+	ret := _m.Called(ctx, element, logLevel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLogLevelSupported")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, LogLevel) (bool, error)); ok {
+		return rf(ctx, element, logLevel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, LogLevel) bool); ok {
+		r0 = rf(ctx, element, logLevel)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, LogLevel) error); ok {
+		r1 = rf(ctx, element, logLevel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLogLevelHandler_IsLogLevelSupported_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLogLevelSupported'
+type MockLogLevelHandler_IsLogLevelSupported_Call struct {
+	*mock.Call
+}
+
+// IsLogLevelSupported is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+//   - logLevel LogLevel
+func (_e *MockLogLevelHandler_Expecter) IsLogLevelSupported(ctx interface{}, element interface{}, logLevel interface{}) *MockLogLevelHandler_IsLogLevelSupported_Call {
+	return &MockLogLevelHandler_IsLogLevelSupported_Call{Call: _e.mock.On("IsLogLevelSupported", ctx, element, logLevel)}
+}
+
+func (_c *MockLogLevelHandler_IsLogLevelSupported_Call) Run(run func(ctx context.Context, element interface{}, logLevel LogLevel)) *MockLogLevelHandler_IsLogLevelSupported_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}), args[2].(LogLevel))
+	})
+	return _c
+}
+
+func (_c *MockLogLevelHandler_IsLogLevelSupported_Call) Return(_a0 bool, _a1 error) *MockLogLevelHandler_IsLogLevelSupported_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLogLevelHandler_IsLogLevelSupported_Call) RunAndReturn(run func(context.Context, interface{}, LogLevel) (bool, error)) *MockLogLevelHandler_IsLogLevelSupported_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Kind provides a mock function with no fields
 func (_m *MockLogLevelHandler) Kind() string {
 	ret := _m.Called()
@@ -123,6 +238,101 @@ func (_c *MockLogLevelHandler_Kind_Call) RunAndReturn(run func() string) *MockLo
 	return _c
 }
 
+// ResetLogLevel provides a mock function with given fields: ctx, element
+func (_m *MockLogLevelHandler) ResetLogLevel(ctx context.Context, element interface{}) error {
+	ret := _m.Called(ctx, element)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLogLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
+		r0 = rf(ctx, element)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLogLevelHandler_ResetLogLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetLogLevel'
+type MockLogLevelHandler_ResetLogLevel_Call struct {
+	*mock.Call
+}
+
+// ResetLogLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+func (_e *MockLogLevelHandler_Expecter) ResetLogLevel(ctx interface{}, element interface{}) *MockLogLevelHandler_ResetLogLevel_Call {
+	return &MockLogLevelHandler_ResetLogLevel_Call{Call: _e.mock.On("ResetLogLevel", ctx, element)}
+}
+
+func (_c *MockLogLevelHandler_ResetLogLevel_Call) Run(run func(ctx context.Context, element interface{})) *MockLogLevelHandler_ResetLogLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockLogLevelHandler_ResetLogLevel_Call) Return(_a0 error) *MockLogLevelHandler_ResetLogLevel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLogLevelHandler_ResetLogLevel_Call) RunAndReturn(run func(context.Context, interface{}) error) *MockLogLevelHandler_ResetLogLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreLogLevel provides a mock function with given fields: ctx, element, state
+func (_m *MockLogLevelHandler) RestoreLogLevel(ctx context.Context, element interface{}, state LogLevelState) error {
+	ret := _m.Called(ctx, element, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreLogLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, LogLevelState) error); ok {
+		r0 = rf(ctx, element, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLogLevelHandler_RestoreLogLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreLogLevel'
+type MockLogLevelHandler_RestoreLogLevel_Call struct {
+	*mock.Call
+}
+
+// RestoreLogLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+//   - state LogLevelState
+func (_e *MockLogLevelHandler_Expecter) RestoreLogLevel(ctx interface{}, element interface{}, state interface{}) *MockLogLevelHandler_RestoreLogLevel_Call {
+	return &MockLogLevelHandler_RestoreLogLevel_Call{Call: _e.mock.On("RestoreLogLevel", ctx, element, state)}
+}
+
+func (_c *MockLogLevelHandler_RestoreLogLevel_Call) Run(run func(ctx context.Context, element interface{}, state LogLevelState)) *MockLogLevelHandler_RestoreLogLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}), args[2].(LogLevelState))
+	})
+	return _c
+}
+
+func (_c *MockLogLevelHandler_RestoreLogLevel_Call) Return(_a0 error) *MockLogLevelHandler_RestoreLogLevel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLogLevelHandler_RestoreLogLevel_Call) RunAndReturn(run func(context.Context, interface{}, LogLevelState) error) *MockLogLevelHandler_RestoreLogLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetLogLevel provides a mock function with given fields: ctx, element, targetLogLevel
 func (_m *MockLogLevelHandler) SetLogLevel(ctx context.Context, element interface{}, targetLogLevel LogLevel) error {
 	ret := _m.Called(ctx, element, targetLogLevel)
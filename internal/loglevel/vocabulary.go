@@ -0,0 +1,76 @@
+package loglevel
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Vocabulary maps log levels to the value a single dogu expects in its configuration,
+// e.g. LevelWarn to "warning" for a dogu that only accepts lowercase values.
+type Vocabulary map[LogLevel]string
+
+// spell returns the value of the log level in the vocabulary.
+func (v Vocabulary) spell(level LogLevel) (string, bool) {
+	value, found := v[level]
+	return value, found
+}
+
+// parse returns the log level of a value in the vocabulary, ignoring the case.
+func (v Vocabulary) parse(value string) (LogLevel, bool) {
+	trimmed := strings.TrimSpace(value)
+	for level, spelling := range v {
+		if strings.EqualFold(spelling, trimmed) {
+			return level, true
+		}
+	}
+	return LevelUnknown, false
+}
+
+// ParseVocabularies reads the vocabularies of dogus from JSON, e.g. {"redmine": {"WARN": "warning"}}.
+// An empty string results in no vocabularies.
+func ParseVocabularies(data string) (map[string]Vocabulary, error) {
+	if strings.TrimSpace(data) == "" {
+		return map[string]Vocabulary{}, nil
+	}
+
+	var raw map[string]map[string]string
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse log level vocabularies: %w", err)
+	}
+	return NewVocabularies(raw)
+}
+
+// NewVocabularies creates the vocabularies of dogus from the spellings of the log levels per dogu,
+// e.g. {"redmine": {"WARN": "warning"}}. Every log level and every spelling, ignoring the case, may only be given
+// once per dogu, so a value is always read as the same log level.
+func NewVocabularies(raw map[string]map[string]string) (map[string]Vocabulary, error) {
+	vocabularies := make(map[string]Vocabulary, len(raw))
+	for doguName, spellings := range raw {
+		vocabulary := make(Vocabulary, len(spellings))
+		spelledLevels := make(map[string]LogLevel, len(spellings))
+		for _, levelName := range slices.Sorted(maps.Keys(spellings)) {
+			spelling := spellings[levelName]
+			level, err := CreateLogLevelFromString(levelName)
+			if err != nil {
+				return nil, fmt.Errorf("invalid log level %q in vocabulary of dogu %s: %w", levelName, doguName, err)
+			}
+			if strings.TrimSpace(spelling) == "" {
+				return nil, fmt.Errorf("empty value for log level %s in vocabulary of dogu %s", level, doguName)
+			}
+			if _, found := vocabulary[level]; found {
+				return nil, fmt.Errorf("log level %s is given more than once in vocabulary of dogu %s", level, doguName)
+			}
+			normalized := strings.ToLower(strings.TrimSpace(spelling))
+			if other, found := spelledLevels[normalized]; found {
+				return nil, fmt.Errorf("value %q is given for log levels %s and %s in vocabulary of dogu %s", spelling, other, level, doguName)
+			}
+			spelledLevels[normalized] = level
+			vocabulary[level] = spelling
+		}
+		vocabularies[doguName] = vocabulary
+	}
+	return vocabularies, nil
+}
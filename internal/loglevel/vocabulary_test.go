@@ -0,0 +1,84 @@
+package loglevel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseVocabularies(t *testing.T) {
+	t.Run("should parse vocabularies of dogus", func(t *testing.T) {
+		// when
+		vocabularies, err := ParseVocabularies(`{"redmine": {"WARN": "warning", "debug": "debug"}, "nexus": {"TRACE": "FINEST"}}`)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]Vocabulary{
+			"redmine": {LevelWarn: "warning", LevelDebug: "debug"},
+			"nexus":   {LevelTrace: "FINEST"},
+		}, vocabularies)
+	})
+	t.Run("should return no vocabularies for empty string", func(t *testing.T) {
+		// when
+		vocabularies, err := ParseVocabularies(" ")
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, vocabularies)
+	})
+	t.Run("should fail on invalid json", func(t *testing.T) {
+		// when
+		_, err := ParseVocabularies(`{"redmine": "warning"}`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse log level vocabularies")
+	})
+	t.Run("should fail on unknown log level", func(t *testing.T) {
+		// when
+		_, err := ParseVocabularies(`{"redmine": {"VERBOSE": "verbose"}}`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `invalid log level "VERBOSE" in vocabulary of dogu redmine`)
+	})
+	t.Run("should fail on empty value", func(t *testing.T) {
+		// when
+		_, err := ParseVocabularies(`{"redmine": {"WARN": ""}}`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "empty value for log level WARN in vocabulary of dogu redmine")
+	})
+	t.Run("should fail on log level given more than once", func(t *testing.T) {
+		// when
+		_, err := ParseVocabularies(`{"redmine": {"WARN": "warn", "WARNING": "warning"}}`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "log level WARN is given more than once in vocabulary of dogu redmine")
+	})
+	t.Run("should fail on value given for several log levels", func(t *testing.T) {
+		// when
+		_, err := ParseVocabularies(`{"redmine": {"ERROR": "x", "WARN": "X"}}`)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `value "X" is given for log levels ERROR and WARN in vocabulary of dogu redmine`)
+	})
+}
+
+func Test_Vocabulary_parse(t *testing.T) {
+	vocabulary := Vocabulary{LevelTrace: "FINEST"}
+
+	level, found := vocabulary.parse(" finest")
+	assert.True(t, found)
+	assert.Equal(t, LevelTrace, level)
+
+	_, found = vocabulary.parse("TRACE")
+	assert.False(t, found)
+
+	_, found = Vocabulary(nil).parse("TRACE")
+	assert.False(t, found)
+}
@@ -0,0 +1,90 @@
+// Package notify informs external systems, e.g. the chat of the team whose dogus are debugged, about the start,
+// extension, failure and end of debug modes by webhooks.
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+)
+
+// Event is the occasion of a notification.
+type Event string
+
+const (
+	// EventActivated is sent once all log levels of a debug mode have been set.
+	EventActivated Event = "activated"
+	// EventExtended is sent if the deactivate timestamp of an active debug mode has been changed.
+	EventExtended Event = "extended"
+	// EventFailed is sent if a reconcile of a debug mode fails. Repeated failures with the same message are sent once.
+	EventFailed Event = "failed"
+	// EventCompleted is sent once the log levels have been restored or the debug mode was force deleted.
+	EventCompleted Event = "completed"
+)
+
+var allEvents = []Event{EventActivated, EventExtended, EventFailed, EventCompleted}
+
+// Notification describes an event of a debug mode session. Webhooks without a template receive it as JSON.
+type Notification struct {
+	Event     Event     `json:"event"`
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	// UID is the UID of the DebugMode of the session.
+	UID                 string    `json:"uid"`
+	TargetLogLevel      string    `json:"targetLogLevel,omitempty"`
+	DeactivateTimestamp time.Time `json:"deactivateTimestamp,omitzero"`
+	// PreviousDeactivateTimestamp is the deactivate timestamp before the extension.
+	PreviousDeactivateTimestamp *time.Time `json:"previousDeactivateTimestamp,omitempty"`
+	RequestedBy                 string     `json:"requestedBy,omitempty"`
+	// TriggeredBy names the degraded dogu if the debug mode was started automatically.
+	TriggeredBy string `json:"triggeredBy,omitempty"`
+	// Result describes how a completed session ended.
+	Result audit.Result `json:"result,omitempty"`
+	// Message is the summary of a completed session or the error of a failed reconcile.
+	Message string `json:"message,omitempty"`
+	// Changes are the dogus and components changed by the session. They are sent on activation and completion.
+	Changes []audit.Change `json:"changes,omitempty"`
+}
+
+// Summary returns a single line describing the notification, e.g. for the text of a chat message.
+func (n Notification) Summary() string {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "Debug mode %s/%s %s", n.Namespace, n.Name, n.Event)
+	switch n.Event {
+	case EventActivated:
+		fmt.Fprintf(&summary, " with log level %s until %s", n.TargetLogLevel, n.DeactivateTimestamp.Format(time.RFC3339))
+	case EventExtended:
+		fmt.Fprintf(&summary, " until %s", n.DeactivateTimestamp.Format(time.RFC3339))
+	case EventFailed, EventCompleted:
+		if n.Result != "" {
+			fmt.Fprintf(&summary, " (%s)", n.Result)
+		}
+		if n.Message != "" {
+			fmt.Fprintf(&summary, ": %s", n.Message)
+		}
+	}
+	if n.TriggeredBy != "" {
+		fmt.Fprintf(&summary, ", triggered by %s", n.TriggeredBy)
+	}
+	if n.Event != EventFailed && len(n.Changes) > 0 {
+		changes := make([]string, 0, len(n.Changes))
+		for _, change := range n.Changes {
+			changes = append(changes, describe(change))
+		}
+		fmt.Fprintf(&summary, ", changed %s", strings.Join(changes, ", "))
+	}
+	return summary.String()
+}
+
+func describe(change audit.Change) string {
+	if len(change.Keys) > 0 {
+		return fmt.Sprintf("%s %s (%s)", change.Kind, change.Name, strings.Join(change.Keys, ", "))
+	}
+	if change.Target == "" {
+		return fmt.Sprintf("%s %s (%s)", change.Kind, change.Name, change.Original)
+	}
+	return fmt.Sprintf("%s %s (%s -> %s)", change.Kind, change.Name, change.Original, change.Target)
+}
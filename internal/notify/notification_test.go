@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotification_Summary(t *testing.T) {
+	deactivate := time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		notification Notification
+		expected     string
+	}{
+		{name: "activation", notification: Notification{
+			Event: EventActivated, Namespace: "ecosystem", Name: "dm", TargetLogLevel: "DEBUG", DeactivateTimestamp: deactivate,
+			TriggeredBy: "cas: unhealthy for 2m0s",
+			Changes:     []audit.Change{{Kind: "dogu", Name: "cas", Original: "INFO", Target: "DEBUG"}},
+		}, expected: "Debug mode ecosystem/dm activated with log level DEBUG until 2026-10-19T11:00:00Z, triggered by cas: unhealthy for 2m0s, changed dogu cas (INFO -> DEBUG)"},
+		{name: "extension", notification: Notification{
+			Event: EventExtended, Namespace: "ecosystem", Name: "dm", DeactivateTimestamp: deactivate,
+		}, expected: "Debug mode ecosystem/dm extended until 2026-10-19T11:00:00Z"},
+		{name: "failure", notification: Notification{
+			Event: EventFailed, Namespace: "ecosystem", Name: "dm", Message: "dogu cas not found",
+		}, expected: "Debug mode ecosystem/dm failed: dogu cas not found"},
+		{name: "completion", notification: Notification{
+			Event: EventCompleted, Namespace: "ecosystem", Name: "dm", Result: audit.ResultDeleted, Message: "Debug-Mode deactivated",
+			Changes: []audit.Change{
+				{Kind: "dogu", Name: "redmine", Keys: []string{"logging/sql"}},
+				{Kind: "component", Name: "k8s-dogu-operator", Original: "INFO"},
+			},
+		}, expected: "Debug mode ecosystem/dm completed (Deleted): Debug-Mode deactivated, changed dogu redmine (logging/sql), component k8s-dogu-operator (INFO)"},
+	}
+	for _, tt := range tests {
+		t.Run("should describe the "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.notification.Summary())
+		})
+	}
+}
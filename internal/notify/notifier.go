@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+)
+
+// Notifier sends notifications to all webhooks subscribed to their event. Notifications are queued, so a debug mode is
+// never delayed by a slow or unavailable webhook. It is a runnable of the manager delivering the queued notifications
+// while the operator is the leader.
+type Notifier struct {
+	webhooks []*webhook
+}
+
+// NewNotifier creates a notifier for the given webhooks.
+func NewNotifier(configs []WebhookConfig) (*Notifier, error) {
+	n := &Notifier{}
+	for _, config := range configs {
+		w, err := newWebhook(config)
+		if err != nil {
+			return nil, err
+		}
+		n.webhooks = append(n.webhooks, w)
+	}
+	return n, nil
+}
+
+// Notify queues the notification for all subscribed webhooks. It is dropped for webhooks whose queue is full.
+func (n *Notifier) Notify(ctx context.Context, notification Notification) {
+	for _, w := range n.webhooks {
+		if !w.subscribes(notification.Event) {
+			continue
+		}
+		select {
+		case w.queue <- notification:
+		default:
+			logging.FromContext(ctx).Error("ERROR: drop notification, the queue of the webhook is full", "webhook", w.name,
+				"event", notification.Event, "namespace", notification.Namespace, "debugMode", notification.Name)
+		}
+	}
+}
+
+// Start delivers the queued notifications until the context is done.
+func (n *Notifier) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, w := range n.webhooks {
+		wg.Go(func() { w.run(ctx) })
+	}
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNotifier(t *testing.T) {
+	t.Run("should fail for an invalid webhook", func(t *testing.T) {
+		_, err := NewNotifier([]WebhookConfig{{Name: "chat"}})
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "webhook chat must have an absolute http or https URL")
+	})
+}
+
+func TestNotifier(t *testing.T) {
+	t.Run("should deliver notifications to the subscribed webhooks", func(t *testing.T) {
+		// given
+		chat, chatRequests, _ := newTestServer(t, http.StatusOK)
+		tickets, ticketRequests, _ := newTestServer(t, http.StatusOK)
+		notifier, err := NewNotifier([]WebhookConfig{
+			{Name: "chat", URL: chat.URL},
+			{Name: "tickets", URL: tickets.URL, Events: []Event{EventFailed}},
+		})
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan error)
+		go func() { stopped <- notifier.Start(ctx) }()
+
+		// when
+		notifier.Notify(ctx, testNotification())
+		failure := testNotification()
+		failure.Event = EventFailed
+		notifier.Notify(ctx, failure)
+
+		// then
+		assert.Equal(t, EventActivated, eventOf(t, <-chatRequests))
+		assert.Equal(t, EventFailed, eventOf(t, <-chatRequests))
+		assert.Equal(t, EventFailed, eventOf(t, <-ticketRequests))
+		cancel()
+		require.NoError(t, <-stopped)
+		assert.Empty(t, ticketRequests)
+	})
+	t.Run("should drop notifications if the queue is full", func(t *testing.T) {
+		// given
+		notifier, err := NewNotifier([]WebhookConfig{{Name: "chat", URL: "https://chat.example.com"}})
+		require.NoError(t, err)
+
+		// when
+		for range queueSize + 1 {
+			notifier.Notify(context.Background(), testNotification())
+		}
+
+		// then
+		assert.Len(t, notifier.webhooks[0].queue, queueSize)
+	})
+	t.Run("should continue with the next notification if one cannot be delivered", func(t *testing.T) {
+		// given
+		server, requests, _ := newTestServer(t, http.StatusBadRequest, http.StatusOK)
+		notifier, err := NewNotifier([]WebhookConfig{{Name: "chat", URL: server.URL}})
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() { _ = notifier.Start(ctx) }()
+
+		// when
+		notifier.Notify(ctx, testNotification())
+		completion := testNotification()
+		completion.Event = EventCompleted
+		notifier.Notify(ctx, completion)
+
+		// then
+		assert.Equal(t, EventActivated, eventOf(t, <-requests))
+		select {
+		case received := <-requests:
+			assert.Equal(t, EventCompleted, eventOf(t, received))
+		case <-time.After(5 * time.Second):
+			t.Fatal("the second notification was not delivered")
+		}
+	})
+}
+
+func eventOf(t *testing.T, received request) Event {
+	t.Helper()
+	var notification Notification
+	require.NoError(t, json.Unmarshal(received.body, &notification))
+	return notification.Event
+}
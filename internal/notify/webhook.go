@@ -0,0 +1,259 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultAttempts is the number of attempts to deliver a notification if none is configured.
+	DefaultAttempts = 4
+	// DefaultTimeout is the timeout of a single request if none is configured.
+	DefaultTimeout = 10 * time.Second
+	// DefaultRetryBackoff is the wait before the first retry if none is configured. It doubles with every retry.
+	DefaultRetryBackoff = time.Second
+
+	// queueSize is the number of notifications waiting per webhook. Further notifications are dropped.
+	queueSize = 100
+)
+
+// WebhookConfig configures a webhook receiving notifications as HTTP POST requests.
+type WebhookConfig struct {
+	// Name identifies the webhook in the log.
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Events are the events sent to the webhook. All events are sent if it is empty.
+	Events []Event `json:"events"`
+	// Headers are added to every request. Environment variables in their values are expanded, e.g. "Bearer ${TOKEN}",
+	// so secrets do not have to be part of the configuration.
+	Headers map[string]string `json:"headers"`
+	// Template is a Go template rendering the JSON payload from the Notification, e.g. {"text": {{ .Summary | json }}}.
+	// The Notification itself is sent if it is empty.
+	Template string `json:"template"`
+	// Attempts is the number of attempts to deliver a notification. DefaultAttempts is used if it is zero.
+	Attempts     int             `json:"attempts"`
+	Timeout      metav1.Duration `json:"timeout"`
+	RetryBackoff metav1.Duration `json:"retryBackoff"`
+}
+
+// ParseWebhooks parses the webhooks given as JSON list. An empty value configures no webhooks.
+func ParseWebhooks(value string) ([]WebhookConfig, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var webhooks []WebhookConfig
+	if err := json.Unmarshal([]byte(value), &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to parse webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// ValidateWebhooks returns an error for every invalid webhook.
+func ValidateWebhooks(webhooks []WebhookConfig) error {
+	var errs []error
+	names := map[string]bool{}
+	for _, config := range webhooks {
+		if names[config.Name] {
+			errs = append(errs, fmt.Errorf("duplicate webhook name %q", config.Name))
+		}
+		names[config.Name] = true
+		if _, err := newWebhook(config); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// webhook delivers the notifications of its queue one after another, so a slow webhook does not delay the others.
+type webhook struct {
+	name         string
+	url          string
+	events       []Event
+	headers      map[string]string
+	template     *template.Template
+	attempts     int
+	retryBackoff time.Duration
+	client       *http.Client
+	queue        chan Notification
+}
+
+func newWebhook(config WebhookConfig) (*webhook, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("webhook %s must have a name", config.URL)
+	}
+	target, err := url.Parse(config.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("webhook %s must have an absolute http or https URL, got %q", config.Name, config.URL)
+	}
+	for _, event := range config.Events {
+		if !slices.Contains(allEvents, event) {
+			return nil, fmt.Errorf("webhook %s has unknown event %q, expected one of %v", config.Name, event, allEvents)
+		}
+	}
+	if config.Attempts < 0 || config.Timeout.Duration < 0 || config.RetryBackoff.Duration < 0 {
+		return nil, fmt.Errorf("webhook %s must not have negative attempts, timeout or retry backoff", config.Name)
+	}
+
+	w := &webhook{
+		name:         config.Name,
+		url:          config.URL,
+		events:       config.Events,
+		headers:      map[string]string{},
+		attempts:     config.Attempts,
+		retryBackoff: config.RetryBackoff.Duration,
+		client:       &http.Client{Timeout: config.Timeout.Duration},
+		queue:        make(chan Notification, queueSize),
+	}
+	if w.attempts == 0 {
+		w.attempts = DefaultAttempts
+	}
+	if w.retryBackoff == 0 {
+		w.retryBackoff = DefaultRetryBackoff
+	}
+	if w.client.Timeout == 0 {
+		w.client.Timeout = DefaultTimeout
+	}
+	for name, value := range config.Headers {
+		w.headers[name] = os.ExpandEnv(value)
+	}
+
+	if config.Template != "" {
+		w.template, err = template.New(config.Name).Funcs(template.FuncMap{"json": toJSON}).Option("missingkey=error").Parse(config.Template)
+		if err != nil {
+			return nil, fmt.Errorf("webhook %s has an invalid template: %w", config.Name, err)
+		}
+		// the template is checked with a complete notification, so errors show up on start instead of on delivery
+		if _, err = w.render(sampleNotification()); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func toJSON(value any) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+func sampleNotification() Notification {
+	now := time.Now()
+	return Notification{
+		Event: EventExtended, Time: now, Namespace: "ecosystem", Name: "debug-mode", UID: "uid",
+		TargetLogLevel: "DEBUG", DeactivateTimestamp: now, PreviousDeactivateTimestamp: &now,
+		RequestedBy: "admin", TriggeredBy: "cas: unhealthy for 2m0s", Result: audit.ResultCompleted, Message: "message",
+		Changes: []audit.Change{{Kind: "dogu", Name: "cas", Original: "INFO", Target: "DEBUG"}},
+	}
+}
+
+func (w *webhook) subscribes(event Event) bool {
+	return len(w.events) == 0 || slices.Contains(w.events, event)
+}
+
+// render returns the JSON payload of the notification.
+func (w *webhook) render(notification Notification) ([]byte, error) {
+	if w.template == nil {
+		return json.Marshal(notification)
+	}
+	var payload bytes.Buffer
+	if err := w.template.Execute(&payload, notification); err != nil {
+		return nil, fmt.Errorf("failed to render template of webhook %s: %w", w.name, err)
+	}
+	if !json.Valid(payload.Bytes()) {
+		return nil, fmt.Errorf("template of webhook %s does not render valid JSON: %s", w.name, payload.String())
+	}
+	return payload.Bytes(), nil
+}
+
+// run delivers the queued notifications until the context is done.
+func (w *webhook) run(ctx context.Context) {
+	logger := logging.FromContext(ctx).WithValues("webhook", w.name)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-w.queue:
+			err := w.send(ctx, notification)
+			if err != nil {
+				logger.Error("ERROR: failed to send notification", "event", notification.Event,
+					"namespace", notification.Namespace, "debugMode", notification.Name, "error", err)
+				continue
+			}
+			logger.Debug("Sent notification", "event", notification.Event, "namespace", notification.Namespace, "debugMode", notification.Name)
+		}
+	}
+}
+
+// send posts the notification and retries with an exponential backoff unless the webhook rejects it.
+func (w *webhook) send(ctx context.Context, notification Notification) error {
+	payload, err := w.render(notification)
+	if err != nil {
+		return err
+	}
+	backoff := w.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err = w.post(ctx, payload)
+		var rejected *rejectedError
+		if err == nil || errors.As(err, &rejected) || attempt >= w.attempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (w *webhook) post(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range w.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	// the body is read, so the connection can be reused
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout:
+		return fmt.Errorf("webhook answered with status %d: %s", resp.StatusCode, body)
+	default:
+		return &rejectedError{status: resp.StatusCode, body: string(body)}
+	}
+}
+
+// rejectedError is returned if the webhook rejects a notification, which is not retried.
+type rejectedError struct {
+	status int
+	body   string
+}
+
+func (e *rejectedError) Error() string {
+	return fmt.Sprintf("webhook rejected notification with status %d: %s", e.status, e.body)
+}
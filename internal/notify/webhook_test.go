@@ -0,0 +1,236 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// request is a request received by the test server.
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+// newTestServer starts a webhook answering with the given status codes one after another and the last one afterward.
+func newTestServer(t *testing.T, statusCodes ...int) (*httptest.Server, <-chan request, *atomic.Int32) {
+	t.Helper()
+	requests := make(chan request, 10)
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{header: r.Header, body: body}
+		n := int(count.Add(1))
+		w.WriteHeader(statusCodes[min(n, len(statusCodes))-1])
+	}))
+	t.Cleanup(server.Close)
+	return server, requests, &count
+}
+
+func fastRetries(config WebhookConfig) WebhookConfig {
+	config.RetryBackoff = metav1.Duration{Duration: time.Millisecond}
+	return config
+}
+
+func testNotification() Notification {
+	return Notification{
+		Event:               EventActivated,
+		Time:                time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC),
+		Namespace:           "ecosystem",
+		Name:                "debug-mode",
+		UID:                 "uid-1",
+		TargetLogLevel:      "DEBUG",
+		DeactivateTimestamp: time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC),
+		RequestedBy:         "admin",
+		Changes:             []audit.Change{{Kind: "dogu", Name: "cas", Original: "INFO", Target: "DEBUG"}},
+	}
+}
+
+func TestParseWebhooks(t *testing.T) {
+	t.Run("should parse webhooks", func(t *testing.T) {
+		// when
+		webhooks, err := ParseWebhooks(`[{"name": "chat", "url": "https://chat.example.com", "events": ["failed"], "timeout": "5s"}]`)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []WebhookConfig{{
+			Name:    "chat",
+			URL:     "https://chat.example.com",
+			Events:  []Event{EventFailed},
+			Timeout: metav1.Duration{Duration: 5 * time.Second},
+		}}, webhooks)
+	})
+	t.Run("should return no webhooks for an empty value", func(t *testing.T) {
+		webhooks, err := ParseWebhooks(" ")
+
+		require.NoError(t, err)
+		assert.Nil(t, webhooks)
+	})
+	t.Run("should fail for invalid JSON", func(t *testing.T) {
+		_, err := ParseWebhooks(`{"name": "chat"}`)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to parse webhooks")
+	})
+}
+
+func TestValidateWebhooks(t *testing.T) {
+	valid := WebhookConfig{Name: "chat", URL: "https://chat.example.com"}
+	tests := []struct {
+		name     string
+		webhooks []WebhookConfig
+		errMsg   string
+	}{
+		{name: "missing name", webhooks: []WebhookConfig{{URL: "https://chat.example.com"}},
+			errMsg: "webhook https://chat.example.com must have a name"},
+		{name: "duplicate name", webhooks: []WebhookConfig{valid, valid},
+			errMsg: `duplicate webhook name "chat"`},
+		{name: "relative URL", webhooks: []WebhookConfig{{Name: "chat", URL: "/hook"}},
+			errMsg: "webhook chat must have an absolute http or https URL"},
+		{name: "other scheme", webhooks: []WebhookConfig{{Name: "chat", URL: "ftp://chat.example.com"}},
+			errMsg: "webhook chat must have an absolute http or https URL"},
+		{name: "unknown event", webhooks: []WebhookConfig{{Name: "chat", URL: "https://chat.example.com", Events: []Event{"started"}}},
+			errMsg: `webhook chat has unknown event "started"`},
+		{name: "negative attempts", webhooks: []WebhookConfig{{Name: "chat", URL: "https://chat.example.com", Attempts: -1}},
+			errMsg: "webhook chat must not have negative attempts"},
+		{name: "invalid template", webhooks: []WebhookConfig{{Name: "chat", URL: "https://chat.example.com", Template: `{"text": {{ .Summary }`}},
+			errMsg: "webhook chat has an invalid template"},
+		{name: "unknown field in template", webhooks: []WebhookConfig{{Name: "chat", URL: "https://chat.example.com", Template: `{"text": {{ .Text | json }}}`}},
+			errMsg: "failed to render template of webhook chat"},
+		{name: "template rendering no JSON", webhooks: []WebhookConfig{{Name: "chat", URL: "https://chat.example.com", Template: `text: {{ .Summary }}`}},
+			errMsg: "template of webhook chat does not render valid JSON"},
+	}
+	for _, tt := range tests {
+		t.Run("should fail for "+tt.name, func(t *testing.T) {
+			err := ValidateWebhooks(tt.webhooks)
+
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+	t.Run("should accept valid webhooks", func(t *testing.T) {
+		err := ValidateWebhooks([]WebhookConfig{valid, {
+			Name:     "ticket",
+			URL:      "http://tickets.example.com/api",
+			Events:   []Event{EventFailed, EventCompleted},
+			Template: `{"title": {{ printf "%s/%s" .Namespace .Name | json }}, "changes": {{ .Changes | json }}}`,
+		}})
+
+		require.NoError(t, err)
+	})
+}
+
+func TestWebhook_send(t *testing.T) {
+	t.Run("should post the notification as JSON", func(t *testing.T) {
+		// given
+		server, requests, _ := newTestServer(t, http.StatusOK)
+		w, err := newWebhook(WebhookConfig{Name: "chat", URL: server.URL})
+		require.NoError(t, err)
+
+		// when
+		err = w.send(context.Background(), testNotification())
+
+		// then
+		require.NoError(t, err)
+		received := <-requests
+		assert.Equal(t, "application/json", received.header.Get("Content-Type"))
+		var notification Notification
+		require.NoError(t, json.Unmarshal(received.body, &notification))
+		assert.Equal(t, testNotification(), notification)
+	})
+	t.Run("should post the rendered template", func(t *testing.T) {
+		// given
+		server, requests, _ := newTestServer(t, http.StatusNoContent)
+		w, err := newWebhook(WebhookConfig{Name: "chat", URL: server.URL, Template: `{"text": {{ .Summary | json }}, "level": "{{ .TargetLogLevel }}"}`})
+		require.NoError(t, err)
+
+		// when
+		err = w.send(context.Background(), testNotification())
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"text": "Debug mode ecosystem/debug-mode activated with log level DEBUG until 2026-10-19T11:00:00Z, changed dogu cas (INFO -> DEBUG)", "level": "DEBUG"}`,
+			string((<-requests).body))
+	})
+	t.Run("should add the headers with expanded environment variables", func(t *testing.T) {
+		// given
+		t.Setenv("CHAT_TOKEN", "secret")
+		server, requests, _ := newTestServer(t, http.StatusOK)
+		w, err := newWebhook(WebhookConfig{Name: "chat", URL: server.URL, Headers: map[string]string{"Authorization": "Bearer ${CHAT_TOKEN}"}})
+		require.NoError(t, err)
+
+		// when
+		err = w.send(context.Background(), testNotification())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer secret", (<-requests).header.Get("Authorization"))
+	})
+	t.Run("should retry until the webhook accepts the notification", func(t *testing.T) {
+		// given
+		server, _, count := newTestServer(t, http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK)
+		w, err := newWebhook(fastRetries(WebhookConfig{Name: "chat", URL: server.URL}))
+		require.NoError(t, err)
+
+		// when
+		err = w.send(context.Background(), testNotification())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), count.Load())
+	})
+	t.Run("should give up after the configured attempts", func(t *testing.T) {
+		// given
+		server, _, count := newTestServer(t, http.StatusBadGateway)
+		w, err := newWebhook(fastRetries(WebhookConfig{Name: "chat", URL: server.URL, Attempts: 2}))
+		require.NoError(t, err)
+
+		// when
+		err = w.send(context.Background(), testNotification())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "webhook answered with status 502")
+		assert.Equal(t, int32(2), count.Load())
+	})
+	t.Run("should not retry a rejected notification", func(t *testing.T) {
+		// given
+		server, _, count := newTestServer(t, http.StatusBadRequest)
+		w, err := newWebhook(fastRetries(WebhookConfig{Name: "chat", URL: server.URL}))
+		require.NoError(t, err)
+
+		// when
+		err = w.send(context.Background(), testNotification())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "webhook rejected notification with status 400")
+		assert.Equal(t, int32(1), count.Load())
+	})
+	t.Run("should stop retrying when the context is done", func(t *testing.T) {
+		// given
+		server, _, count := newTestServer(t, http.StatusServiceUnavailable)
+		w, err := newWebhook(WebhookConfig{Name: "chat", URL: server.URL, RetryBackoff: metav1.Duration{Duration: time.Hour}})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// when
+		err = w.send(ctx, testNotification())
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "gave up after 1 attempts")
+		assert.Equal(t, int32(1), count.Load())
+	})
+}
@@ -0,0 +1,129 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/apis/apiserver"
+	"k8s.io/apiserver/pkg/authentication/authenticatorfactory"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authenticationv1 "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/rest"
+)
+
+// ErrForbidden is returned if the user of a request may not read the status of a namespace.
+var ErrForbidden = errors.New("forbidden")
+
+// Authenticator authenticates the requests of the status API with a TokenReview. A SubjectAccessReview authorizes the
+// requested path as non-resource URL and, for every served namespace, the permission to get its DebugModes.
+type Authenticator struct {
+	authenticator requestAuthenticator
+	authorizer    accessAuthorizer
+}
+
+// NewAuthenticator creates an authenticator reviewing tokens and access with the API server of the given config. The
+// reviews are cached like those of the metrics server of controller-runtime.
+func NewAuthenticator(config *rest.Config, httpClient *http.Client) (*Authenticator, error) {
+	authenticationClient, err := authenticationv1.NewForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authentication client: %w", err)
+	}
+	authorizationClient, err := authorizationv1.NewForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorization client: %w", err)
+	}
+
+	backoff := &wait.Backoff{Duration: 500 * time.Millisecond, Factor: 1.5, Jitter: 0.2, Steps: 5}
+	tokenAuthenticator, _, err := authenticatorfactory.DelegatingAuthenticatorConfig{
+		Anonymous:                &apiserver.AnonymousAuthConfig{Enabled: false},
+		CacheTTL:                 time.Minute,
+		TokenAccessReviewClient:  authenticationClient,
+		TokenAccessReviewTimeout: 10 * time.Second,
+		WebhookRetryBackoff:      backoff,
+	}.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticator: %w", err)
+	}
+	accessAuthorizer, err := authorizerfactory.DelegatingAuthorizerConfig{
+		SubjectAccessReviewClient: authorizationClient,
+		AllowCacheTTL:             5 * time.Minute,
+		DenyCacheTTL:              30 * time.Second,
+		WebhookRetryBackoff:       backoff,
+	}.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %w", err)
+	}
+
+	return &Authenticator{authenticator: tokenAuthenticator, authorizer: accessAuthorizer}, nil
+}
+
+// Filter only passes authenticated requests whose user may use the method of the request on the requested path. The
+// user is stored in the context of the request for the authorization of the namespace.
+func (a *Authenticator) Filter(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		logger := logging.FromContext(req.Context())
+		response, ok, err := a.authenticator.AuthenticateRequest(req)
+		if err != nil {
+			logger.Error("ERROR: failed to authenticate request", "error", err)
+			http.Error(w, "authentication failed", http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		decision, reason, err := a.authorizer.Authorize(req.Context(), authorizer.AttributesRecord{
+			User: response.User,
+			Verb: strings.ToLower(req.Method),
+			Path: req.URL.Path,
+		})
+		if err != nil {
+			logger.Error("ERROR: failed to authorize request", "user", response.User.GetName(), "error", err)
+			http.Error(w, "authorization failed", http.StatusInternalServerError)
+			return
+		}
+		if decision != authorizer.DecisionAllow {
+			logger.Debug("Deny request", "user", response.User.GetName(), "path", req.URL.Path, "reason", reason)
+			http.Error(w, fmt.Sprintf("user %s may not %s %s", response.User.GetName(), strings.ToLower(req.Method), req.URL.Path), http.StatusForbidden)
+			return
+		}
+
+		handler.ServeHTTP(w, req.WithContext(request.WithUser(req.Context(), response.User)))
+	})
+}
+
+// AuthorizeNamespace returns ErrForbidden if the user of the request may not get the DebugModes of the given namespace.
+func (a *Authenticator) AuthorizeNamespace(ctx context.Context, namespace string) error {
+	user, found := request.UserFrom(ctx)
+	if !found {
+		return fmt.Errorf("%w: the request is not authenticated", ErrForbidden)
+	}
+
+	decision, _, err := a.authorizer.Authorize(ctx, authorizer.AttributesRecord{
+		User:            user,
+		Verb:            "get",
+		Namespace:       namespace,
+		APIGroup:        k8sCRLib.GroupVersion.Group,
+		APIVersion:      k8sCRLib.GroupVersion.Version,
+		Resource:        "debugmodes",
+		ResourceRequest: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to authorize user %s for namespace %s: %w", user.GetName(), namespace, err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return fmt.Errorf("%w: user %s may not get debugmodes in namespace %s", ErrForbidden, user.GetName(), namespace)
+	}
+	return nil
+}
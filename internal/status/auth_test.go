@@ -0,0 +1,127 @@
+package status
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestAuthenticator_Filter(t *testing.T) {
+	jane := &user.DefaultInfo{Name: "jane"}
+	pathOf := func(path string) any {
+		return mock.MatchedBy(func(attributes authorizer.Attributes) bool {
+			return attributes.GetUser().GetName() == "jane" && attributes.GetVerb() == "get" &&
+				!attributes.IsResourceRequest() && attributes.GetPath() == path
+		})
+	}
+	serve := func(a *Authenticator) (*httptest.ResponseRecorder, *string) {
+		var servedUser string
+		recorder := httptest.NewRecorder()
+		a.Filter(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+			if u, found := request.UserFrom(req.Context()); found {
+				servedUser = u.GetName()
+			}
+		})).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status", nil))
+		return recorder, &servedUser
+	}
+
+	t.Run("should pass an authorized request with its user", func(t *testing.T) {
+		// given
+		authn := newMockRequestAuthenticator(t)
+		authn.EXPECT().AuthenticateRequest(mock.Anything).Return(&authenticator.Response{User: jane}, true, nil)
+		authz := newMockAccessAuthorizer(t)
+		authz.EXPECT().Authorize(mock.Anything, pathOf("/status")).Return(authorizer.DecisionAllow, "", nil)
+
+		// when
+		recorder, servedUser := serve(&Authenticator{authenticator: authn, authorizer: authz})
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "jane", *servedUser)
+	})
+	t.Run("should answer 401 without valid token", func(t *testing.T) {
+		authn := newMockRequestAuthenticator(t)
+		authn.EXPECT().AuthenticateRequest(mock.Anything).Return(nil, false, nil)
+
+		recorder, servedUser := serve(&Authenticator{authenticator: authn, authorizer: newMockAccessAuthorizer(t)})
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Empty(t, *servedUser)
+	})
+	t.Run("should answer 403 if the user may not get the path", func(t *testing.T) {
+		authn := newMockRequestAuthenticator(t)
+		authn.EXPECT().AuthenticateRequest(mock.Anything).Return(&authenticator.Response{User: jane}, true, nil)
+		authz := newMockAccessAuthorizer(t)
+		authz.EXPECT().Authorize(mock.Anything, pathOf("/status")).Return(authorizer.DecisionNoOpinion, "no rule", nil)
+
+		recorder, servedUser := serve(&Authenticator{authenticator: authn, authorizer: authz})
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Empty(t, *servedUser)
+	})
+	t.Run("should fail if the review fails", func(t *testing.T) {
+		authn := newMockRequestAuthenticator(t)
+		authn.EXPECT().AuthenticateRequest(mock.Anything).Return(nil, false, assert.AnError)
+
+		recorder, servedUser := serve(&Authenticator{authenticator: authn, authorizer: newMockAccessAuthorizer(t)})
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Empty(t, *servedUser)
+	})
+}
+
+func TestAuthenticator_AuthorizeNamespace(t *testing.T) {
+	jane := &user.DefaultInfo{Name: "jane"}
+	debugModesIn := func(namespace string) any {
+		return mock.MatchedBy(func(attributes authorizer.Attributes) bool {
+			return attributes.GetUser().GetName() == "jane" && attributes.IsResourceRequest() &&
+				attributes.GetVerb() == "get" && attributes.GetAPIGroup() == "k8s.cloudogu.com" &&
+				attributes.GetResource() == "debugmodes" && attributes.GetNamespace() == namespace
+		})
+	}
+
+	t.Run("should allow a user who may get the debug modes of the namespace", func(t *testing.T) {
+		authz := newMockAccessAuthorizer(t)
+		authz.EXPECT().Authorize(mock.Anything, debugModesIn("ecosystem")).Return(authorizer.DecisionAllow, "", nil)
+		a := &Authenticator{authorizer: authz}
+
+		err := a.AuthorizeNamespace(request.WithUser(t.Context(), jane), "ecosystem")
+
+		require.NoError(t, err)
+	})
+	t.Run("should forbid a user who may not get the debug modes of the namespace", func(t *testing.T) {
+		authz := newMockAccessAuthorizer(t)
+		authz.EXPECT().Authorize(mock.Anything, debugModesIn("other")).Return(authorizer.DecisionNoOpinion, "", nil)
+		a := &Authenticator{authorizer: authz}
+
+		err := a.AuthorizeNamespace(request.WithUser(t.Context(), jane), "other")
+
+		require.ErrorIs(t, err, ErrForbidden)
+		assert.ErrorContains(t, err, "user jane may not get debugmodes in namespace other")
+	})
+	t.Run("should forbid a request without user", func(t *testing.T) {
+		a := &Authenticator{authorizer: newMockAccessAuthorizer(t)}
+
+		err := a.AuthorizeNamespace(t.Context(), "ecosystem")
+
+		require.ErrorIs(t, err, ErrForbidden)
+	})
+	t.Run("should fail if the access cannot be reviewed", func(t *testing.T) {
+		authz := newMockAccessAuthorizer(t)
+		authz.EXPECT().Authorize(mock.Anything, debugModesIn("ecosystem")).Return(authorizer.DecisionNoOpinion, "", assert.AnError)
+		a := &Authenticator{authorizer: authz}
+
+		err := a.AuthorizeNamespace(request.WithUser(t.Context(), jane), "ecosystem")
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.NotErrorIs(t, err, ErrForbidden)
+	})
+}
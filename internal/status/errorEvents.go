@@ -0,0 +1,73 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// maxErrors is the number of errors served for a debug mode.
+const maxErrors = 20
+
+// ErrorEntry is an error that occurred while reconciling a debug mode.
+type ErrorEntry struct {
+	Time    time.Time `json:"time"`
+	Name    string    `json:"name"`
+	Message string    `json:"message"`
+	// Count is the number of times the error occurred, if it occurred repeatedly.
+	Count int32 `json:"count,omitempty"`
+}
+
+// errorsOf reads the errors of the given DebugMode, the latest first. They are reported as Warning events by the
+// reconciling leader, so every replica serves the same errors until the events expire.
+func (r *Reader) errorsOf(ctx context.Context, cr *k8sCRLib.DebugMode) ([]ErrorEntry, error) {
+	list, err := r.events.List(ctx, metav1.ListOptions{FieldSelector: fields.AndSelectors(
+		fields.OneTermEqualSelector("involvedObject.uid", string(cr.UID)),
+		fields.OneTermEqualSelector("reason", controller.ReasonReconcileFailed),
+	).String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	entries := make([]ErrorEntry, 0, len(list.Items))
+	for _, event := range list.Items {
+		entry := ErrorEntry{Time: lastSeen(event), Name: cr.Name, Message: event.Message}
+		if count := occurrences(event); count > 1 {
+			entry.Count = count
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortStableFunc(entries, func(a, b ErrorEntry) int {
+		return b.Time.Compare(a.Time)
+	})
+	return entries[:min(len(entries), maxErrors)], nil
+}
+
+// lastSeen returns the time the event occurred last. Events of the events.k8s.io API only set the last timestamp
+// of their series.
+func lastSeen(event corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func occurrences(event corev1.Event) int32 {
+	if event.Series != nil {
+		return event.Series.Count
+	}
+	return event.Count
+}
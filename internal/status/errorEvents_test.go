@@ -0,0 +1,65 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReader_errorsOf(t *testing.T) {
+	cr := &k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: DebugModeName, UID: "uid-1"}}
+
+	t.Run("should return latest errors first", func(t *testing.T) {
+		// given
+		reader, m := newTestReader(t)
+		m.events.EXPECT().List(t.Context(), mock.Anything).Return(&corev1.EventList{Items: []corev1.Event{
+			{Message: "first", EventTime: metav1.NewMicroTime(testNow.Add(-time.Hour))},
+			{Message: "repeated", EventTime: metav1.NewMicroTime(testNow.Add(-2 * time.Hour)),
+				Series: &corev1.EventSeries{Count: 3, LastObservedTime: metav1.NewMicroTime(testNow)}},
+			{Message: "legacy", LastTimestamp: metav1.NewTime(testNow.Add(-time.Minute)), Count: 1},
+		}}, nil)
+
+		// when
+		entries, err := reader.errorsOf(t.Context(), cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []ErrorEntry{
+			{Time: testNow, Name: DebugModeName, Message: "repeated", Count: 3},
+			{Time: testNow.Add(-time.Minute), Name: DebugModeName, Message: "legacy"},
+			{Time: testNow.Add(-time.Hour), Name: DebugModeName, Message: "first"},
+		}, entries)
+	})
+	t.Run("should return at most the latest errors", func(t *testing.T) {
+		// given
+		reader, m := newTestReader(t)
+		events := make([]corev1.Event, maxErrors+1)
+		for i := range events {
+			events[i] = corev1.Event{Message: "error", LastTimestamp: metav1.NewTime(testNow.Add(time.Duration(i) * time.Minute))}
+		}
+		m.events.EXPECT().List(t.Context(), mock.Anything).Return(&corev1.EventList{Items: events}, nil)
+
+		// when
+		entries, err := reader.errorsOf(t.Context(), cr)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, entries, maxErrors)
+		assert.Equal(t, testNow.Add(maxErrors*time.Minute), entries[0].Time)
+	})
+	t.Run("should return empty list without errors", func(t *testing.T) {
+		reader, m := newTestReader(t)
+		m.events.EXPECT().List(t.Context(), mock.Anything).Return(&corev1.EventList{}, nil)
+
+		entries, err := reader.errorsOf(t.Context(), cr)
+
+		require.NoError(t, err)
+		assert.Equal(t, []ErrorEntry{}, entries)
+	})
+}
@@ -0,0 +1,129 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// namespaceParameter selects the namespace of the served debug mode, e.g. /status?namespace=ecosystem.
+const namespaceParameter = "namespace"
+
+// Handler serves the status of the debug mode as JSON. It only answers GET requests.
+type Handler struct {
+	reader statusReader
+}
+
+// NewHandler creates a handler serving the status of the given reader.
+func NewHandler(reader statusReader) *Handler {
+	return &Handler{reader: reader}
+}
+
+// ServeHTTP writes the status of the debug mode.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	serveJSON(w, req, "debug mode status", func(ctx context.Context) (any, error) {
+		return h.reader.Read(ctx)
+	})
+}
+
+// HistoryHandler serves the audit records of all kept debug mode sessions as JSON. It only answers GET requests.
+type HistoryHandler struct {
+	history historyReader
+}
+
+// NewHistoryHandler creates a handler serving the records of the given audit trail.
+func NewHistoryHandler(history historyReader) *HistoryHandler {
+	return &HistoryHandler{history: history}
+}
+
+// ServeHTTP writes the audit records, the latest session first.
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	serveJSON(w, req, "debug mode history", func(ctx context.Context) (any, error) {
+		return h.history.List(ctx)
+	})
+}
+
+// NamespaceHandler serves the handler of the namespace selected by the query parameter "namespace". Without the
+// parameter the handler of the default namespace is served.
+type NamespaceHandler struct {
+	defaultNamespace string
+	handlerFor       func(ctx context.Context, namespace string) (http.Handler, error)
+	authorizer       namespaceAuthorizer
+}
+
+// NewNamespaceHandler creates a handler selecting the handler of a namespace with the given function.
+func NewNamespaceHandler(defaultNamespace string, handlerFor func(ctx context.Context, namespace string) (http.Handler, error)) *NamespaceHandler {
+	return &NamespaceHandler{defaultNamespace: defaultNamespace, handlerFor: handlerFor}
+}
+
+// SetAuthorizer sets the authorizer checking for every request whether its user may read the selected namespace.
+// Without it every namespace is served.
+func (h *NamespaceHandler) SetAuthorizer(authorizer namespaceAuthorizer) {
+	h.authorizer = authorizer
+}
+
+// ServeHTTP serves the request with the handler of the selected namespace. Namespaces the user may not read are
+// answered with 403, namespaces that are not watched or do not exist with 404.
+func (h *NamespaceHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	namespace := strings.TrimSpace(req.URL.Query().Get(namespaceParameter))
+	if namespace == "" {
+		namespace = h.defaultNamespace
+	}
+	if len(validation.IsDNS1123Label(namespace)) > 0 {
+		http.Error(w, fmt.Sprintf("invalid namespace %q", namespace), http.StatusBadRequest)
+		return
+	}
+
+	logger := logging.FromContext(req.Context())
+	if h.authorizer != nil {
+		// the namespace is authorized first, so no clients are created for namespaces the user may not read
+		err := h.authorizer.AuthorizeNamespace(req.Context(), namespace)
+		if errors.Is(err, ErrForbidden) {
+			logger.Debug("Deny request", "namespace", namespace, "reason", err.Error())
+			http.Error(w, fmt.Sprintf("reading namespace %q is forbidden", namespace), http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			logger.Error("ERROR: failed to authorize namespace", "namespace", namespace, "error", err)
+			http.Error(w, fmt.Sprintf("failed to authorize namespace %q", namespace), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	handler, err := h.handlerFor(req.Context(), namespace)
+	if err != nil {
+		if errors.Is(err, controller.ErrNamespaceNotWatched) || errors.Is(err, controller.ErrNamespaceNotFound) {
+			http.Error(w, fmt.Sprintf("namespace %q is not watched", namespace), http.StatusNotFound)
+			return
+		}
+		logger.Error("ERROR: failed to serve namespace", "namespace", namespace, "error", err)
+		http.Error(w, fmt.Sprintf("failed to serve namespace %q", namespace), http.StatusInternalServerError)
+		return
+	}
+	handler.ServeHTTP(w, req)
+}
+
+func serveJSON(w http.ResponseWriter, req *http.Request, subject string, read func(ctx context.Context) (any, error)) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := read(req.Context())
+	if err != nil {
+		logging.FromContext(req.Context()).Error("ERROR: failed to read", "subject", subject, "error", err)
+		http.Error(w, fmt.Sprintf("failed to read %s", subject), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,216 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Run("should serve status as json", func(t *testing.T) {
+		// given
+		reader := newMockStatusReader(t)
+		reader.EXPECT().Read(mock.Anything).Return(Status{Found: true, Phase: "WaitForRollback", RemainingSeconds: 60}, nil)
+		recorder := httptest.NewRecorder()
+
+		// when
+		NewHandler(reader).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		var status Status
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+		assert.Equal(t, "WaitForRollback", status.Phase)
+		assert.Equal(t, int64(60), status.RemainingSeconds)
+	})
+	t.Run("should fail if status cannot be read", func(t *testing.T) {
+		// given
+		reader := newMockStatusReader(t)
+		reader.EXPECT().Read(mock.Anything).Return(Status{}, assert.AnError)
+		recorder := httptest.NewRecorder()
+
+		// when
+		NewHandler(reader).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), assert.AnError.Error())
+	})
+	t.Run("should reject other methods than GET", func(t *testing.T) {
+		// given
+		recorder := httptest.NewRecorder()
+
+		// when
+		NewHandler(newMockStatusReader(t)).ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/status", nil))
+
+		// then
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, http.MethodGet, recorder.Header().Get("Allow"))
+	})
+}
+
+func TestHistoryHandler_ServeHTTP(t *testing.T) {
+	t.Run("should serve audit records as json", func(t *testing.T) {
+		// given
+		history := newMockHistoryReader(t)
+		history.EXPECT().List(mock.Anything).Return([]audit.Record{{UID: "uid-1", RequestedBy: "jane", Result: audit.ResultCompleted}}, nil)
+		recorder := httptest.NewRecorder()
+
+		// when
+		NewHistoryHandler(history).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status/history", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var records []audit.Record
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &records))
+		assert.Equal(t, []audit.Record{{UID: "uid-1", RequestedBy: "jane", Result: audit.ResultCompleted}}, records)
+	})
+	t.Run("should fail if history cannot be read", func(t *testing.T) {
+		// given
+		history := newMockHistoryReader(t)
+		history.EXPECT().List(mock.Anything).Return(nil, assert.AnError)
+		recorder := httptest.NewRecorder()
+
+		// when
+		NewHistoryHandler(history).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status/history", nil))
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "failed to read debug mode history")
+	})
+}
+
+func TestNamespaceHandler_ServeHTTP(t *testing.T) {
+	newHandler := func(served *string) *NamespaceHandler {
+		return NewNamespaceHandler("ecosystem", func(_ context.Context, namespace string) (http.Handler, error) {
+			switch namespace {
+			case "ecosystem", "ecosystem-2":
+				return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					*served = namespace
+				}), nil
+			case "broken":
+				return nil, assert.AnError
+			case "deleted":
+				return nil, fmt.Errorf("%w: %q", controller.ErrNamespaceNotFound, namespace)
+			default:
+				return nil, fmt.Errorf("%w: %q", controller.ErrNamespaceNotWatched, namespace)
+			}
+		})
+	}
+
+	t.Run("should serve the default namespace", func(t *testing.T) {
+		// given
+		var served string
+		recorder := httptest.NewRecorder()
+
+		// when
+		newHandler(&served).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "ecosystem", served)
+	})
+	t.Run("should serve the selected namespace", func(t *testing.T) {
+		var served string
+		recorder := httptest.NewRecorder()
+
+		newHandler(&served).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status?namespace=ecosystem-2", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "ecosystem-2", served)
+	})
+	t.Run("should answer 404 for a namespace that is not watched", func(t *testing.T) {
+		var served string
+		recorder := httptest.NewRecorder()
+
+		newHandler(&served).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status?namespace=other", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Empty(t, served)
+	})
+	t.Run("should answer 404 for a namespace that does not exist", func(t *testing.T) {
+		var served string
+		recorder := httptest.NewRecorder()
+
+		newHandler(&served).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status?namespace=deleted", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Empty(t, served)
+	})
+	t.Run("should serve a namespace the user may read", func(t *testing.T) {
+		// given
+		var served string
+		authorizer := newMockNamespaceAuthorizer(t)
+		authorizer.EXPECT().AuthorizeNamespace(mock.Anything, "ecosystem-2").Return(nil)
+		handler := newHandler(&served)
+		handler.SetAuthorizer(authorizer)
+		recorder := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status?namespace=ecosystem-2", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "ecosystem-2", served)
+	})
+	t.Run("should answer 403 for a namespace the user may not read", func(t *testing.T) {
+		// given
+		var served string
+		authorizer := newMockNamespaceAuthorizer(t)
+		authorizer.EXPECT().AuthorizeNamespace(mock.Anything, "other").Return(fmt.Errorf("%w: user jane", ErrForbidden))
+		handler := NewNamespaceHandler("ecosystem", func(context.Context, string) (http.Handler, error) {
+			t.Fatal("must not select the handler of a forbidden namespace")
+			return nil, nil
+		})
+		handler.SetAuthorizer(authorizer)
+		recorder := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status?namespace=other", nil))
+
+		// then
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Empty(t, served)
+	})
+	t.Run("should fail if the namespace cannot be authorized", func(t *testing.T) {
+		var served string
+		authorizer := newMockNamespaceAuthorizer(t)
+		authorizer.EXPECT().AuthorizeNamespace(mock.Anything, "ecosystem").Return(assert.AnError)
+		handler := newHandler(&served)
+		handler.SetAuthorizer(authorizer)
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Empty(t, served)
+	})
+	t.Run("should answer 400 for an invalid namespace", func(t *testing.T) {
+		var served string
+		recorder := httptest.NewRecorder()
+
+		newHandler(&served).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status?namespace=Not_Valid", nil))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Empty(t, served)
+	})
+	t.Run("should fail if the handler cannot be created", func(t *testing.T) {
+		var served string
+		recorder := httptest.NewRecorder()
+
+		newHandler(&served).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/status?namespace=broken", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), assert.AnError.Error())
+	})
+}
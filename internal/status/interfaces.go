@@ -0,0 +1,56 @@
+package status
+
+import (
+	"context"
+
+	libclient "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	typev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+type debugModeInterface interface {
+	libclient.DebugModeInterface
+}
+
+type doguInterface interface {
+	doguClient.DoguInterface
+}
+
+type configMapInterface interface {
+	typev1.ConfigMapInterface
+}
+
+type eventInterface interface {
+	typev1.EventInterface
+}
+
+type logLevelHandler interface {
+	loglevel.LogLevelHandler
+}
+
+// historyReader reads the audit records of all kept debug mode sessions.
+type historyReader interface {
+	List(ctx context.Context) ([]audit.Record, error)
+}
+
+// statusReader reads the current status of the debug mode.
+type statusReader interface {
+	Read(ctx context.Context) (Status, error)
+}
+
+type requestAuthenticator interface {
+	authenticator.Request
+}
+
+type accessAuthorizer interface {
+	authorizer.Authorizer
+}
+
+// namespaceAuthorizer authorizes the user of a request to read the status of a namespace.
+type namespaceAuthorizer interface {
+	AuthorizeNamespace(ctx context.Context, namespace string) error
+}
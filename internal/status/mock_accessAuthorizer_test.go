@@ -0,0 +1,102 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package status
+
+import (
+	context "context"
+
+	authorizer "k8s.io/apiserver/pkg/authorization/authorizer"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockAccessAuthorizer is an autogenerated mock type for the accessAuthorizer type
+type mockAccessAuthorizer struct {
+	mock.Mock
+}
+
+type mockAccessAuthorizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockAccessAuthorizer) EXPECT() *mockAccessAuthorizer_Expecter {
+	return &mockAccessAuthorizer_Expecter{mock: &_m.Mock}
+}
+
+// Authorize provides a mock function with given fields: ctx, a
+func (_m *mockAccessAuthorizer) Authorize(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+	ret := _m.Called(ctx, a)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authorize")
+	}
+
+	var r0 authorizer.Decision
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error)); ok {
+		return rf(ctx, a)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authorizer.Attributes) authorizer.Decision); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Get(0).(authorizer.Decision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authorizer.Attributes) string); ok {
+		r1 = rf(ctx, a)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, authorizer.Attributes) error); ok {
+		r2 = rf(ctx, a)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// mockAccessAuthorizer_Authorize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authorize'
+type mockAccessAuthorizer_Authorize_Call struct {
+	*mock.Call
+}
+
+// Authorize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - a authorizer.Attributes
+func (_e *mockAccessAuthorizer_Expecter) Authorize(ctx interface{}, a interface{}) *mockAccessAuthorizer_Authorize_Call {
+	return &mockAccessAuthorizer_Authorize_Call{Call: _e.mock.On("Authorize", ctx, a)}
+}
+
+func (_c *mockAccessAuthorizer_Authorize_Call) Run(run func(ctx context.Context, a authorizer.Attributes)) *mockAccessAuthorizer_Authorize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(authorizer.Attributes))
+	})
+	return _c
+}
+
+func (_c *mockAccessAuthorizer_Authorize_Call) Return(authorized authorizer.Decision, reason string, err error) *mockAccessAuthorizer_Authorize_Call {
+	_c.Call.Return(authorized, reason, err)
+	return _c
+}
+
+func (_c *mockAccessAuthorizer_Authorize_Call) RunAndReturn(run func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error)) *mockAccessAuthorizer_Authorize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockAccessAuthorizer creates a new instance of mockAccessAuthorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockAccessAuthorizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockAccessAuthorizer {
+	mock := &mockAccessAuthorizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
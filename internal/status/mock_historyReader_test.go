@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package status
+
+import (
+	context "context"
+
+	audit "github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockHistoryReader is an autogenerated mock type for the historyReader type
+type mockHistoryReader struct {
+	mock.Mock
+}
+
+type mockHistoryReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockHistoryReader) EXPECT() *mockHistoryReader_Expecter {
+	return &mockHistoryReader_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *mockHistoryReader) List(ctx context.Context) ([]audit.Record, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []audit.Record
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]audit.Record, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []audit.Record); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]audit.Record)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockHistoryReader_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockHistoryReader_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockHistoryReader_Expecter) List(ctx interface{}) *mockHistoryReader_List_Call {
+	return &mockHistoryReader_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *mockHistoryReader_List_Call) Run(run func(ctx context.Context)) *mockHistoryReader_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockHistoryReader_List_Call) Return(_a0 []audit.Record, _a1 error) *mockHistoryReader_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockHistoryReader_List_Call) RunAndReturn(run func(context.Context) ([]audit.Record, error)) *mockHistoryReader_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockHistoryReader creates a new instance of mockHistoryReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockHistoryReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockHistoryReader {
+	mock := &mockHistoryReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,397 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package status
+
+import (
+	context "context"
+
+	loglevel "github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockLogLevelHandler is an autogenerated mock type for the logLevelHandler type
+type mockLogLevelHandler struct {
+	mock.Mock
+}
+
+type mockLogLevelHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockLogLevelHandler) EXPECT() *mockLogLevelHandler_Expecter {
+	return &mockLogLevelHandler_Expecter{mock: &_m.Mock}
+}
+
+// GetLogLevel provides a mock function with given fields: ctx, element
+func (_m *mockLogLevelHandler) GetLogLevel(ctx context.Context, element interface{}) (loglevel.LogLevel, error) {
+	ret := _m.Called(ctx, element)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogLevel")
+	}
+
+	var r0 loglevel.LogLevel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) (loglevel.LogLevel, error)); ok {
+		return rf(ctx, element)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) loglevel.LogLevel); ok {
+		r0 = rf(ctx, element)
+	} else {
+		r0 = ret.Get(0).(loglevel.LogLevel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, element)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockLogLevelHandler_GetLogLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogLevel'
+type mockLogLevelHandler_GetLogLevel_Call struct {
+	*mock.Call
+}
+
+// GetLogLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+func (_e *mockLogLevelHandler_Expecter) GetLogLevel(ctx interface{}, element interface{}) *mockLogLevelHandler_GetLogLevel_Call {
+	return &mockLogLevelHandler_GetLogLevel_Call{Call: _e.mock.On("GetLogLevel", ctx, element)}
+}
+
+func (_c *mockLogLevelHandler_GetLogLevel_Call) Run(run func(ctx context.Context, element interface{})) *mockLogLevelHandler_GetLogLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *mockLogLevelHandler_GetLogLevel_Call) Return(_a0 loglevel.LogLevel, _a1 error) *mockLogLevelHandler_GetLogLevel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockLogLevelHandler_GetLogLevel_Call) RunAndReturn(run func(context.Context, interface{}) (loglevel.LogLevel, error)) *mockLogLevelHandler_GetLogLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLogLevelState provides a mock function with given fields: ctx, element
+func (_m *mockLogLevelHandler) GetLogLevelState(ctx context.Context, element interface{}) (loglevel.LogLevelState, error) {
+	ret := _m.Called(ctx, element)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogLevelState")
+	}
+
+	var r0 loglevel.LogLevelState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) (loglevel.LogLevelState, error)); ok {
+		return rf(ctx, element)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) loglevel.LogLevelState); ok {
+		r0 = rf(ctx, element)
+	} else {
+		r0 = ret.Get(0).(loglevel.LogLevelState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, element)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockLogLevelHandler_GetLogLevelState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogLevelState'
+type mockLogLevelHandler_GetLogLevelState_Call struct {
+	*mock.Call
+}
+
+// GetLogLevelState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+func (_e *mockLogLevelHandler_Expecter) GetLogLevelState(ctx interface{}, element interface{}) *mockLogLevelHandler_GetLogLevelState_Call {
+	return &mockLogLevelHandler_GetLogLevelState_Call{Call: _e.mock.On("GetLogLevelState", ctx, element)}
+}
+
+func (_c *mockLogLevelHandler_GetLogLevelState_Call) Run(run func(ctx context.Context, element interface{})) *mockLogLevelHandler_GetLogLevelState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *mockLogLevelHandler_GetLogLevelState_Call) Return(_a0 loglevel.LogLevelState, _a1 error) *mockLogLevelHandler_GetLogLevelState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockLogLevelHandler_GetLogLevelState_Call) RunAndReturn(run func(context.Context, interface{}) (loglevel.LogLevelState, error)) *mockLogLevelHandler_GetLogLevelState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsLogLevelSupported provides a mock function with given fields: ctx, element, logLevel
+func (_m *mockLogLevelHandler) IsLogLevelSupported(ctx context.Context, element interface{}, logLevel loglevel.LogLevel) (bool, error) {
+	ret := _m.Called(ctx, element, logLevel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLogLevelSupported")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, loglevel.LogLevel) (bool, error)); ok {
+		return rf(ctx, element, logLevel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, loglevel.LogLevel) bool); ok {
+		r0 = rf(ctx, element, logLevel)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, loglevel.LogLevel) error); ok {
+		r1 = rf(ctx, element, logLevel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockLogLevelHandler_IsLogLevelSupported_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLogLevelSupported'
+type mockLogLevelHandler_IsLogLevelSupported_Call struct {
+	*mock.Call
+}
+
+// IsLogLevelSupported is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+//   - logLevel loglevel.LogLevel
+func (_e *mockLogLevelHandler_Expecter) IsLogLevelSupported(ctx interface{}, element interface{}, logLevel interface{}) *mockLogLevelHandler_IsLogLevelSupported_Call {
+	return &mockLogLevelHandler_IsLogLevelSupported_Call{Call: _e.mock.On("IsLogLevelSupported", ctx, element, logLevel)}
+}
+
+func (_c *mockLogLevelHandler_IsLogLevelSupported_Call) Run(run func(ctx context.Context, element interface{}, logLevel loglevel.LogLevel)) *mockLogLevelHandler_IsLogLevelSupported_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}), args[2].(loglevel.LogLevel))
+	})
+	return _c
+}
+
+func (_c *mockLogLevelHandler_IsLogLevelSupported_Call) Return(_a0 bool, _a1 error) *mockLogLevelHandler_IsLogLevelSupported_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockLogLevelHandler_IsLogLevelSupported_Call) RunAndReturn(run func(context.Context, interface{}, loglevel.LogLevel) (bool, error)) *mockLogLevelHandler_IsLogLevelSupported_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Kind provides a mock function with no fields
+func (_m *mockLogLevelHandler) Kind() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Kind")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// mockLogLevelHandler_Kind_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Kind'
+type mockLogLevelHandler_Kind_Call struct {
+	*mock.Call
+}
+
+// Kind is a helper method to define mock.On call
+func (_e *mockLogLevelHandler_Expecter) Kind() *mockLogLevelHandler_Kind_Call {
+	return &mockLogLevelHandler_Kind_Call{Call: _e.mock.On("Kind")}
+}
+
+func (_c *mockLogLevelHandler_Kind_Call) Run(run func()) *mockLogLevelHandler_Kind_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockLogLevelHandler_Kind_Call) Return(_a0 string) *mockLogLevelHandler_Kind_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockLogLevelHandler_Kind_Call) RunAndReturn(run func() string) *mockLogLevelHandler_Kind_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetLogLevel provides a mock function with given fields: ctx, element
+func (_m *mockLogLevelHandler) ResetLogLevel(ctx context.Context, element interface{}) error {
+	ret := _m.Called(ctx, element)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLogLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
+		r0 = rf(ctx, element)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockLogLevelHandler_ResetLogLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetLogLevel'
+type mockLogLevelHandler_ResetLogLevel_Call struct {
+	*mock.Call
+}
+
+// ResetLogLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+func (_e *mockLogLevelHandler_Expecter) ResetLogLevel(ctx interface{}, element interface{}) *mockLogLevelHandler_ResetLogLevel_Call {
+	return &mockLogLevelHandler_ResetLogLevel_Call{Call: _e.mock.On("ResetLogLevel", ctx, element)}
+}
+
+func (_c *mockLogLevelHandler_ResetLogLevel_Call) Run(run func(ctx context.Context, element interface{})) *mockLogLevelHandler_ResetLogLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *mockLogLevelHandler_ResetLogLevel_Call) Return(_a0 error) *mockLogLevelHandler_ResetLogLevel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockLogLevelHandler_ResetLogLevel_Call) RunAndReturn(run func(context.Context, interface{}) error) *mockLogLevelHandler_ResetLogLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreLogLevel provides a mock function with given fields: ctx, element, state
+func (_m *mockLogLevelHandler) RestoreLogLevel(ctx context.Context, element interface{}, state loglevel.LogLevelState) error {
+	ret := _m.Called(ctx, element, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreLogLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, loglevel.LogLevelState) error); ok {
+		r0 = rf(ctx, element, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockLogLevelHandler_RestoreLogLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreLogLevel'
+type mockLogLevelHandler_RestoreLogLevel_Call struct {
+	*mock.Call
+}
+
+// RestoreLogLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+//   - state loglevel.LogLevelState
+func (_e *mockLogLevelHandler_Expecter) RestoreLogLevel(ctx interface{}, element interface{}, state interface{}) *mockLogLevelHandler_RestoreLogLevel_Call {
+	return &mockLogLevelHandler_RestoreLogLevel_Call{Call: _e.mock.On("RestoreLogLevel", ctx, element, state)}
+}
+
+func (_c *mockLogLevelHandler_RestoreLogLevel_Call) Run(run func(ctx context.Context, element interface{}, state loglevel.LogLevelState)) *mockLogLevelHandler_RestoreLogLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}), args[2].(loglevel.LogLevelState))
+	})
+	return _c
+}
+
+func (_c *mockLogLevelHandler_RestoreLogLevel_Call) Return(_a0 error) *mockLogLevelHandler_RestoreLogLevel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockLogLevelHandler_RestoreLogLevel_Call) RunAndReturn(run func(context.Context, interface{}, loglevel.LogLevelState) error) *mockLogLevelHandler_RestoreLogLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLogLevel provides a mock function with given fields: ctx, element, targetLogLevel
+func (_m *mockLogLevelHandler) SetLogLevel(ctx context.Context, element interface{}, targetLogLevel loglevel.LogLevel) error {
+	ret := _m.Called(ctx, element, targetLogLevel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLogLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, loglevel.LogLevel) error); ok {
+		r0 = rf(ctx, element, targetLogLevel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockLogLevelHandler_SetLogLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLogLevel'
+type mockLogLevelHandler_SetLogLevel_Call struct {
+	*mock.Call
+}
+
+// SetLogLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - element interface{}
+//   - targetLogLevel loglevel.LogLevel
+func (_e *mockLogLevelHandler_Expecter) SetLogLevel(ctx interface{}, element interface{}, targetLogLevel interface{}) *mockLogLevelHandler_SetLogLevel_Call {
+	return &mockLogLevelHandler_SetLogLevel_Call{Call: _e.mock.On("SetLogLevel", ctx, element, targetLogLevel)}
+}
+
+func (_c *mockLogLevelHandler_SetLogLevel_Call) Run(run func(ctx context.Context, element interface{}, targetLogLevel loglevel.LogLevel)) *mockLogLevelHandler_SetLogLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}), args[2].(loglevel.LogLevel))
+	})
+	return _c
+}
+
+func (_c *mockLogLevelHandler_SetLogLevel_Call) Return(_a0 error) *mockLogLevelHandler_SetLogLevel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockLogLevelHandler_SetLogLevel_Call) RunAndReturn(run func(context.Context, interface{}, loglevel.LogLevel) error) *mockLogLevelHandler_SetLogLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockLogLevelHandler creates a new instance of mockLogLevelHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockLogLevelHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockLogLevelHandler {
+	mock := &mockLogLevelHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package status
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockNamespaceAuthorizer is an autogenerated mock type for the namespaceAuthorizer type
+type mockNamespaceAuthorizer struct {
+	mock.Mock
+}
+
+type mockNamespaceAuthorizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockNamespaceAuthorizer) EXPECT() *mockNamespaceAuthorizer_Expecter {
+	return &mockNamespaceAuthorizer_Expecter{mock: &_m.Mock}
+}
+
+// AuthorizeNamespace provides a mock function with given fields: ctx, namespace
+func (_m *mockNamespaceAuthorizer) AuthorizeNamespace(ctx context.Context, namespace string) error {
+	ret := _m.Called(ctx, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthorizeNamespace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockNamespaceAuthorizer_AuthorizeNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthorizeNamespace'
+type mockNamespaceAuthorizer_AuthorizeNamespace_Call struct {
+	*mock.Call
+}
+
+// AuthorizeNamespace is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+func (_e *mockNamespaceAuthorizer_Expecter) AuthorizeNamespace(ctx interface{}, namespace interface{}) *mockNamespaceAuthorizer_AuthorizeNamespace_Call {
+	return &mockNamespaceAuthorizer_AuthorizeNamespace_Call{Call: _e.mock.On("AuthorizeNamespace", ctx, namespace)}
+}
+
+func (_c *mockNamespaceAuthorizer_AuthorizeNamespace_Call) Run(run func(ctx context.Context, namespace string)) *mockNamespaceAuthorizer_AuthorizeNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockNamespaceAuthorizer_AuthorizeNamespace_Call) Return(_a0 error) *mockNamespaceAuthorizer_AuthorizeNamespace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockNamespaceAuthorizer_AuthorizeNamespace_Call) RunAndReturn(run func(context.Context, string) error) *mockNamespaceAuthorizer_AuthorizeNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockNamespaceAuthorizer creates a new instance of mockNamespaceAuthorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockNamespaceAuthorizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockNamespaceAuthorizer {
+	mock := &mockNamespaceAuthorizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package status
+
+import (
+	http "net/http"
+
+	authenticator "k8s.io/apiserver/pkg/authentication/authenticator"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockRequestAuthenticator is an autogenerated mock type for the requestAuthenticator type
+type mockRequestAuthenticator struct {
+	mock.Mock
+}
+
+type mockRequestAuthenticator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockRequestAuthenticator) EXPECT() *mockRequestAuthenticator_Expecter {
+	return &mockRequestAuthenticator_Expecter{mock: &_m.Mock}
+}
+
+// AuthenticateRequest provides a mock function with given fields: req
+func (_m *mockRequestAuthenticator) AuthenticateRequest(req *http.Request) (*authenticator.Response, bool, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateRequest")
+	}
+
+	var r0 *authenticator.Response
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (*authenticator.Response, bool, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) *authenticator.Response); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authenticator.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) bool); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(*http.Request) error); ok {
+		r2 = rf(req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// mockRequestAuthenticator_AuthenticateRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticateRequest'
+type mockRequestAuthenticator_AuthenticateRequest_Call struct {
+	*mock.Call
+}
+
+// AuthenticateRequest is a helper method to define mock.On call
+//   - req *http.Request
+func (_e *mockRequestAuthenticator_Expecter) AuthenticateRequest(req interface{}) *mockRequestAuthenticator_AuthenticateRequest_Call {
+	return &mockRequestAuthenticator_AuthenticateRequest_Call{Call: _e.mock.On("AuthenticateRequest", req)}
+}
+
+func (_c *mockRequestAuthenticator_AuthenticateRequest_Call) Run(run func(req *http.Request)) *mockRequestAuthenticator_AuthenticateRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *mockRequestAuthenticator_AuthenticateRequest_Call) Return(_a0 *authenticator.Response, _a1 bool, _a2 error) *mockRequestAuthenticator_AuthenticateRequest_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *mockRequestAuthenticator_AuthenticateRequest_Call) RunAndReturn(run func(*http.Request) (*authenticator.Response, bool, error)) *mockRequestAuthenticator_AuthenticateRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockRequestAuthenticator creates a new instance of mockRequestAuthenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockRequestAuthenticator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockRequestAuthenticator {
+	mock := &mockRequestAuthenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package status
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockStatusReader is an autogenerated mock type for the statusReader type
+type mockStatusReader struct {
+	mock.Mock
+}
+
+type mockStatusReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockStatusReader) EXPECT() *mockStatusReader_Expecter {
+	return &mockStatusReader_Expecter{mock: &_m.Mock}
+}
+
+// Read provides a mock function with given fields: ctx
+func (_m *mockStatusReader) Read(ctx context.Context) (Status, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Read")
+	}
+
+	var r0 Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (Status, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) Status); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(Status)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStatusReader_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type mockStatusReader_Read_Call struct {
+	*mock.Call
+}
+
+// Read is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockStatusReader_Expecter) Read(ctx interface{}) *mockStatusReader_Read_Call {
+	return &mockStatusReader_Read_Call{Call: _e.mock.On("Read", ctx)}
+}
+
+func (_c *mockStatusReader_Read_Call) Run(run func(ctx context.Context)) *mockStatusReader_Read_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockStatusReader_Read_Call) Return(_a0 Status, _a1 error) *mockStatusReader_Read_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStatusReader_Read_Call) RunAndReturn(run func(context.Context) (Status, error)) *mockStatusReader_Read_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockStatusReader creates a new instance of mockStatusReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockStatusReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockStatusReader {
+	mock := &mockStatusReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,195 @@
+// Package status provides the current state of the debug mode for clients that cannot read the state map themselves.
+package status
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DebugModeName is the only name a DebugMode may have.
+	DebugModeName = "debug-mode"
+
+	// KindDogu is the kind of state map entries of dogus.
+	KindDogu = "dogu"
+	// KindComponent is the kind of state map entries of platform components.
+	KindComponent = "component"
+
+	// LevelInvalid is reported as original log level if the state map entry cannot be read.
+	LevelInvalid = "invalid"
+	// LevelUninstalled is reported as current log level of dogus that are not installed anymore.
+	LevelUninstalled = "uninstalled"
+	// LevelUnknown is reported as current log level if it cannot be read.
+	LevelUnknown = "unknown"
+)
+
+// Status is the current state of the debug mode.
+type Status struct {
+	// Found is false if no DebugMode exists. All other fields are empty then.
+	Found               bool       `json:"found"`
+	Phase               string     `json:"phase,omitempty"`
+	TargetLogLevel      string     `json:"targetLogLevel,omitempty"`
+	DeactivateTimestamp *time.Time `json:"deactivateTimestamp,omitempty"`
+	// RemainingSeconds is the time until the deactivation, 0 if it has passed.
+	RemainingSeconds int64        `json:"remainingSeconds"`
+	Message          string       `json:"message,omitempty"`
+	Elements         []Element    `json:"elements"`
+	Errors           []ErrorEntry `json:"errors"`
+}
+
+// Element is the log level of a single dogu or component changed by the debug mode.
+type Element struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Original string `json:"original"`
+	// Current is the current log level. It is only read for dogus.
+	Current string `json:"current,omitempty"`
+	Target  string `json:"target"`
+}
+
+// Reader reads the status of the debug mode from the DebugMode, its state map and the dogu config.
+type Reader struct {
+	debugModes      debugModeInterface
+	configMaps      configMapInterface
+	dogus           doguInterface
+	logLevelHandler logLevelHandler
+	events          eventInterface
+	now             func() time.Time
+}
+
+// NewReader creates a reader. The events are optional; no errors are read without them.
+func NewReader(debugModes debugModeInterface, configMaps configMapInterface, dogus doguInterface, handler logLevelHandler, events eventInterface) *Reader {
+	return &Reader{
+		debugModes:      debugModes,
+		configMaps:      configMaps,
+		dogus:           dogus,
+		logLevelHandler: handler,
+		events:          events,
+		now:             time.Now,
+	}
+}
+
+// Read returns the current status of the debug mode.
+func (r *Reader) Read(ctx context.Context) (Status, error) {
+	status := Status{Elements: []Element{}, Errors: []ErrorEntry{}}
+
+	cr, err := r.debugModes.Get(ctx, DebugModeName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return status, nil
+		}
+		return status, fmt.Errorf("failed to get debug mode: %w", err)
+	}
+
+	status.Found = true
+	status.Phase = Phase(cr)
+	status.TargetLogLevel = cr.Spec.TargetLogLevel
+	deactivate := cr.Spec.DeactivateTimestamp.Time
+	status.DeactivateTimestamp = &deactivate
+	if remaining := deactivate.Sub(r.now()); remaining > 0 {
+		status.RemainingSeconds = int64(remaining.Seconds())
+	}
+	if cond := meta.FindStatusCondition(cr.Status.Conditions, k8sCRLib.ConditionLogLevelSet); cond != nil {
+		status.Message = cond.Message
+	}
+
+	status.Elements, err = r.Elements(ctx, cr)
+	if err != nil {
+		return status, err
+	}
+	if r.events != nil {
+		status.Errors, err = r.errorsOf(ctx, cr)
+		if err != nil {
+			return status, err
+		}
+	}
+	return status, nil
+}
+
+// Elements reads the original log levels from the state map of the given DebugMode and adds the current log levels
+// of the dogus.
+func (r *Reader) Elements(ctx context.Context, cr *k8sCRLib.DebugMode) ([]Element, error) {
+	list, err := r.configMaps.List(ctx, metav1.ListOptions{LabelSelector: controller.StateMapSelector(cr.Name)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list state maps: %w", err)
+	}
+
+	var dogus map[string]any
+	elements := []Element{}
+	for _, stateMap := range list.Items {
+		if !isOwnedBy(stateMap.OwnerReferences, cr) {
+			continue
+		}
+		for key, raw := range stateMap.Data {
+			kind, name, found := strings.Cut(key, ".")
+			if !found || (kind != KindDogu && kind != KindComponent) {
+				continue
+			}
+			element := Element{Kind: kind, Name: name, Original: LevelInvalid, Target: cr.Spec.TargetLogLevel}
+			if entry, err := controller.ParseStateEntry(raw); err == nil {
+				element.Original = entry.Level
+			}
+			if kind == KindDogu {
+				if dogus == nil {
+					dogus, err = r.installedDogus(ctx)
+					if err != nil {
+						return nil, err
+					}
+				}
+				element.Current = r.CurrentLevel(ctx, dogus[name])
+			}
+			elements = append(elements, element)
+		}
+	}
+
+	slices.SortFunc(elements, func(a, b Element) int {
+		return strings.Compare(a.Kind+"."+a.Name, b.Kind+"."+b.Name)
+	})
+	return elements, nil
+}
+
+// CurrentLevel returns the current log level of the given dogu or a placeholder if it cannot be read.
+func (r *Reader) CurrentLevel(ctx context.Context, dogu any) string {
+	if dogu == nil {
+		return LevelUninstalled
+	}
+	state, err := r.logLevelHandler.GetLogLevelState(ctx, dogu)
+	if err != nil {
+		return LevelUnknown
+	}
+	return state.Level.String()
+}
+
+// installedDogus returns all installed dogus by name.
+func (r *Reader) installedDogus(ctx context.Context) (map[string]any, error) {
+	list, err := r.dogus.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dogus: %w", err)
+	}
+	dogus := make(map[string]any, len(list.Items))
+	for _, dogu := range list.Items {
+		dogus[dogu.Name] = dogu
+	}
+	return dogus, nil
+}
+
+// Phase returns the phase of the DebugMode or "Pending" if the operator has not handled it yet.
+func Phase(cr *k8sCRLib.DebugMode) string {
+	if cr.Status.Phase == "" {
+		return "Pending"
+	}
+	return string(cr.Status.Phase)
+}
+
+func isOwnedBy(refs []metav1.OwnerReference, cr *k8sCRLib.DebugMode) bool {
+	return slices.ContainsFunc(refs, func(ref metav1.OwnerReference) bool { return ref.UID == cr.UID })
+}
@@ -0,0 +1,174 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testNow = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+type readerMocks struct {
+	debugModes      *mockDebugModeInterface
+	configMaps      *mockConfigMapInterface
+	dogus           *mockDoguInterface
+	logLevelHandler *mockLogLevelHandler
+	events          *mockEventInterface
+}
+
+func newTestReader(t *testing.T) (*Reader, readerMocks) {
+	m := readerMocks{
+		debugModes:      newMockDebugModeInterface(t),
+		configMaps:      newMockConfigMapInterface(t),
+		dogus:           newMockDoguInterface(t),
+		logLevelHandler: newMockLogLevelHandler(t),
+		events:          newMockEventInterface(t),
+	}
+	reader := NewReader(m.debugModes, m.configMaps, m.dogus, m.logLevelHandler, m.events)
+	reader.now = func() time.Time { return testNow }
+	return reader, m
+}
+
+func TestReader_Read(t *testing.T) {
+	t.Run("should read debug mode with original and current log levels", func(t *testing.T) {
+		// given
+		reader, m := newTestReader(t)
+
+		deactivate := testNow.Add(90 * time.Second)
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: DebugModeName, UID: "uid-1"},
+			Spec:       k8sCRLib.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(deactivate), TargetLogLevel: "DEBUG"},
+			Status: k8sCRLib.DebugModeStatus{
+				Phase:      k8sCRLib.DebugModeStatusWaitForRollback,
+				Conditions: []metav1.Condition{{Type: k8sCRLib.ConditionLogLevelSet, Message: "Debug-Mode set"}},
+			},
+		}
+		m.debugModes.EXPECT().Get(t.Context(), DebugModeName, metav1.GetOptions{}).Return(cr, nil)
+		m.configMaps.EXPECT().List(t.Context(), metav1.ListOptions{LabelSelector: controller.StateMapSelector(DebugModeName)}).
+			Return(&corev1.ConfigMapList{Items: []corev1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{UID: "uid-1"}}},
+					Data: map[string]string{
+						"dogu.cas":                    "WARN",
+						"dogu.redmine":                `{"version":1,"level":"ERROR","checksum":"manipulated"}`,
+						"component.k8s-dogu-operator": "INFO",
+						"doguconfig.cas":              "{}",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{UID: "previous"}}},
+					Data:       map[string]string{"dogu.ldap": "INFO"},
+				},
+			}}, nil)
+		cas := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas"}}
+		m.dogus.EXPECT().List(t.Context(), metav1.ListOptions{}).Return(&v2.DoguList{Items: []v2.Dogu{cas}}, nil)
+		m.logLevelHandler.EXPECT().GetLogLevelState(t.Context(), cas).Return(loglevel.LogLevelState{Level: loglevel.LevelDebug}, nil)
+		m.events.EXPECT().List(t.Context(), metav1.ListOptions{FieldSelector: "involvedObject.uid=uid-1,reason=ReconcileFailed"}).
+			Return(&corev1.EventList{Items: []corev1.Event{{Message: "failed to set log level", LastTimestamp: metav1.NewTime(testNow)}}}, nil)
+
+		// when
+		status, err := reader.Read(t.Context())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Status{
+			Found:               true,
+			Phase:               "WaitForRollback",
+			TargetLogLevel:      "DEBUG",
+			DeactivateTimestamp: &deactivate,
+			RemainingSeconds:    90,
+			Message:             "Debug-Mode set",
+			Elements: []Element{
+				{Kind: KindComponent, Name: "k8s-dogu-operator", Original: "INFO", Target: "DEBUG"},
+				{Kind: KindDogu, Name: "cas", Original: "WARN", Current: "DEBUG", Target: "DEBUG"},
+				{Kind: KindDogu, Name: "redmine", Original: LevelInvalid, Current: LevelUninstalled, Target: "DEBUG"},
+			},
+			Errors: []ErrorEntry{{Time: testNow, Name: DebugModeName, Message: "failed to set log level"}},
+		}, status)
+	})
+	t.Run("should report missing debug mode", func(t *testing.T) {
+		// given
+		reader, m := newTestReader(t)
+		m.debugModes.EXPECT().Get(t.Context(), DebugModeName, metav1.GetOptions{}).
+			Return(nil, apierrors.NewNotFound(schema.GroupResource{}, DebugModeName))
+
+		// when
+		status, err := reader.Read(t.Context())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Status{Elements: []Element{}, Errors: []ErrorEntry{}}, status)
+	})
+	t.Run("should report no remaining time after deactivation", func(t *testing.T) {
+		// given
+		reader, m := newTestReader(t)
+		cr := &k8sCRLib.DebugMode{
+			ObjectMeta: metav1.ObjectMeta{Name: DebugModeName},
+			Spec:       k8sCRLib.DebugModeSpec{DeactivateTimestamp: metav1.NewTime(testNow.Add(-time.Minute))},
+		}
+		m.debugModes.EXPECT().Get(t.Context(), DebugModeName, metav1.GetOptions{}).Return(cr, nil)
+		m.configMaps.EXPECT().List(t.Context(), metav1.ListOptions{LabelSelector: controller.StateMapSelector(DebugModeName)}).
+			Return(&corev1.ConfigMapList{}, nil)
+		m.events.EXPECT().List(t.Context(), mock.Anything).Return(&corev1.EventList{}, nil)
+
+		// when
+		status, err := reader.Read(t.Context())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Pending", status.Phase)
+		assert.Zero(t, status.RemainingSeconds)
+		assert.Empty(t, status.Elements)
+	})
+	t.Run("should fail to get debug mode", func(t *testing.T) {
+		reader, m := newTestReader(t)
+		m.debugModes.EXPECT().Get(t.Context(), DebugModeName, metav1.GetOptions{}).Return(nil, assert.AnError)
+
+		_, err := reader.Read(t.Context())
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("should fail to list state maps", func(t *testing.T) {
+		reader, m := newTestReader(t)
+		m.debugModes.EXPECT().Get(t.Context(), DebugModeName, metav1.GetOptions{}).Return(&k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: DebugModeName}}, nil)
+		m.configMaps.EXPECT().List(t.Context(), metav1.ListOptions{LabelSelector: controller.StateMapSelector(DebugModeName)}).Return(nil, assert.AnError)
+
+		_, err := reader.Read(t.Context())
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to list state maps")
+	})
+	t.Run("should fail to list events", func(t *testing.T) {
+		reader, m := newTestReader(t)
+		m.debugModes.EXPECT().Get(t.Context(), DebugModeName, metav1.GetOptions{}).Return(&k8sCRLib.DebugMode{ObjectMeta: metav1.ObjectMeta{Name: DebugModeName}}, nil)
+		m.configMaps.EXPECT().List(t.Context(), metav1.ListOptions{LabelSelector: controller.StateMapSelector(DebugModeName)}).Return(&corev1.ConfigMapList{}, nil)
+		m.events.EXPECT().List(t.Context(), mock.Anything).Return(nil, assert.AnError)
+
+		_, err := reader.Read(t.Context())
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to list events")
+	})
+}
+
+func TestReader_CurrentLevel(t *testing.T) {
+	t.Run("should report unknown level if it cannot be read", func(t *testing.T) {
+		reader, m := newTestReader(t)
+		cas := v2.Dogu{ObjectMeta: metav1.ObjectMeta{Name: "cas"}}
+		m.logLevelHandler.EXPECT().GetLogLevelState(t.Context(), cas).Return(loglevel.LogLevelState{}, assert.AnError)
+
+		assert.Equal(t, LevelUnknown, reader.CurrentLevel(t.Context(), cas))
+	})
+}
@@ -0,0 +1,28 @@
+package trigger
+
+import (
+	"context"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	libclient "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// objectReader reads the Dogus and DebugModes from the cache of the manager.
+type objectReader interface {
+	Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
+}
+
+type debugModeInterface interface {
+	libclient.DebugModeInterface
+}
+
+// doguDescriptorGetter provides the descriptor of the installed version of a dogu.
+type doguDescriptorGetter interface {
+	GetCurrent(ctx context.Context, simpleDoguName string) (*core.Dogu, error)
+}
+
+type eventRecorder interface {
+	events.EventRecorder
+}
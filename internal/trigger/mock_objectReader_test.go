@@ -0,0 +1,103 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package trigger
+
+import (
+	context "context"
+
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// mockObjectReader is an autogenerated mock type for the objectReader type
+type mockObjectReader struct {
+	mock.Mock
+}
+
+type mockObjectReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockObjectReader) EXPECT() *mockObjectReader_Expecter {
+	return &mockObjectReader_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, key, obj, opts
+func (_m *mockObjectReader) Get(ctx context.Context, key types.NamespacedName, obj client.Object, opts ...client.GetOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key, obj)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.NamespacedName, client.Object, ...client.GetOption) error); ok {
+		r0 = rf(ctx, key, obj, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockObjectReader_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type mockObjectReader_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key types.NamespacedName
+//   - obj client.Object
+//   - opts ...client.GetOption
+func (_e *mockObjectReader_Expecter) Get(ctx interface{}, key interface{}, obj interface{}, opts ...interface{}) *mockObjectReader_Get_Call {
+	return &mockObjectReader_Get_Call{Call: _e.mock.On("Get",
+		append([]interface{}{ctx, key, obj}, opts...)...)}
+}
+
+func (_c *mockObjectReader_Get_Call) Run(run func(ctx context.Context, key types.NamespacedName, obj client.Object, opts ...client.GetOption)) *mockObjectReader_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]client.GetOption, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(client.GetOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(types.NamespacedName), args[2].(client.Object), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockObjectReader_Get_Call) Return(_a0 error) *mockObjectReader_Get_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockObjectReader_Get_Call) RunAndReturn(run func(context.Context, types.NamespacedName, client.Object, ...client.GetOption) error) *mockObjectReader_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockObjectReader creates a new instance of mockObjectReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockObjectReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockObjectReader {
+	mock := &mockObjectReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Package trigger starts debug modes automatically when selected dogus become unhealthy or restart repeatedly, so the
+// logs of a problem are captured before it is gone.
+package trigger
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AllDogus as the only selected dogu lets every dogu trigger a debug mode.
+const AllDogus = "*"
+
+// Policy decides which degradations of dogus start a debug mode and limits how often this happens.
+type Policy struct {
+	// Dogus are the dogus whose degradation triggers a debug mode. AllDogus selects all dogus. Automatic debug modes
+	// are disabled if it is empty.
+	Dogus []string `json:"dogus"`
+	// UnhealthyFor is the time a dogu must stay unhealthy before a debug mode is started, so regular restarts do not
+	// trigger one.
+	UnhealthyFor metav1.Duration `json:"unhealthyFor"`
+	// Restarts is the number of restarts within the RestartWindow that triggers a debug mode. Zero ignores restarts.
+	Restarts      int             `json:"restarts"`
+	RestartWindow metav1.Duration `json:"restartWindow"`
+	// Duration is the time a triggered debug mode stays active.
+	Duration       metav1.Duration `json:"duration"`
+	TargetLogLevel string          `json:"targetLogLevel"`
+	// Cooldown is the time after the end of a debug mode in which degradations are ignored, because changing the log
+	// levels restarts the dogus.
+	Cooldown metav1.Duration `json:"cooldown"`
+	// MaxTriggers is the number of debug modes that may be triggered in a namespace within the RateLimitWindow.
+	MaxTriggers     int             `json:"maxTriggers"`
+	RateLimitWindow metav1.Duration `json:"rateLimitWindow"`
+}
+
+// DefaultPolicy returns the policy used for all settings that are not configured. It selects no dogus.
+func DefaultPolicy() Policy {
+	return Policy{
+		UnhealthyFor:    metav1.Duration{Duration: 2 * time.Minute},
+		Restarts:        3,
+		RestartWindow:   metav1.Duration{Duration: 10 * time.Minute},
+		Duration:        metav1.Duration{Duration: 30 * time.Minute},
+		TargetLogLevel:  loglevel.LevelDebug.String(),
+		Cooldown:        metav1.Duration{Duration: time.Hour},
+		MaxTriggers:     3,
+		RateLimitWindow: metav1.Duration{Duration: 24 * time.Hour},
+	}
+}
+
+// Enabled returns true if at least one dogu may trigger a debug mode.
+func (p Policy) Enabled() bool {
+	return len(p.Dogus) > 0
+}
+
+// Selects returns true if the degradation of the dogu triggers a debug mode.
+func (p Policy) Selects(dogu string) bool {
+	return slices.Equal(p.Dogus, []string{AllDogus}) || slices.Contains(p.Dogus, dogu)
+}
+
+// Validate returns an error for every invalid setting. A disabled policy is always valid.
+func (p Policy) Validate() error {
+	if !p.Enabled() {
+		return nil
+	}
+	var errs []error
+	for _, dogu := range p.Dogus {
+		if dogu == "" || (dogu == AllDogus && len(p.Dogus) > 1) {
+			errs = append(errs, fmt.Errorf("invalid automatic debug mode dogu %q, expected dogu names or only %s", dogu, AllDogus))
+		}
+	}
+	if p.UnhealthyFor.Duration < 0 {
+		errs = append(errs, fmt.Errorf("automatic debug mode unhealthy time %s must not be negative", p.UnhealthyFor.Duration))
+	}
+	if p.Restarts < 0 {
+		errs = append(errs, fmt.Errorf("automatic debug mode restarts %d must not be negative", p.Restarts))
+	}
+	if p.Restarts > 0 && p.RestartWindow.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("automatic debug mode restart window %s must be positive", p.RestartWindow.Duration))
+	}
+	if p.Duration.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("automatic debug mode duration %s must be positive", p.Duration.Duration))
+	}
+	if _, err := loglevel.CreateLogLevelFromString(p.TargetLogLevel); err != nil {
+		errs = append(errs, fmt.Errorf("invalid automatic debug mode target log level: %w", err))
+	}
+	if p.Cooldown.Duration < 0 {
+		errs = append(errs, fmt.Errorf("automatic debug mode cooldown %s must not be negative", p.Cooldown.Duration))
+	}
+	if p.MaxTriggers <= 0 || p.RateLimitWindow.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("automatic debug mode rate limit of %d per %s must be positive", p.MaxTriggers, p.RateLimitWindow.Duration))
+	}
+	return errors.Join(errs...)
+}
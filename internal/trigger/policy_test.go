@@ -0,0 +1,74 @@
+package trigger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicy_Selects(t *testing.T) {
+	t.Run("should select listed dogus", func(t *testing.T) {
+		policy := Policy{Dogus: []string{"cas", "ldap"}}
+
+		assert.True(t, policy.Selects("cas"))
+		assert.False(t, policy.Selects("redmine"))
+	})
+	t.Run("should select all dogus", func(t *testing.T) {
+		assert.True(t, Policy{Dogus: []string{AllDogus}}.Selects("redmine"))
+	})
+	t.Run("should select no dogus by default", func(t *testing.T) {
+		assert.False(t, DefaultPolicy().Enabled())
+		assert.False(t, DefaultPolicy().Selects("cas"))
+	})
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(policy *Policy)
+		errMsg string
+	}{
+		{name: "all dogus combined with others", modify: func(policy *Policy) { policy.Dogus = []string{"cas", AllDogus} },
+			errMsg: `invalid automatic debug mode dogu "*"`},
+		{name: "negative unhealthy time", modify: func(policy *Policy) { policy.UnhealthyFor = metav1.Duration{Duration: -1} },
+			errMsg: "unhealthy time -1ns must not be negative"},
+		{name: "negative restarts", modify: func(policy *Policy) { policy.Restarts = -1 },
+			errMsg: "restarts -1 must not be negative"},
+		{name: "zero restart window", modify: func(policy *Policy) { policy.RestartWindow = metav1.Duration{} },
+			errMsg: "restart window 0s must be positive"},
+		{name: "zero duration", modify: func(policy *Policy) { policy.Duration = metav1.Duration{} },
+			errMsg: "duration 0s must be positive"},
+		{name: "unknown target log level", modify: func(policy *Policy) { policy.TargetLogLevel = "VERBOSE" },
+			errMsg: "invalid automatic debug mode target log level"},
+		{name: "zero max triggers", modify: func(policy *Policy) { policy.MaxTriggers = 0 },
+			errMsg: "rate limit of 0 per 24h0m0s must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run("should fail for "+tt.name, func(t *testing.T) {
+			// given
+			policy := DefaultPolicy()
+			policy.Dogus = []string{"cas"}
+			tt.modify(&policy)
+
+			// when
+			err := policy.Validate()
+
+			// then
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+	t.Run("should ignore the restart window if restarts are ignored", func(t *testing.T) {
+		policy := DefaultPolicy()
+		policy.Dogus = []string{"cas"}
+		policy.Restarts = 0
+		policy.RestartWindow = metav1.Duration{}
+
+		require.NoError(t, policy.Validate())
+	})
+	t.Run("should accept a disabled policy", func(t *testing.T) {
+		require.NoError(t, Policy{}.Validate())
+	})
+}
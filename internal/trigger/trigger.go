@@ -0,0 +1,353 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/status"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// fieldManager identifies the trigger as creator of a DebugMode in its audit record.
+	fieldManager = "k8s-debug-mode-operator-trigger"
+	// deletionPollInterval is the time after which the trigger checks again whether a completed DebugMode is gone.
+	deletionPollInterval = 2 * time.Second
+
+	triggerReason = "DebugModeTriggered"
+	triggerAction = "TriggerDebugMode"
+)
+
+// Clients are the clients of the Cloudogu EcoSystem in one namespace.
+type Clients struct {
+	DebugModes  debugModeInterface
+	Descriptors doguDescriptorGetter
+}
+
+// Trigger watches the health of the selected dogus and starts a debug mode for a dogu and its dependencies when the
+// dogu stays unhealthy or restarts repeatedly. Degradations are ignored while a debug mode is active and for the
+// cooldown after it ended, because changing the log levels restarts the dogus. The observed state is kept in memory,
+// so it starts over when the operator restarts.
+type Trigger struct {
+	policy   Policy
+	reader   objectReader
+	clients  func(namespace string) (Clients, error)
+	recorder eventRecorder
+	now      func() time.Time
+
+	// the controller has a single worker, so the maps are never accessed concurrently
+	dogus map[types.NamespacedName]*doguState
+	// triggered contains the start times of the debug modes triggered in each namespace for the rate limit.
+	triggered map[string][]time.Time
+	// endedAt contains the latest known end of a debug mode in each namespace for the cooldown.
+	endedAt map[string]time.Time
+}
+
+// NewTrigger creates a trigger reading Dogus and DebugModes with the given reader and starting debug modes with the
+// clients of their namespace.
+func NewTrigger(policy Policy, reader objectReader, clients func(namespace string) (Clients, error), recorder eventRecorder) *Trigger {
+	return &Trigger{
+		policy:    policy,
+		reader:    reader,
+		clients:   clients,
+		recorder:  recorder,
+		now:       time.Now,
+		dogus:     map[types.NamespacedName]*doguState{},
+		triggered: map[string][]time.Time{},
+		endedAt:   map[string]time.Time{},
+	}
+}
+
+func (t *Trigger) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logging.FromContext(ctx).WithValues("dogu", req.NamespacedName)
+	dogu := &v2.Dogu{}
+	err := t.reader.Get(ctx, req.NamespacedName, dogu)
+	if apierrors.IsNotFound(err) {
+		delete(t.dogus, req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get dogu %s: %w", req.NamespacedName, err)
+	}
+
+	state, found := t.dogus[req.NamespacedName]
+	if !found {
+		state = &doguState{}
+		t.dogus[req.NamespacedName] = state
+	}
+	now := t.now()
+	reason, recheck := state.observe(dogu, now, t.policy)
+	if state.pending != "" {
+		// a debug mode has already been triggered, but could not be started yet
+		return t.start(ctx, dogu, state, logger)
+	}
+	if reason == "" {
+		return ctrl.Result{RequeueAfter: recheck}, nil
+	}
+
+	suppressed, err := t.suppressed(ctx, req.Namespace, now)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if suppressed {
+		logger.Info("Ignore degradation during or shortly after a debug mode", "reason", reason)
+		return ctrl.Result{}, nil
+	}
+	if !t.allowed(req.Namespace, now) {
+		logger.Info("Ignore degradation, the maximum of debug modes has already been triggered", "maxTriggers", t.policy.MaxTriggers,
+			"window", t.policy.RateLimitWindow.Duration, "reason", reason)
+		return ctrl.Result{}, nil
+	}
+
+	state.pending = reason
+	return t.start(ctx, dogu, state, logger)
+}
+
+// suppressed returns true if a debug mode is active in the namespace or ended less than the cooldown ago.
+func (t *Trigger) suppressed(ctx context.Context, namespace string, now time.Time) (bool, error) {
+	cr := &k8sCRLib.DebugMode{}
+	err := t.reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: status.DebugModeName}, cr)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get debug mode in namespace %s: %w", namespace, err)
+	}
+	if err == nil {
+		if !isCompleted(cr) {
+			return true, nil
+		}
+		t.recordEnd(namespace, endOf(cr))
+	}
+	return now.Before(t.endedAt[namespace].Add(t.policy.Cooldown.Duration)), nil
+}
+
+// allowed returns true if the rate limit permits another debug mode in the namespace.
+func (t *Trigger) allowed(namespace string, now time.Time) bool {
+	t.triggered[namespace] = since(t.triggered[namespace], now.Add(-t.policy.RateLimitWindow.Duration))
+	return len(t.triggered[namespace]) < t.policy.MaxTriggers
+}
+
+func (t *Trigger) recordEnd(namespace string, end time.Time) {
+	if end.After(t.endedAt[namespace]) {
+		t.endedAt[namespace] = end
+	}
+}
+
+// start creates the DebugMode of the pending degradation. A completed DebugMode is deleted first, a DebugMode started
+// in the meantime is never changed.
+func (t *Trigger) start(ctx context.Context, dogu *v2.Dogu, state *doguState, logger logging.Logger) (ctrl.Result, error) {
+	clients, err := t.clients(dogu.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	existing, err := clients.DebugModes.Get(ctx, status.DebugModeName, metav1.GetOptions{})
+	switch {
+	case err == nil && !isCompleted(existing):
+		logger.Info("Skip automatic debug mode, a debug mode has been started in the meantime", "reason", state.pending)
+		state.pending = ""
+		return ctrl.Result{}, nil
+	case err == nil:
+		if existing.DeletionTimestamp == nil {
+			// the precondition prevents deleting a DebugMode that was recreated in the meantime
+			uid := existing.UID
+			err = clients.DebugModes.Delete(ctx, existing.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("failed to delete completed debug mode: %w", err)
+			}
+		}
+		return ctrl.Result{RequeueAfter: deletionPollInterval}, nil
+	case !apierrors.IsNotFound(err):
+		return ctrl.Result{}, fmt.Errorf("failed to get debug mode: %w", err)
+	}
+
+	now := t.now()
+	triggeredBy := fmt.Sprintf("%s: %s", dogu.Name, state.pending)
+	targets := t.targets(ctx, dogu.Name, clients.Descriptors, logger)
+	cr := &k8sCRLib.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      status.DebugModeName,
+			Namespace: dogu.Namespace,
+			Annotations: map[string]string{
+				controller.DogusAnnotation:       strings.Join(targets, ","),
+				controller.TriggeredByAnnotation: triggeredBy,
+			},
+		},
+		Spec: k8sCRLib.DebugModeSpec{
+			DeactivateTimestamp: metav1.NewTime(now.Add(t.policy.Duration.Duration)),
+			TargetLogLevel:      t.policy.TargetLogLevel,
+		},
+	}
+	created, err := clients.DebugModes.Create(ctx, cr, metav1.CreateOptions{FieldManager: fieldManager})
+	if apierrors.IsAlreadyExists(err) {
+		// the deleted DebugMode is still terminating or a new one has been created, which is checked on the next try
+		return ctrl.Result{RequeueAfter: deletionPollInterval}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create debug mode: %w", err)
+	}
+
+	logger.Info("Started automatic debug mode", "deactivateTimestamp", created.Spec.DeactivateTimestamp, "dogus", targets,
+		"triggeredBy", triggeredBy)
+	t.recorder.Eventf(dogu, created, corev1.EventTypeNormal, triggerReason, triggerAction,
+		"Started debug mode with log level %s until %s for dogus %s: %s", created.Spec.TargetLogLevel,
+		created.Spec.DeactivateTimestamp.Format(time.RFC3339), strings.Join(targets, ","), state.pending)
+	state.pending = ""
+	t.triggered[dogu.Namespace] = append(t.triggered[dogu.Namespace], now)
+	t.recordEnd(dogu.Namespace, created.Spec.DeactivateTimestamp.Time)
+	return ctrl.Result{}, nil
+}
+
+// targets returns the dogu and its mandatory dogu dependencies. Without its descriptor only the dogu is returned.
+func (t *Trigger) targets(ctx context.Context, name string, descriptors doguDescriptorGetter, logger logging.Logger) []string {
+	dogus := []string{name}
+	descriptor, err := descriptors.GetCurrent(ctx, name)
+	if err != nil {
+		logger.Error("ERROR: failed to get dependencies, start debug mode for the dogu only", "error", err)
+		return dogus
+	}
+	for _, dependency := range descriptor.GetDependenciesOfType(core.DependencyTypeDogu) {
+		dogus = append(dogus, dependency.Name)
+	}
+	return controller.ParseDoguSelection(strings.Join(dogus, ","))
+}
+
+// SetupWithManager sets up the controller watching the selected Dogus with the Manager.
+func (t *Trigger) SetupWithManager(mgr ctrl.Manager) error {
+	controllerOptions := mgr.GetControllerOptions()
+	options := ctrlcontroller.TypedOptions[reconcile.Request]{
+		SkipNameValidation: controllerOptions.SkipNameValidation,
+		RecoverPanic:       controllerOptions.RecoverPanic,
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("dogu-health-trigger").
+		WithEventFilter(predicate.NewPredicateFuncs(func(obj client.Object) bool { return t.policy.Selects(obj.GetName()) })).
+		WithOptions(options).
+		For(&v2.Dogu{}).
+		Complete(t)
+}
+
+// doguState is the observed health of a single dogu.
+type doguState struct {
+	health health
+	// unhealthySince is the time the dogu became unhealthy after it was healthy. It is zero otherwise.
+	unhealthySince time.Time
+	// reported is true if the current unhealthiness has already been handled.
+	reported  bool
+	startedAt time.Time
+	// restarts contains the start times of the restarts within the restart window.
+	restarts []time.Time
+	// pending is the reason of a debug mode that has been triggered but not started yet.
+	pending string
+}
+
+type health int
+
+const (
+	healthUnknown health = iota
+	healthy
+	unhealthy
+)
+
+// observe records the health and the start time of the dogu and returns the reason for a debug mode, if any.
+// Otherwise, it returns the time after which the dogu must be checked again to notice a lasting unhealthiness.
+func (s *doguState) observe(dogu *v2.Dogu, now time.Time, policy Policy) (string, time.Duration) {
+	startedAt := dogu.Status.StartedAt.Time
+	restarted := !s.startedAt.IsZero() && !startedAt.IsZero() && !startedAt.Equal(s.startedAt)
+	if !startedAt.IsZero() {
+		s.startedAt = startedAt
+	}
+	if dogu.Status.Status != v2.DoguStatusInstalled || dogu.Spec.Stopped {
+		// installations, upgrades and stopped dogus are no degradation, so the observation starts over afterward
+		*s = doguState{startedAt: s.startedAt, pending: s.pending}
+		return "", 0
+	}
+
+	switch healthOf(dogu) {
+	case healthy:
+		s.health, s.unhealthySince, s.reported = healthy, time.Time{}, false
+	case unhealthy:
+		if s.health == healthy {
+			s.unhealthySince = now
+		}
+		s.health = unhealthy
+	default:
+		// the health is kept until the dogu reports it again
+	}
+
+	if restarted && policy.Restarts > 0 {
+		s.restarts = append(since(s.restarts, now.Add(-policy.RestartWindow.Duration)), startedAt)
+		if len(s.restarts) >= policy.Restarts {
+			// the debug mode also covers the current unhealthiness
+			s.restarts, s.reported = nil, s.health == unhealthy
+			return fmt.Sprintf("restarted %d times within %s", policy.Restarts, policy.RestartWindow.Duration), 0
+		}
+	}
+	if s.health != unhealthy || s.unhealthySince.IsZero() || s.reported {
+		return "", 0
+	}
+	if remaining := s.unhealthySince.Add(policy.UnhealthyFor.Duration).Sub(now); remaining > 0 {
+		return "", remaining
+	}
+	s.reported = true
+	return fmt.Sprintf("unhealthy for %s", now.Sub(s.unhealthySince).Round(time.Second)), 0
+}
+
+// healthOf returns the health of the healthy condition or of the deprecated health status if the condition is missing.
+func healthOf(dogu *v2.Dogu) health {
+	if condition := meta.FindStatusCondition(dogu.Status.Conditions, v2.ConditionHealthy); condition != nil {
+		switch condition.Status {
+		case metav1.ConditionTrue:
+			return healthy
+		case metav1.ConditionFalse:
+			return unhealthy
+		default:
+			return healthUnknown
+		}
+	}
+	switch dogu.Status.Health {
+	case v2.AvailableHealthStatus:
+		return healthy
+	case v2.UnavailableHealthStatus:
+		return unhealthy
+	default:
+		return healthUnknown
+	}
+}
+
+func isCompleted(cr *k8sCRLib.DebugMode) bool {
+	if cr.Status.Phase == k8sCRLib.DebugModeStatusCompleted {
+		return true
+	}
+	return slices.ContainsFunc(cr.Status.Conditions, func(condition metav1.Condition) bool {
+		return condition.Reason == string(k8sCRLib.DebugModeStatusCompleted)
+	})
+}
+
+// endOf returns the start of the rollback of a completed DebugMode, which restarts the dogus again.
+func endOf(cr *k8sCRLib.DebugMode) time.Time {
+	if condition := meta.FindStatusCondition(cr.Status.Conditions, k8sCRLib.ConditionLogLevelSet); condition != nil {
+		return condition.LastTransitionTime.Time
+	}
+	return cr.Spec.DeactivateTimestamp.Time
+}
+
+// since returns the times after the given time.
+func since(times []time.Time, after time.Time) []time.Time {
+	return slices.DeleteFunc(times, func(t time.Time) bool { return !t.After(after) })
+}
@@ -0,0 +1,432 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudogu/cesapp-lib/core"
+	k8sCRLib "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	v2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testNamespace = "ecosystem"
+
+var (
+	testNow        = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	casKey         = types.NamespacedName{Namespace: testNamespace, Name: "cas"}
+	debugModeKey   = types.NamespacedName{Namespace: testNamespace, Name: "debug-mode"}
+	casRequest     = ctrl.Request{NamespacedName: casKey}
+	errTestFailure = errors.New("test failure")
+)
+
+func newDogu(health metav1.ConditionStatus, startedAt time.Time) *v2.Dogu {
+	return &v2.Dogu{
+		ObjectMeta: metav1.ObjectMeta{Name: casKey.Name, Namespace: casKey.Namespace},
+		Status: v2.DoguStatus{
+			Status:     v2.DoguStatusInstalled,
+			StartedAt:  metav1.NewTime(startedAt),
+			Conditions: []metav1.Condition{{Type: v2.ConditionHealthy, Status: health}},
+		},
+	}
+}
+
+func newCompletedDebugMode(rollbackAt time.Time) *k8sCRLib.DebugMode {
+	return &k8sCRLib.DebugMode{
+		ObjectMeta: metav1.ObjectMeta{Name: debugModeKey.Name, Namespace: testNamespace, UID: "completed-uid"},
+		Status: k8sCRLib.DebugModeStatus{
+			Phase: k8sCRLib.DebugModeStatusCompleted,
+			Conditions: []metav1.Condition{{
+				Type:               k8sCRLib.ConditionLogLevelSet,
+				Status:             metav1.ConditionFalse,
+				Reason:             string(k8sCRLib.DebugModeStatusCompleted),
+				LastTransitionTime: metav1.NewTime(rollbackAt),
+			}},
+		},
+	}
+}
+
+func notFound(name string) error {
+	return apierrors.NewNotFound(schema.GroupResource{Group: "k8s.cloudogu.com", Resource: "debugmodes"}, name)
+}
+
+// expectGet lets the reader return the given object or error for the key.
+func expectGet[T client.Object](reader *mockObjectReader, key types.NamespacedName, object T, err error) {
+	reader.EXPECT().Get(mock.Anything, key, mock.AnythingOfType(typeName(object))).
+		RunAndReturn(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			if err != nil {
+				return err
+			}
+			copyInto(object, obj)
+			return nil
+		})
+}
+
+func typeName(object client.Object) string {
+	switch object.(type) {
+	case *v2.Dogu:
+		return "*v2.Dogu"
+	default:
+		return "*v1.DebugMode"
+	}
+}
+
+func copyInto(from client.Object, to client.Object) {
+	switch source := from.(type) {
+	case *v2.Dogu:
+		*to.(*v2.Dogu) = *source.DeepCopy()
+	case *k8sCRLib.DebugMode:
+		*to.(*k8sCRLib.DebugMode) = *source.DeepCopy()
+	}
+}
+
+type testTrigger struct {
+	*Trigger
+	reader      *mockObjectReader
+	debugModes  *mockDebugModeInterface
+	descriptors *mockDoguDescriptorGetter
+	recorder    *mockEventRecorder
+}
+
+func newTestTrigger(t *testing.T) testTrigger {
+	policy := DefaultPolicy()
+	policy.Dogus = []string{"cas"}
+	policy.UnhealthyFor = metav1.Duration{}
+
+	reader := newMockObjectReader(t)
+	debugModes := newMockDebugModeInterface(t)
+	descriptors := newMockDoguDescriptorGetter(t)
+	recorder := newMockEventRecorder(t)
+	trigger := NewTrigger(policy, reader, func(namespace string) (Clients, error) {
+		if namespace != testNamespace {
+			return Clients{}, controller.ErrNamespaceNotWatched
+		}
+		return Clients{DebugModes: debugModes, Descriptors: descriptors}, nil
+	}, recorder)
+	trigger.now = func() time.Time { return testNow }
+	return testTrigger{Trigger: trigger, reader: reader, debugModes: debugModes, descriptors: descriptors, recorder: recorder}
+}
+
+// observeHealthy lets the trigger observe the healthy dogu, so its next unhealthiness is a degradation.
+func (tt testTrigger) observeHealthy(t *testing.T) {
+	expectGet(tt.reader, casKey, newDogu(metav1.ConditionTrue, testNow.Add(-time.Hour)), nil)
+	_, err := tt.Reconcile(t.Context(), casRequest)
+	require.NoError(t, err)
+	tt.reader.ExpectedCalls = nil
+}
+
+func TestTrigger_Reconcile(t *testing.T) {
+	t.Run("should start a debug mode for the unhealthy dogu and its dependencies", func(t *testing.T) {
+		// given
+		tt := newTestTrigger(t)
+		tt.observeHealthy(t)
+		expectGet(tt.reader, casKey, newDogu(metav1.ConditionFalse, testNow.Add(-time.Hour)), nil)
+		expectGet(tt.reader, debugModeKey, &k8sCRLib.DebugMode{}, notFound(debugModeKey.Name))
+		tt.debugModes.EXPECT().Get(mock.Anything, debugModeKey.Name, metav1.GetOptions{}).Return(nil, notFound(debugModeKey.Name))
+		tt.descriptors.EXPECT().GetCurrent(mock.Anything, "cas").Return(&core.Dogu{
+			Name: "official/cas",
+			Dependencies: []core.Dependency{
+				{Type: core.DependencyTypeDogu, Name: "ldap"},
+				{Type: core.DependencyTypeDogu, Name: "postfix"},
+				{Type: core.DependencyTypeClient, Name: "cesapp"},
+			},
+		}, nil)
+		var created *k8sCRLib.DebugMode
+		tt.debugModes.EXPECT().Create(mock.Anything, mock.Anything, metav1.CreateOptions{FieldManager: fieldManager}).
+			RunAndReturn(func(_ context.Context, cr *k8sCRLib.DebugMode, _ metav1.CreateOptions) (*k8sCRLib.DebugMode, error) {
+				created = cr
+				return cr, nil
+			})
+		tt.recorder.EXPECT().Eventf(mock.Anything, mock.Anything, corev1.EventTypeNormal, triggerReason, triggerAction,
+			mock.Anything, "DEBUG", mock.Anything, "cas,ldap,postfix", "unhealthy for 0s").Return()
+
+		// when
+		result, err := tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		require.NotNil(t, created)
+		assert.Equal(t, "debug-mode", created.Name)
+		assert.Equal(t, testNamespace, created.Namespace)
+		assert.Equal(t, "cas,ldap,postfix", created.Annotations[controller.DogusAnnotation])
+		assert.Equal(t, "cas: unhealthy for 0s", created.Annotations[controller.TriggeredByAnnotation])
+		assert.Equal(t, "DEBUG", created.Spec.TargetLogLevel)
+		assert.Equal(t, testNow.Add(30*time.Minute), created.Spec.DeactivateTimestamp.Time)
+	})
+	t.Run("should start a debug mode for the dogu only if its descriptor is missing", func(t *testing.T) {
+		// given
+		tt := newTestTrigger(t)
+		tt.observeHealthy(t)
+		expectGet(tt.reader, casKey, newDogu(metav1.ConditionFalse, testNow.Add(-time.Hour)), nil)
+		expectGet(tt.reader, debugModeKey, &k8sCRLib.DebugMode{}, notFound(debugModeKey.Name))
+		tt.debugModes.EXPECT().Get(mock.Anything, debugModeKey.Name, metav1.GetOptions{}).Return(nil, notFound(debugModeKey.Name))
+		tt.descriptors.EXPECT().GetCurrent(mock.Anything, "cas").Return(nil, errTestFailure)
+		tt.debugModes.EXPECT().Create(mock.Anything, mock.MatchedBy(func(cr *k8sCRLib.DebugMode) bool {
+			return cr.Annotations[controller.DogusAnnotation] == "cas"
+		}), mock.Anything).RunAndReturn(func(_ context.Context, cr *k8sCRLib.DebugMode, _ metav1.CreateOptions) (*k8sCRLib.DebugMode, error) {
+			return cr, nil
+		})
+		tt.recorder.EXPECT().Eventf(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+		// when
+		_, err := tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should ignore an unhealthy dogu while a debug mode is active", func(t *testing.T) {
+		// given
+		tt := newTestTrigger(t)
+		tt.observeHealthy(t)
+		expectGet(tt.reader, casKey, newDogu(metav1.ConditionFalse, testNow.Add(-time.Hour)), nil)
+		expectGet(tt.reader, debugModeKey, &k8sCRLib.DebugMode{Status: k8sCRLib.DebugModeStatus{Phase: k8sCRLib.DebugModeStatusSet}}, nil)
+
+		// when
+		result, err := tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+	t.Run("should ignore an unhealthy dogu within the cooldown after a debug mode", func(t *testing.T) {
+		// given
+		tt := newTestTrigger(t)
+		tt.observeHealthy(t)
+		expectGet(tt.reader, casKey, newDogu(metav1.ConditionFalse, testNow.Add(-time.Hour)), nil)
+		expectGet(tt.reader, debugModeKey, newCompletedDebugMode(testNow.Add(-30*time.Minute)), nil)
+
+		// when
+		_, err := tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, testNow.Add(-30*time.Minute), tt.endedAt[testNamespace])
+	})
+	t.Run("should ignore an unhealthy dogu if the rate limit is exceeded", func(t *testing.T) {
+		// given
+		tt := newTestTrigger(t)
+		tt.triggered[testNamespace] = []time.Time{testNow.Add(-25 * time.Hour), testNow.Add(-3 * time.Hour), testNow.Add(-2 * time.Hour), testNow.Add(-time.Hour)}
+		tt.observeHealthy(t)
+		expectGet(tt.reader, casKey, newDogu(metav1.ConditionFalse, testNow.Add(-time.Hour)), nil)
+		expectGet(tt.reader, debugModeKey, &k8sCRLib.DebugMode{}, notFound(debugModeKey.Name))
+
+		// when
+		_, err := tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, tt.triggered[testNamespace], 3, "triggers outside the window are forgotten")
+	})
+	t.Run("should delete a completed debug mode and start the debug mode on the next try", func(t *testing.T) {
+		// given
+		tt := newTestTrigger(t)
+		tt.observeHealthy(t)
+		completed := newCompletedDebugMode(testNow.Add(-2 * time.Hour))
+		expectGet(tt.reader, casKey, newDogu(metav1.ConditionFalse, testNow.Add(-time.Hour)), nil)
+		expectGet(tt.reader, debugModeKey, completed, nil)
+		tt.debugModes.EXPECT().Get(mock.Anything, debugModeKey.Name, metav1.GetOptions{}).Return(completed, nil).Once()
+		uid := completed.UID
+		tt.debugModes.EXPECT().Delete(mock.Anything, debugModeKey.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}).Return(nil)
+
+		// when
+		result, err := tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: deletionPollInterval}, result)
+
+		// given the completed debug mode is gone and the dogu is healthy again
+		tt.reader.ExpectedCalls = nil
+		expectGet(tt.reader, casKey, newDogu(metav1.ConditionTrue, testNow.Add(-time.Hour)), nil)
+		tt.debugModes.EXPECT().Get(mock.Anything, debugModeKey.Name, metav1.GetOptions{}).Return(nil, notFound(debugModeKey.Name)).Once()
+		tt.descriptors.EXPECT().GetCurrent(mock.Anything, "cas").Return(&core.Dogu{Name: "official/cas"}, nil)
+		tt.debugModes.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, cr *k8sCRLib.DebugMode, _ metav1.CreateOptions) (*k8sCRLib.DebugMode, error) {
+				return cr, nil
+			})
+		tt.recorder.EXPECT().Eventf(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+		// when
+		result, err = tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.Equal(t, []time.Time{testNow}, tt.triggered[testNamespace])
+		assert.Equal(t, testNow.Add(30*time.Minute), tt.endedAt[testNamespace])
+	})
+	t.Run("should not change a debug mode started in the meantime", func(t *testing.T) {
+		// given
+		tt := newTestTrigger(t)
+		tt.observeHealthy(t)
+		expectGet(tt.reader, casKey, newDogu(metav1.ConditionFalse, testNow.Add(-time.Hour)), nil)
+		expectGet(tt.reader, debugModeKey, &k8sCRLib.DebugMode{}, notFound(debugModeKey.Name))
+		tt.debugModes.EXPECT().Get(mock.Anything, debugModeKey.Name, metav1.GetOptions{}).Return(&k8sCRLib.DebugMode{}, nil)
+
+		// when
+		result, err := tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.Empty(t, tt.dogus[casKey].pending)
+	})
+	t.Run("should keep the pending debug mode if it cannot be created", func(t *testing.T) {
+		// given
+		tt := newTestTrigger(t)
+		tt.observeHealthy(t)
+		expectGet(tt.reader, casKey, newDogu(metav1.ConditionFalse, testNow.Add(-time.Hour)), nil)
+		expectGet(tt.reader, debugModeKey, &k8sCRLib.DebugMode{}, notFound(debugModeKey.Name))
+		tt.debugModes.EXPECT().Get(mock.Anything, debugModeKey.Name, metav1.GetOptions{}).Return(nil, notFound(debugModeKey.Name))
+		tt.descriptors.EXPECT().GetCurrent(mock.Anything, "cas").Return(&core.Dogu{Name: "official/cas"}, nil)
+		tt.debugModes.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, errTestFailure)
+
+		// when
+		_, err := tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errTestFailure)
+		assert.ErrorContains(t, err, "failed to create debug mode")
+		assert.Equal(t, "unhealthy for 0s", tt.dogus[casKey].pending)
+		assert.Empty(t, tt.triggered[testNamespace])
+	})
+	t.Run("should forget a deleted dogu", func(t *testing.T) {
+		// given
+		tt := newTestTrigger(t)
+		tt.observeHealthy(t)
+		expectGet(tt.reader, casKey, &v2.Dogu{}, notFound(casKey.Name))
+
+		// when
+		_, err := tt.Reconcile(t.Context(), casRequest)
+
+		// then
+		require.NoError(t, err)
+		assert.NotContains(t, tt.dogus, casKey)
+	})
+	t.Run("should fail if the dogu cannot be read", func(t *testing.T) {
+		tt := newTestTrigger(t)
+		expectGet(tt.reader, casKey, &v2.Dogu{}, errTestFailure)
+
+		_, err := tt.Reconcile(t.Context(), casRequest)
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get dogu ecosystem/cas")
+	})
+}
+
+func Test_doguState_observe(t *testing.T) {
+	policy := DefaultPolicy()
+	startedAt := testNow.Add(-time.Hour)
+
+	t.Run("should ignore a dogu that is unhealthy on the first observation", func(t *testing.T) {
+		state := &doguState{}
+
+		reason, recheck := state.observe(newDogu(metav1.ConditionFalse, startedAt), testNow, policy)
+
+		assert.Empty(t, reason)
+		assert.Zero(t, recheck)
+	})
+	t.Run("should report a dogu that stays unhealthy once", func(t *testing.T) {
+		// given
+		state := &doguState{}
+		state.observe(newDogu(metav1.ConditionTrue, startedAt), testNow, policy)
+
+		// when
+		reason, recheck := state.observe(newDogu(metav1.ConditionFalse, startedAt), testNow, policy)
+
+		// then
+		assert.Empty(t, reason)
+		assert.Equal(t, 2*time.Minute, recheck)
+
+		// when
+		reason, recheck = state.observe(newDogu(metav1.ConditionFalse, startedAt), testNow.Add(3*time.Minute), policy)
+
+		// then
+		assert.Equal(t, "unhealthy for 3m0s", reason)
+		assert.Zero(t, recheck)
+
+		// when
+		reason, _ = state.observe(newDogu(metav1.ConditionFalse, startedAt), testNow.Add(4*time.Minute), policy)
+
+		// then
+		assert.Empty(t, reason, "the same unhealthiness is reported only once")
+	})
+	t.Run("should not report a dogu that is healthy again within the unhealthy time", func(t *testing.T) {
+		state := &doguState{}
+		state.observe(newDogu(metav1.ConditionTrue, startedAt), testNow, policy)
+		state.observe(newDogu(metav1.ConditionFalse, startedAt), testNow, policy)
+		state.observe(newDogu(metav1.ConditionTrue, startedAt), testNow.Add(time.Minute), policy)
+
+		reason, _ := state.observe(newDogu(metav1.ConditionTrue, startedAt), testNow.Add(3*time.Minute), policy)
+
+		assert.Empty(t, reason)
+	})
+	t.Run("should report restarts within the restart window", func(t *testing.T) {
+		// given
+		state := &doguState{}
+		state.observe(newDogu(metav1.ConditionTrue, startedAt), testNow, policy)
+		state.observe(newDogu(metav1.ConditionTrue, testNow.Add(-15*time.Minute)), testNow, policy)
+		state.observe(newDogu(metav1.ConditionTrue, testNow.Add(-5*time.Minute)), testNow, policy)
+		state.observe(newDogu(metav1.ConditionTrue, testNow.Add(-2*time.Minute)), testNow, policy)
+
+		// when
+		reason, _ := state.observe(newDogu(metav1.ConditionTrue, testNow), testNow, policy)
+
+		// then
+		assert.Equal(t, "restarted 3 times within 10m0s", reason)
+		assert.Empty(t, state.restarts)
+	})
+	t.Run("should ignore restarts if disabled", func(t *testing.T) {
+		withoutRestarts := policy
+		withoutRestarts.Restarts = 0
+		state := &doguState{}
+		state.observe(newDogu(metav1.ConditionTrue, startedAt), testNow, withoutRestarts)
+
+		for i := range 5 {
+			reason, _ := state.observe(newDogu(metav1.ConditionTrue, testNow.Add(time.Duration(i)*time.Second)), testNow, withoutRestarts)
+			assert.Empty(t, reason)
+		}
+	})
+	t.Run("should start over after an upgrade", func(t *testing.T) {
+		// given
+		state := &doguState{}
+		state.observe(newDogu(metav1.ConditionTrue, startedAt), testNow, policy)
+		upgrading := newDogu(metav1.ConditionFalse, testNow)
+		upgrading.Status.Status = v2.DoguStatusUpgrading
+		state.observe(upgrading, testNow, policy)
+
+		// when
+		reason, recheck := state.observe(newDogu(metav1.ConditionFalse, testNow), testNow.Add(time.Hour), policy)
+
+		// then
+		assert.Empty(t, reason)
+		assert.Zero(t, recheck)
+		assert.Equal(t, testNow, state.startedAt)
+		assert.Empty(t, state.restarts)
+	})
+	t.Run("should use the deprecated health status without healthy condition", func(t *testing.T) {
+		dogu := &v2.Dogu{Status: v2.DoguStatus{Health: v2.UnavailableHealthStatus}}
+
+		assert.Equal(t, unhealthy, healthOf(dogu))
+		dogu.Status.Health = v2.AvailableHealthStatus
+		assert.Equal(t, healthy, healthOf(dogu))
+		dogu.Status.Health = v2.PendingHealthStatus
+		assert.Equal(t, healthUnknown, healthOf(dogu))
+	})
+}
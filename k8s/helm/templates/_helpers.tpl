@@ -23,4 +23,64 @@ k8s.cloudogu.com/component.version: {{ .Chart.AppVersion | quote }}
 {{- define "k8s-debug-mode-operator.selectorLabels" -}}
 app.kubernetes.io/name: {{ include "k8s-debug-mode-operator.name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
-{{- end }}
\ No newline at end of file
+{{- end }}
+
+{{/* Permissions needed in every namespace whose debug modes are managed */}}
+{{- define "k8s-debug-mode-operator.ecosystemRules" -}}
+- apiGroups:
+    - ""
+  resources:
+    - configmaps
+  verbs:
+    - get
+    - list
+    - update
+    - create
+    - delete
+- apiGroups:
+    - ""
+  resources:
+    - secrets
+  verbs:
+    - get
+    - update
+    - create
+    - delete
+- apiGroups:
+    - apps
+  resources:
+    - deployments
+  verbs:
+    - get
+    - list
+    - update
+- apiGroups:
+    - k8s.cloudogu.com
+  resources:
+    - debugmodes
+    - debugmodes/status
+    - debugmodes/finalizers
+  verbs:
+    - '*'
+- apiGroups:
+    - k8s.cloudogu.com
+  resources:
+    - dogus
+  verbs:
+    - list
+    - watch
+- apiGroups:
+    - events.k8s.io
+  resources:
+    - events
+  verbs:
+    - create
+    - patch
+# the status API serves the errors reported as events
+- apiGroups:
+    - ""
+  resources:
+    - events
+  verbs:
+    - list
+{{- end }}
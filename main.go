@@ -2,20 +2,29 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/audit"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/config"
 	"github.com/cloudogu/k8s-debug-mode-operator/internal/controller"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/health"
 	"github.com/cloudogu/k8s-debug-mode-operator/internal/logging"
 	"github.com/cloudogu/k8s-debug-mode-operator/internal/loglevel"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/notify"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/status"
+	"github.com/cloudogu/k8s-debug-mode-operator/internal/trigger"
 	"github.com/cloudogu/k8s-registry-lib/dogu"
 	"github.com/cloudogu/k8s-registry-lib/repository"
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -26,7 +35,10 @@ import (
 
 	k8scloudogucomv1 "github.com/cloudogu/k8s-debug-mode-cr-lib/api/v1"
 	k8scloudogucomclient "github.com/cloudogu/k8s-debug-mode-cr-lib/pkg/client"
+	doguv2 "github.com/cloudogu/k8s-dogu-lib/v2/api/v2"
 	doguClient "github.com/cloudogu/k8s-dogu-lib/v2/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -37,8 +49,6 @@ import (
 var (
 	scheme      = runtime.NewScheme()
 	operatorLog = ctrl.Log.WithName("debug-mode-operator")
-	metricsAddr string
-	probeAddr   string
 )
 
 type controllerManager interface {
@@ -49,24 +59,25 @@ type ecosystemClientSet struct {
 	kubernetes.Interface
 	k8scloudogucomclient.DebugModeEcosystemInterface
 	doguClient.EcoSystemV2Client
-	corev1.ConfigMapInterface
 }
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(k8scloudogucomv1.AddToScheme(scheme))
+	utilruntime.Must(doguv2.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
 
 }
 
 func main() {
-	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	operatorLevel := logging.ConfigureLogger()
 
-	flag.Parse()
-
-	err := startOperator()
+	// the flags are registered in the default flag set, which also contains --kubeconfig
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:], os.LookupEnv)
+	if err == nil {
+		err = startOperator(cfg, operatorLevel)
+	}
 
 	if err != nil {
 		operatorLog.Error(err, "failed to start operator")
@@ -74,10 +85,8 @@ func main() {
 	}
 }
 
-func startOperator() error {
-	logging.ConfigureLogger()
-
-	options := getK8sManagerOptions()
+func startOperator(cfg config.Config, operatorLevel *logging.OperatorLevel) error {
+	options := getK8sManagerOptions(cfg)
 	k8sManager, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		return fmt.Errorf("failed to start manager: %w", err)
@@ -85,7 +94,7 @@ func startOperator() error {
 
 	ctx := ctrl.SetupSignalHandler()
 
-	err = configureManager(ctx, k8sManager)
+	err = configureManager(ctx, k8sManager, cfg, operatorLevel)
 	if err != nil {
 		return fmt.Errorf("unable to configure manager: %w", err)
 	}
@@ -115,15 +124,18 @@ func createDoguClientSet(k8sManager manager.Manager) (*doguClient.EcoSystemV2Cli
 	return doguClientSet, nil
 }
 
-func configureManager(ctx context.Context, k8sManager manager.Manager) error {
+func configureManager(ctx context.Context, k8sManager manager.Manager, cfg config.Config, operatorLevel *logging.OperatorLevel) error {
 	logger := logging.FromContext(ctx)
-	namespace, found := os.LookupEnv("NAMESPACE")
-	if !found {
-		namespace = "ecosystem"
+	if cfg.ClusterWide() {
+		logger.Info("watch debug modes in all namespaces")
+	} else {
+		logger.Info("watch debug modes in namespaces", "namespaces", cfg.WatchedNamespaces())
 	}
-	logger.Debug(fmt.Sprintf("use namespace %s", namespace))
 
 	k8sClientSet, err := kubernetes.NewForConfig(k8sManager.GetConfig())
+	if err != nil {
+		return fmt.Errorf("ERROR: failed to create kubernetes client set: %w", err)
+	}
 
 	debugModeClientSet, err := createDebugModeClientSet(k8sManager)
 	if err != nil {
@@ -135,16 +147,139 @@ func configureManager(ctx context.Context, k8sManager manager.Manager) error {
 		return fmt.Errorf("ERROR: failed to create dogu client set: %w", err)
 	}
 
-	configMapClient := k8sClientSet.CoreV1().ConfigMaps(namespace)
-
 	ecoClientSet := ecosystemClientSet{
 		k8sClientSet,
 		debugModeClientSet,
 		*doguClientSet,
-		configMapClient,
 	}
 
-	v1DebugMode := ecoClientSet.DebugModeV1()
+	var notifier *notify.Notifier
+	if len(cfg.Webhooks) > 0 {
+		notifier, err = notify.NewNotifier(cfg.Webhooks)
+		if err != nil {
+			return fmt.Errorf("ERROR: failed to create notifier: %w", err)
+		}
+		// the notifications are only delivered by the leader, which is the only replica reconciling debug modes
+		err = k8sManager.Add(notifier)
+		if err != nil {
+			return fmt.Errorf("unable to add notifier: %w", err)
+		}
+		logger.Info("send notifications to webhooks", "webhooks", len(cfg.Webhooks))
+	}
+
+	watchdog := health.NewWatchdog(cfg.ReconcileDeadline.Duration)
+	eventRecorder := k8sManager.GetEventRecorder("k8s-debug-mode-operator")
+	ecosystems := controller.NewNamespaceCache(cfg.WatchedNamespaces(), func(namespace string) (*ecosystem, error) {
+		return newEcosystem(namespace, cfg, ecoClientSet, operatorLevel, watchdog, notifier, eventRecorder)
+	})
+	// the ecosystems of explicitly watched namespaces are created right away, so the readiness probe checks all of them
+	for _, namespace := range cfg.WatchedNamespaces() {
+		if _, err = ecosystems.Get(namespace); err != nil {
+			return err
+		}
+	}
+
+	debugModeReconciler := controller.NewNamespacedReconciler(func(namespace string) (*controller.DebugModeReconciler, error) {
+		eco, err := ecosystems.Get(namespace)
+		if err != nil {
+			return nil, err
+		}
+		return eco.reconciler, nil
+	})
+
+	// the status API may name any namespace, so in all namespaces only existing ones get an ecosystem
+	statusEcosystem := func(ctx context.Context, namespace string) (*ecosystem, error) {
+		if !cfg.ClusterWide() {
+			return ecosystems.Get(namespace)
+		}
+		return ecosystems.GetExisting(ctx, namespace, namespaceExists(k8sClientSet.CoreV1().Namespaces()))
+	}
+	statusHandlers := map[string]*status.NamespaceHandler{
+		"/status": status.NewNamespaceHandler(cfg.Namespace, func(ctx context.Context, namespace string) (http.Handler, error) {
+			eco, err := statusEcosystem(ctx, namespace)
+			if err != nil {
+				return nil, err
+			}
+			return eco.status, nil
+		}),
+	}
+	if cfg.AuditRetention > 0 {
+		statusHandlers["/status/history"] = status.NewNamespaceHandler(cfg.Namespace, func(ctx context.Context, namespace string) (http.Handler, error) {
+			eco, err := statusEcosystem(ctx, namespace)
+			if err != nil {
+				return nil, err
+			}
+			return eco.history, nil
+		})
+	}
+
+	err = addStatusServer(k8sManager, cfg, statusHandlers, operatorLevel)
+	if err != nil {
+		return fmt.Errorf("unable to add status API: %w", err)
+	}
+
+	err = debugModeReconciler.SetupWithManager(k8sManager)
+	if err != nil {
+		return fmt.Errorf("unable to configure reconciler: %w", err)
+	}
+
+	// without a list of namespaces the state maps of all namespaces are recovered
+	stateMapNamespaces := cfg.WatchedNamespaces()
+	if cfg.ClusterWide() {
+		stateMapNamespaces = []string{metav1.NamespaceAll}
+	}
+	var stateMaps []corev1.ConfigMapInterface
+	for _, namespace := range stateMapNamespaces {
+		stateMaps = append(stateMaps, k8sClientSet.CoreV1().ConfigMaps(namespace))
+	}
+	startupRecovery := controller.NewNamespacedStartupRecovery(debugModeReconciler, stateMaps, eventRecorder)
+	err = k8sManager.Add(startupRecovery)
+	if err != nil {
+		return fmt.Errorf("unable to add startup recovery: %w", err)
+	}
+
+	if cfg.AutoDebugMode.Enabled() {
+		logger.Info("start debug modes automatically", "dogus", cfg.AutoDebugMode.Dogus)
+		autoDebugMode := trigger.NewTrigger(cfg.AutoDebugMode, k8sManager.GetClient(), func(namespace string) (trigger.Clients, error) {
+			eco, err := ecosystems.Get(namespace)
+			if err != nil {
+				return trigger.Clients{}, err
+			}
+			return eco.triggerClients, nil
+		}, eventRecorder)
+		err = autoDebugMode.SetupWithManager(k8sManager)
+		if err != nil {
+			return fmt.Errorf("unable to configure automatic debug modes: %w", err)
+		}
+	}
+
+	// +kubebuilder:scaffold:builder
+	err = addChecks(k8sManager, doguRegistries{ecosystems}, watchdog)
+	if err != nil {
+		return fmt.Errorf("failed to add checks to the manager: %w", err)
+	}
+
+	return nil
+}
+
+// ecosystem holds the reconciler and the clients of the Cloudogu EcoSystem in one namespace.
+type ecosystem struct {
+	namespace  string
+	reconciler *controller.DebugModeReconciler
+	doguGetter *controller.DoguGetter
+	status     http.Handler
+	// history is nil if the audit trail is disabled.
+	history        http.Handler
+	triggerClients trigger.Clients
+}
+
+// newEcosystem creates the reconciler and the clients bound to the given namespace. The notifier is nil if no
+// notifications are sent.
+func newEcosystem(namespace string, cfg config.Config, ecoClientSet ecosystemClientSet, operatorLevel *logging.OperatorLevel, watchdog *health.Watchdog, notifier *notify.Notifier, eventRecorder events.EventRecorder) (*ecosystem, error) {
+	configMapClient := ecoClientSet.CoreV1().ConfigMaps(namespace)
+	debugModeClient := ecoClientSet.DebugModeV1().DebugMode(namespace)
+	doguClient := ecoClientSet.Dogus(namespace)
+
 	doguConfig := repository.NewDoguConfigRepository(configMapClient)
 	doguDescriptorGetter := controller.NewDoguGetter(
 		dogu.NewDoguVersionRegistry(configMapClient),
@@ -152,48 +287,177 @@ func configureManager(ctx context.Context, k8sManager manager.Manager) error {
 	)
 
 	doguLogLevelGetter := loglevel.NewDoguLogLevelHandler(doguConfig, doguDescriptorGetter)
+	vocabularies, err := cfg.Vocabularies()
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level vocabularies: %w", err)
+	}
+	doguLogLevelGetter.SetVocabularies(vocabularies)
 
 	debugModeReconciler := controller.NewDebugModeReconciler(
-		v1DebugMode.DebugMode(namespace),
-		ecoClientSet.Dogus(namespace),
-		ecoClientSet.ConfigMapInterface,
+		debugModeClient,
+		doguClient,
+		configMapClient,
 		doguLogLevelGetter,
 	)
 
-	err = debugModeReconciler.SetupWithManager(k8sManager)
-	if err != nil {
-		return fmt.Errorf("unable to configure reconciler: %w", err)
+	debugModeReconciler.SetRequeueInterval(cfg.RequeueInterval.Duration)
+	debugModeReconciler.SetAddedDoguPolicy(cfg.AddedDoguPolicy)
+	debugModeReconciler.SetDoguConfigHandler(loglevel.NewDoguConfigHandler(doguConfig))
+	secretClient := ecoClientSet.CoreV1().Secrets(namespace)
+	debugModeReconciler.SetSensitiveDoguConfig(
+		loglevel.NewDoguConfigHandler(repository.NewSensitiveDoguConfigRepository(secretClient)),
+		secretClient,
+	)
+	debugModeReconciler.SetDoguConfigProfiles(cfg.DoguConfigProfiles)
+	debugModeReconciler.SetRaiseOnly(cfg.RaiseOnly)
+
+	deploymentClient := ecoClientSet.AppsV1().Deployments(namespace)
+	debugModeReconciler.SetComponents(
+		loglevel.NewComponentLogLevelHandler(deploymentClient),
+		deploymentClient,
+		cfg.ComponentSelector,
+	)
+
+	// the debug modes of every namespace raise the log level of the operator independently
+	debugModeReconciler.SetOperatorLogLevel(operatorLevel.Source(namespace))
+
+	// the errors are reported as events, so the status API of every replica serves the errors of the leader
+	debugModeReconciler.SetEventRecorder(eventRecorder)
+	statusReader := status.NewReader(debugModeClient, configMapClient, doguClient, doguLogLevelGetter, ecoClientSet.CoreV1().Events(namespace))
+
+	eco := &ecosystem{
+		namespace:  namespace,
+		reconciler: debugModeReconciler,
+		doguGetter: doguDescriptorGetter,
+		status:     status.NewHandler(statusReader),
+		triggerClients: trigger.Clients{
+			DebugModes:  debugModeClient,
+			Descriptors: doguDescriptorGetter,
+		},
 	}
 
-	// +kubebuilder:scaffold:builder
-	err = addChecks(k8sManager)
-	if err != nil {
-		return fmt.Errorf("failed to add checks to the manager: %w", err)
+	if cfg.AuditRetention > 0 {
+		auditTrail := audit.NewTrail(configMapClient, cfg.AuditRetention)
+		debugModeReconciler.SetAuditTrail(auditTrail)
+		eco.history = status.NewHistoryHandler(auditTrail)
+	}
+	if notifier != nil {
+		debugModeReconciler.SetNotifier(notifier)
 	}
 
-	return nil
+	debugModeReconciler.SetCompletedTTL(cfg.CompletedTTL.Duration)
+	debugModeReconciler.SetWatchdog(watchdog)
+
+	return eco, nil
 }
 
-func getK8sManagerOptions() manager.Options {
-	namespace, found := os.LookupEnv("NAMESPACE")
-	if !found {
-		namespace = "ecosystem"
+// doguRegistries checks the access to the dogu registries of all ecosystems created so far.
+type doguRegistries struct {
+	ecosystems *controller.NamespaceCache[*ecosystem]
+}
+
+func (d doguRegistries) CheckAccess(ctx context.Context) error {
+	var errs []error
+	for _, eco := range d.ecosystems.Created() {
+		if err := eco.doguGetter.CheckAccess(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: %w", eco.namespace, err))
+		}
 	}
-	options := ctrl.Options{
-		Scheme:  scheme,
-		Metrics: server.Options{BindAddress: metricsAddr},
+	return errors.Join(errs...)
+}
+
+func getK8sManagerOptions(cfg config.Config) manager.Options {
+	leaderElection := cfg.LeaderElection
+	return ctrl.Options{
+		Scheme: scheme,
+		Metrics: server.Options{
+			BindAddress: cfg.MetricsBindAddress,
+		},
 		Cache: cache.Options{ByObject: map[client.Object]cache.ByObject{
 			// Restrict namespace for components only as we want to reconcile Deployments,
 			// StatefulSets and DaemonSets across all namespaces.
-			&k8scloudogucomv1.DebugMode{}: {Namespaces: map[string]cache.Config{
-				namespace: {},
-			}},
+			&k8scloudogucomv1.DebugMode{}: {Namespaces: watchedNamespaces(cfg)},
+			&doguv2.Dogu{}:                {Namespaces: watchedNamespaces(cfg)},
 		}},
-		HealthProbeBindAddress: probeAddr,
+		HealthProbeBindAddress: cfg.HealthProbeBindAddress,
+		// only the leader reconciles and recovers state maps, the status API is served by all replicas
+		LeaderElection:          leaderElection.Enabled,
+		LeaderElectionID:        leaderElection.ID,
+		LeaderElectionNamespace: cfg.LeaderElectionNamespace(),
+		LeaseDuration:           &leaderElection.LeaseDuration.Duration,
+		RenewDeadline:           &leaderElection.RenewDeadline.Duration,
+		RetryPeriod:             &leaderElection.RetryPeriod.Duration,
+		// the operator exits right after the manager stops, so the next leader must not wait for the lease to expire
+		LeaderElectionReleaseOnCancel: true,
 	}
+}
 
-	return options
+// watchedNamespaces returns the cache configuration of the watched namespaces or nil to watch all namespaces.
+func watchedNamespaces(cfg config.Config) map[string]cache.Config {
+	if cfg.ClusterWide() {
+		return nil
+	}
+	namespaces := map[string]cache.Config{}
+	for _, namespace := range cfg.WatchedNamespaces() {
+		namespaces[namespace] = cache.Config{}
+	}
+	return namespaces
 }
+
+// addStatusServer serves the read-only status API and the runtime log level of the operator on their own address, so
+// they can be exposed independently of the metrics. With the authentication token-review, every request needs a token
+// allowed to use the path and to get the DebugModes of the requested namespace. Otherwise, the log level can only be
+// read.
+func addStatusServer(k8sManager manager.Manager, cfg config.Config, handlers map[string]*status.NamespaceHandler, operatorLevel *logging.OperatorLevel) error {
+	if cfg.StatusBindAddress == config.StatusBindAddressDisabled {
+		return nil
+	}
+
+	statusAPI := http.NewServeMux()
+	for path, handler := range handlers {
+		statusAPI.Handle(path, handler)
+	}
+	var handler http.Handler = statusAPI
+
+	switch cfg.StatusAPIAuthentication {
+	case config.StatusAPIAuthenticationNone:
+		statusAPI.Handle("/loglevel", operatorLevel.ReadOnly())
+	case config.StatusAPIAuthenticationTokenReview:
+		authenticator, err := status.NewAuthenticator(k8sManager.GetConfig(), k8sManager.GetHTTPClient())
+		if err != nil {
+			return fmt.Errorf("failed to create authenticator: %w", err)
+		}
+		for _, namespaceHandler := range handlers {
+			namespaceHandler.SetAuthorizer(authenticator)
+		}
+		// changing the log level requires the permission to put the non-resource URL /loglevel
+		statusAPI.Handle("/loglevel", operatorLevel)
+		handler = authenticator.Filter(statusAPI)
+	default:
+		return fmt.Errorf("unknown status API authentication %q", cfg.StatusAPIAuthentication)
+	}
+
+	return k8sManager.Add(&manager.Server{
+		Name: "status-api",
+		Server: &http.Server{
+			Addr:              cfg.StatusBindAddress,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	})
+}
+
+// namespaceExists returns a check whether a namespace exists.
+func namespaceExists(namespaces corev1.NamespaceInterface) func(ctx context.Context, namespace string) (bool, error) {
+	return func(ctx context.Context, namespace string) (bool, error) {
+		_, err := namespaces.Get(ctx, namespace, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+}
+
 func startK8sManager(ctx context.Context, k8sManager controllerManager) error {
 	logger := log.FromContext(ctx).WithName("k8s-manager-start")
 	logger.Info("starting manager")
@@ -204,13 +468,20 @@ func startK8sManager(ctx context.Context, k8sManager controllerManager) error {
 	return nil
 }
 
-func addChecks(mgr manager.Manager) error {
-	err := mgr.AddHealthzCheck("healthz", healthz.Ping)
+// addChecks adds a liveness check detecting a stuck reconciler and readiness checks for the informers of the
+// DebugModes and Dogus and for the access to the dogu version registry.
+func addChecks(mgr manager.Manager, registries doguRegistries, watchdog *health.Watchdog) error {
+	err := mgr.AddHealthzCheck("reconcile", watchdog.Check)
 	if err != nil {
 		return fmt.Errorf("failed to add healthz check: %w", err)
 	}
 
-	err = mgr.AddReadyzCheck("readyz", healthz.Ping)
+	err = mgr.AddReadyzCheck("informers", health.CacheSynced(mgr.GetCache(), &k8scloudogucomv1.DebugMode{}, &doguv2.Dogu{}))
+	if err != nil {
+		return fmt.Errorf("failed to add readyz check: %w", err)
+	}
+
+	err = mgr.AddReadyzCheck("dogu-registry", health.RegistryAccessible(registries))
 	if err != nil {
 		return fmt.Errorf("failed to add readyz check: %w", err)
 	}